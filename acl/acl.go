@@ -0,0 +1,114 @@
+// Package acl is a rule engine for access control lists on top of an interval tree.
+//
+// Every rule matches an interval, e.g. an IP range or a port range, and carries a priority and an action.
+// [ACL.Evaluate] finds all rules whose interval covers the probe, the rule with the highest priority wins,
+// rules with the same priority are resolved first-match, in the order they were added.
+//
+//	a := acl.New(cmpRange)
+//	a.Add(
+//		acl.Rule[Range]{Match: any4, Priority: 0, Action: acl.Deny},
+//		acl.Rule[Range]{Match: lan, Priority: 10, Action: acl.Permit},
+//	)
+//	action, rule, ok := a.Evaluate(probe)
+package acl
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/gaissmai/interval"
+)
+
+// Action is the decision of a rule.
+type Action uint8
+
+const (
+	Deny   Action = iota // reject the probe
+	Permit               // accept the probe
+)
+
+// String implements fmt.Stringer.
+func (a Action) String() string {
+	switch a {
+	case Deny:
+		return "deny"
+	case Permit:
+		return "permit"
+	default:
+		return "action(" + strconv.Itoa(int(a)) + ")"
+	}
+}
+
+// Rule matches all probes covered by Match, higher priorities win.
+type Rule[T any] struct {
+	Match    T
+	Priority int
+	Action   Action
+}
+
+// entry, a rule with its insertion sequence for first-match.
+type entry[T any] struct {
+	Rule[T]
+	seq uint64
+}
+
+// ACL is an ordered set of rules. An ACL is not safe for concurrent use.
+type ACL[T any] struct {
+	tree *interval.Tree2[T, []entry[T]]
+	seq  uint64
+}
+
+// New returns an empty ACL with the compare function for the intervals and the options, see [interval.New].
+func New[T any](cmp func(a, b T) (ll, rr, lr, rl int), opts ...interval.Option) *ACL[T] {
+	return &ACL[T]{tree: interval.NewTree2[T, []entry[T]](cmp, opts...)}
+}
+
+// Add appends the rules, for the same priority the rules added first match first.
+// Many rules may have the same interval.
+func (a *ACL[T]) Add(rules ...Rule[T]) {
+	for _, r := range rules {
+		a.seq++
+		entries, _ := a.tree.Get(r.Match)
+		a.tree.Put(r.Match, append(slices.Clip(entries), entry[T]{r, a.seq}))
+	}
+}
+
+// Remove deletes the first rule with the same interval, priority and action,
+// returns true if such a rule exists, false otherwise.
+func (a *ACL[T]) Remove(rule Rule[T]) bool {
+	entries, ok := a.tree.Get(rule.Match)
+	if !ok {
+		return false
+	}
+
+	i := slices.IndexFunc(entries, func(e entry[T]) bool {
+		return e.Priority == rule.Priority && e.Action == rule.Action
+	})
+	if i < 0 {
+		return false
+	}
+
+	if len(entries) == 1 {
+		return a.tree.DeleteKey(rule.Match)
+	}
+
+	a.tree.Put(rule.Match, slices.Delete(slices.Clone(entries), i, i+1))
+	return true
+}
+
+// Evaluate returns the action and the rule that decides the probe: of all rules with an interval
+// covering the probe, the one with the highest priority, the first added for equal priorities.
+// If no rule covers the probe, ok is false and the caller decides the default action.
+func (a *ACL[T]) Evaluate(probe T) (action Action, rule Rule[T], ok bool) {
+	var best entry[T]
+
+	for _, e := range a.tree.Covers(probe) {
+		for _, cand := range e.Val {
+			if !ok || cand.Priority > best.Priority || cand.Priority == best.Priority && cand.seq < best.seq {
+				best, ok = cand, true
+			}
+		}
+	}
+
+	return best.Action, best.Rule, ok
+}
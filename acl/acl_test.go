@@ -0,0 +1,64 @@
+package acl_test
+
+import (
+	"testing"
+
+	"github.com/gaissmai/interval/acl"
+)
+
+type portRange [2]uint16
+
+func cmpPortRange(a, b portRange) (ll, rr, lr, rl int) {
+	return cmp2(a[0], b[0]), cmp2(a[1], b[1]), cmp2(a[0], b[1]), cmp2(a[1], b[0])
+}
+
+func cmp2(a, b uint16) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	a := acl.New(cmpPortRange)
+	a.Add(
+		acl.Rule[portRange]{Match: portRange{0, 65535}, Priority: 0, Action: acl.Deny},
+		acl.Rule[portRange]{Match: portRange{1024, 65535}, Priority: 10, Action: acl.Permit},
+		acl.Rule[portRange]{Match: portRange{6000, 6063}, Priority: 10, Action: acl.Deny}, // shadowed, same prio, added later
+		acl.Rule[portRange]{Match: portRange{6000, 6063}, Priority: 20, Action: acl.Deny},
+		acl.Rule[portRange]{Match: portRange{6000, 6063}, Priority: 20, Action: acl.Permit}, // shadowed, same prio, same interval
+	)
+
+	tests := []struct {
+		port uint16
+		want acl.Action
+		prio int
+	}{
+		{22, acl.Deny, 0},
+		{8080, acl.Permit, 10},
+		{6010, acl.Deny, 20},
+	}
+
+	for _, tt := range tests {
+		action, rule, ok := a.Evaluate(portRange{tt.port, tt.port})
+		if !ok || action != tt.want || rule.Priority != tt.prio {
+			t.Errorf("Evaluate(%d), got %v %v %v, want %v prio %d", tt.port, action, rule, ok, tt.want, tt.prio)
+		}
+	}
+
+	if !a.Remove(acl.Rule[portRange]{Match: portRange{6000, 6063}, Priority: 20, Action: acl.Deny}) {
+		t.Fatal("Remove, got false, want true")
+	}
+	if action, _, _ := a.Evaluate(portRange{6010, 6010}); action != acl.Permit {
+		t.Errorf("Evaluate(6010) after Remove, got %v, want %v", action, acl.Permit)
+	}
+
+	if _, _, ok := acl.New(cmpPortRange).Evaluate(portRange{80, 80}); ok {
+		t.Error("Evaluate on empty ACL, got true, want false")
+	}
+}
@@ -0,0 +1,47 @@
+package interval
+
+// AppendIntersections appends all intervals that intersect with item to dst in sorted order and
+// returns the extended slice, see [Tree.Intersections]. Repeated queries with a reused or
+// preallocated dst avoid the allocations for the result.
+//
+//	buf = tree.AppendIntersections(buf[:0], item)
+func (t Tree[T]) AppendIntersections(dst []T, item T) []T {
+	if t.metrics() != nil {
+		report := t.observe("AppendIntersections")
+		defer func(start int) { report(len(dst) - start) }(len(dst))
+	}
+
+	t.intersectionsFn(t.root, item, func(hit *T) bool {
+		dst = append(dst, *hit)
+		return true
+	})
+	return dst
+}
+
+// AppendCovers appends all intervals that cover the item to dst in sorted order and returns
+// the extended slice, see [Tree.Covers] and [Tree.AppendIntersections].
+func (t Tree[T]) AppendCovers(dst []T, item T) []T {
+	if t.metrics() != nil {
+		report := t.observe("AppendCovers")
+		defer func(start int) { report(len(dst) - start) }(len(dst))
+	}
+
+	t.covers(t.root, item, func(hit *T) {
+		dst = append(dst, *hit)
+	})
+	return dst
+}
+
+// AppendCoveredBy appends all intervals that are covered by item to dst in sorted order and returns
+// the extended slice, see [Tree.CoveredBy] and [Tree.AppendIntersections].
+func (t Tree[T]) AppendCoveredBy(dst []T, item T) []T {
+	if t.metrics() != nil {
+		report := t.observe("AppendCoveredBy")
+		defer func(start int) { report(len(dst) - start) }(len(dst))
+	}
+
+	t.coveredBy(t.root, item, func(hit *T) {
+		dst = append(dst, *hit)
+	})
+	return dst
+}
@@ -0,0 +1,47 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestAppendQueries(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+	prefix := []uintInterval{{1, 2}}
+
+	for _, probe := range genUintIvals(100) {
+		for _, tt := range []struct {
+			name   string
+			query  func(uintInterval) []uintInterval
+			append func([]uintInterval, uintInterval) []uintInterval
+		}{
+			{"Intersections", tree.Intersections, tree.AppendIntersections},
+			{"Covers", tree.Covers, tree.AppendCovers},
+			{"CoveredBy", tree.CoveredBy, tree.AppendCoveredBy},
+		} {
+			want := append(slices.Clone(prefix), tt.query(probe)...)
+			if got := tt.append(slices.Clone(prefix), probe); !slices.Equal(got, want) {
+				t.Fatalf("Append%s(%v), got %v, want %v", tt.name, probe, got, want)
+			}
+		}
+	}
+}
+
+func TestAppendIntersectionsAllocs(t *testing.T) {
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+	probe := genUintIvals(1)[0]
+
+	buf := make([]uintInterval, 0, 10_000)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = tree.AppendIntersections(buf[:0], probe)
+	})
+
+	// the result fits into buf
+	if allocs > 0 {
+		t.Errorf("AppendIntersections, got %v allocs, want 0", allocs)
+	}
+}
@@ -0,0 +1,98 @@
+package interval
+
+import "sync"
+
+// arenaChunkSize, the number of nodes allocated at once.
+const arenaChunkSize = 1024
+
+// arena hands out nodes from preallocated chunks.
+// Safe for concurrent use, immutable operations on the same tree may run concurrently.
+type arena[T any] struct {
+	mu    sync.Mutex
+	chunk []node[T]  // the unused rest of the current chunk
+	free  []*node[T] // recycled nodes, see Tree.Recycle
+}
+
+// alloc returns a zeroed node, a recycled one or from the current chunk,
+// a new chunk is allocated if exhausted.
+func (a *arena[T]) alloc() *node[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if k := len(a.free); k > 0 {
+		n := a.free[k-1]
+		a.free[k-1] = nil
+		a.free = a.free[:k-1]
+
+		*n = node[T]{}
+		return n
+	}
+
+	if len(a.chunk) == 0 {
+		a.chunk = make([]node[T], arenaChunkSize)
+	}
+
+	n := &a.chunk[0]
+	a.chunk = a.chunk[1:]
+
+	return n
+}
+
+// Recycle returns the nodes of old that are not part of t to the arena of t,
+// subsequent inserts reuse them instead of allocating new ones. Returns the number
+// of recycled nodes, 0 if the trees don't share an arena, see [WithArena].
+//
+// old must be a predecessor of t, t derived from old by immutable operations or by
+// mutations of a clone. The nodes are found by a descent from the changed paths,
+// in O(k log n) for k recycled nodes.
+//
+// Recycle is unsafe: old and every other tree sharing the recycled nodes, e.g. a clone
+// of old, an older version still in use or pointers from [Tree.ItemsRef], must never
+// be used again. Concurrent readers of old must have finished, use a grace period,
+// e.g. the one of the rcu package. Recycle predecessors oldest first.
+func (t *Tree[T]) Recycle(old *Tree[T]) int {
+	if t.arena == nil || old == nil || old.arena != t.arena || old.root == t.root {
+		return 0
+	}
+
+	var garbage []*node[T]
+	t.collect(old.root, &garbage)
+
+	t.arena.mu.Lock()
+	t.arena.free = append(t.arena.free, garbage...)
+	t.arena.mu.Unlock()
+
+	return len(garbage)
+}
+
+// collect, the nodes in the subtree of n not reachable in t. If n is part of t,
+// its subtree is shared with t and unchanged, published nodes are never modified.
+func (t *Tree[T]) collect(n *node[T], garbage *[]*node[T]) {
+	if n == nil || t.contains(n) {
+		return
+	}
+
+	*garbage = append(*garbage, n)
+	t.collect(n.left, garbage)
+	t.collect(n.right, garbage)
+}
+
+// contains, true if the node n is part of the tree, found by its item.
+func (t *Tree[T]) contains(n *node[T]) bool {
+	m := t.root
+	for m != nil {
+		if m == n {
+			return true
+		}
+
+		switch c := t.compare(n.item, m.item); {
+		case c < 0:
+			m = m.left
+		case c > 0:
+			m = m.right
+		default:
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+//go:build interval_inline && !interval_maxonly
+
+package interval
+
+// augmentMinUpper, the min upper values are maintained, see augment_maxonly_*.go.
+const augmentMinUpper = true
+
+// augment, the augmented upper bounds of the subtree stored inline as values.
+//
+// Build with the tag 'interval_inline' to select this representation.
+// It trades the memory for two additional items per node for less pointer chasing
+// and a simpler GC graph, the augmentation doesn't point to other nodes.
+type augment[T any] struct {
+	minUpper T // item in subtree with min upper value
+	maxUpper T // item in subtree with max upper value
+}
+
+// minUpperItem returns the item in subtree with min upper value.
+func (n *node[T]) minUpperItem() T {
+	return n.minUpper
+}
+
+// maxUpperItem returns the item in subtree with max upper value.
+func (n *node[T]) maxUpperItem() T {
+	return n.maxUpper
+}
+
+// recalc the augmented fields in treap node after each creation/modification with values in descendants.
+// Only one level deeper must be considered. The treap datastructure is very easy to augment.
+func (t *Tree[T]) recalc(n *node[T]) {
+	if n == nil || t.deferred {
+		return
+	}
+
+	// start with upper min/max as self
+	n.minUpper = n.item
+	n.maxUpper = n.item
+
+	if n.right != nil {
+		if t.cmpRR(n.minUpper, n.right.minUpper) > 0 {
+			n.minUpper = n.right.minUpper
+		}
+
+		if t.cmpRR(n.maxUpper, n.right.maxUpper) < 0 {
+			n.maxUpper = n.right.maxUpper
+		}
+	}
+
+	if n.left != nil {
+		if t.cmpRR(n.minUpper, n.left.minUpper) > 0 {
+			n.minUpper = n.left.minUpper
+		}
+
+		if t.cmpRR(n.maxUpper, n.left.maxUpper) < 0 {
+			n.maxUpper = n.left.maxUpper
+		}
+	}
+
+	n.resize()
+	t.rehash(n)
+}
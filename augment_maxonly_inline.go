@@ -0,0 +1,43 @@
+//go:build interval_maxonly && interval_inline
+
+package interval
+
+// augmentMinUpper, the min upper values are not maintained, see augment_maxonly_ptr.go.
+const augmentMinUpper = false
+
+// augment, the augmented max upper bound of the subtree stored inline as value.
+type augment[T any] struct {
+	maxUpper T // item in subtree with max upper value
+}
+
+// minUpperItem, not maintained, must not be called, check augmentMinUpper first.
+func (n *node[T]) minUpperItem() T {
+	panic("unreachable")
+}
+
+// maxUpperItem returns the item in subtree with max upper value.
+func (n *node[T]) maxUpperItem() T {
+	return n.maxUpper
+}
+
+// recalc the augmented fields in treap node after each creation/modification with values in descendants.
+// Only one level deeper must be considered. The treap datastructure is very easy to augment.
+func (t *Tree[T]) recalc(n *node[T]) {
+	if n == nil || t.deferred {
+		return
+	}
+
+	// start with upper max as self
+	n.maxUpper = n.item
+
+	if n.right != nil && t.cmpRR(n.maxUpper, n.right.maxUpper) < 0 {
+		n.maxUpper = n.right.maxUpper
+	}
+
+	if n.left != nil && t.cmpRR(n.maxUpper, n.left.maxUpper) < 0 {
+		n.maxUpper = n.left.maxUpper
+	}
+
+	n.resize()
+	t.rehash(n)
+}
@@ -0,0 +1,47 @@
+//go:build interval_maxonly && !interval_inline
+
+package interval
+
+// augmentMinUpper, the min upper values are not maintained.
+//
+// Build with the tag 'interval_maxonly' to select this representation. The nodes are one
+// pointer smaller, the recalc one comparison cheaper. CoveredBy, Precedes and their
+// variants can't prune by the min upper value anymore, they are slower.
+const augmentMinUpper = false
+
+// augment, the augmented max upper bound of the subtree as pointer to the node.
+type augment[T any] struct {
+	maxUpper *node[T] // pointer to node in subtree with max upper value
+}
+
+// minUpperItem, not maintained, must not be called, check augmentMinUpper first.
+func (n *node[T]) minUpperItem() T {
+	panic("unreachable")
+}
+
+// maxUpperItem returns the item in subtree with max upper value.
+func (n *node[T]) maxUpperItem() T {
+	return n.maxUpper.item
+}
+
+// recalc the augmented fields in treap node after each creation/modification with values in descendants.
+// Only one level deeper must be considered. The treap datastructure is very easy to augment.
+func (t *Tree[T]) recalc(n *node[T]) {
+	if n == nil || t.deferred {
+		return
+	}
+
+	// start with upper max pointing to self
+	n.maxUpper = n
+
+	if n.right != nil && t.cmpRR(n.maxUpper.item, n.right.maxUpper.item) < 0 {
+		n.maxUpper = n.right.maxUpper
+	}
+
+	if n.left != nil && t.cmpRR(n.maxUpper.item, n.left.maxUpper.item) < 0 {
+		n.maxUpper = n.left.maxUpper
+	}
+
+	n.resize()
+	t.rehash(n)
+}
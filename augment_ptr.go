@@ -0,0 +1,57 @@
+//go:build !interval_inline && !interval_maxonly
+
+package interval
+
+// augmentMinUpper, the min upper values are maintained, see augment_maxonly_*.go.
+const augmentMinUpper = true
+
+// augment, the augmented upper bounds of the subtree as pointers to the nodes.
+type augment[T any] struct {
+	minUpper *node[T] // pointer to node in subtree with min upper value
+	maxUpper *node[T] // pointer to node in subtree with max upper value
+}
+
+// minUpperItem returns the item in subtree with min upper value.
+func (n *node[T]) minUpperItem() T {
+	return n.minUpper.item
+}
+
+// maxUpperItem returns the item in subtree with max upper value.
+func (n *node[T]) maxUpperItem() T {
+	return n.maxUpper.item
+}
+
+// recalc the augmented fields in treap node after each creation/modification with values in descendants.
+// Only one level deeper must be considered. The treap datastructure is very easy to augment.
+func (t *Tree[T]) recalc(n *node[T]) {
+	if n == nil || t.deferred {
+		return
+	}
+
+	// start with upper min/max pointing to self
+	n.minUpper = n
+	n.maxUpper = n
+
+	if n.right != nil {
+		if t.cmpRR(n.minUpper.item, n.right.minUpper.item) > 0 {
+			n.minUpper = n.right.minUpper
+		}
+
+		if t.cmpRR(n.maxUpper.item, n.right.maxUpper.item) < 0 {
+			n.maxUpper = n.right.maxUpper
+		}
+	}
+
+	if n.left != nil {
+		if t.cmpRR(n.minUpper.item, n.left.minUpper.item) > 0 {
+			n.minUpper = n.left.minUpper
+		}
+
+		if t.cmpRR(n.maxUpper.item, n.left.maxUpper.item) < 0 {
+			n.maxUpper = n.left.maxUpper
+		}
+	}
+
+	n.resize()
+	t.rehash(n)
+}
@@ -0,0 +1,188 @@
+// Package bed is an adapter for genomic intervals in the BED format.
+//
+// BED records are parsed into chromosome-tagged intervals and indexed in one interval tree per chromosome.
+// The BED coordinates are zero-based and half-open [chromStart, chromEnd), the trees are created
+// with [interval.WithHalfOpen], features that just abut don't intersect.
+//
+//	records, err := bed.Parse(r)
+//	idx := bed.NewIndex(records...)
+//	hits := idx.Intersections("chr1", 11_873, 14_409)
+package bed
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gaissmai/interval"
+)
+
+// Record is a BED record, the chromosome with the half-open interval [Start, End).
+type Record struct {
+	Chrom  string
+	Start  uint64
+	End    uint64
+	Fields []string // optional columns after chromEnd, e.g. name, score, strand
+}
+
+// Name returns the optional name column, or the empty string.
+func (r Record) Name() string {
+	if len(r.Fields) == 0 {
+		return ""
+	}
+	return r.Fields[0]
+}
+
+// String implements fmt.Stringer.
+func (r Record) String() string {
+	return fmt.Sprintf("%s:%d-%d", r.Chrom, r.Start, r.End)
+}
+
+// Parse reads the BED records from r. Header lines (track, browser) and comments are skipped.
+// The columns are separated by tabs, or by whitespace if a line has no tabs.
+func Parse(r io.Reader) ([]Record, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+
+	var records []Record
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimRight(sc.Text(), "\r")
+
+		if line == "" || strings.HasPrefix(line, "#") ||
+			strings.HasPrefix(line, "track") || strings.HasPrefix(line, "browser") {
+			continue
+		}
+
+		rec, err := parseLine(line)
+		if err != nil {
+			return records, fmt.Errorf("bed: line %d: %w", lineNo, err)
+		}
+		records = append(records, rec)
+	}
+
+	return records, sc.Err()
+}
+
+// parseLine, parses a single BED line.
+func parseLine(line string) (rec Record, err error) {
+	fields := strings.Split(line, "\t")
+	if len(fields) == 1 {
+		fields = strings.Fields(line)
+	}
+
+	if len(fields) < 3 {
+		return rec, fmt.Errorf("want at least 3 columns, got %d", len(fields))
+	}
+
+	rec.Chrom = fields[0]
+
+	if rec.Start, err = strconv.ParseUint(fields[1], 10, 64); err != nil {
+		return rec, fmt.Errorf("chromStart: %w", err)
+	}
+
+	if rec.End, err = strconv.ParseUint(fields[2], 10, 64); err != nil {
+		return rec, fmt.Errorf("chromEnd: %w", err)
+	}
+
+	if rec.Start > rec.End {
+		return rec, fmt.Errorf("chromStart %d is greater than chromEnd %d", rec.Start, rec.End)
+	}
+
+	if len(fields) > 3 {
+		rec.Fields = fields[3:]
+	}
+
+	return rec, nil
+}
+
+// region, the interval key of a record, the records with the same region share a key.
+type region = [2]uint64
+
+// cmpRegion, the compare function for the regions.
+func cmpRegion(a, b region) (ll, rr, lr, rl int) {
+	return cmp.Compare(a[0], b[0]),
+		cmp.Compare(a[1], b[1]),
+		cmp.Compare(a[0], b[1]),
+		cmp.Compare(a[1], b[0])
+}
+
+// Index is a set of interval trees, one per chromosome.
+// The zero value is not usable, create it with [NewIndex].
+type Index struct {
+	trees map[string]*interval.Tree2[region, []Record]
+}
+
+// NewIndex returns an index with the records.
+func NewIndex(records ...Record) *Index {
+	idx := &Index{trees: make(map[string]*interval.Tree2[region, []Record])}
+	idx.Insert(records...)
+	return idx
+}
+
+// Insert adds the records to the index. Records with the same chromosome and region are all kept.
+func (idx *Index) Insert(records ...Record) {
+	for _, rec := range records {
+		tree, ok := idx.trees[rec.Chrom]
+		if !ok {
+			tree = interval.NewTree2[region, []Record](cmpRegion, interval.WithHalfOpen())
+			idx.trees[rec.Chrom] = tree
+		}
+
+		key := region{rec.Start, rec.End}
+		recs, _ := tree.Get(key)
+		tree.Put(key, append(recs, rec))
+	}
+}
+
+// Chroms returns the sorted chromosome names in the index.
+func (idx *Index) Chroms() []string {
+	chroms := make([]string, 0, len(idx.trees))
+	for chrom := range idx.trees {
+		chroms = append(chroms, chrom)
+	}
+	slices.Sort(chroms)
+	return chroms
+}
+
+// Intersects returns true if any record on chrom intersects the region [start, end).
+func (idx *Index) Intersects(chrom string, start, end uint64) bool {
+	tree, ok := idx.trees[chrom]
+	if !ok {
+		return false
+	}
+	return tree.Intersects(region{start, end})
+}
+
+// Intersections returns all records on chrom that intersect the region [start, end),
+// sorted by start position.
+func (idx *Index) Intersections(chrom string, start, end uint64) []Record {
+	tree, ok := idx.trees[chrom]
+	if !ok {
+		return nil
+	}
+
+	var result []Record
+	for _, e := range tree.Intersections(region{start, end}) {
+		result = append(result, e.Val...)
+	}
+	return result
+}
+
+// CoveredBy returns all records on chrom that lie completely within the region [start, end),
+// sorted by start position.
+func (idx *Index) CoveredBy(chrom string, start, end uint64) []Record {
+	tree, ok := idx.trees[chrom]
+	if !ok {
+		return nil
+	}
+
+	var result []Record
+	for _, e := range tree.CoveredBy(region{start, end}) {
+		result = append(result, e.Val...)
+	}
+	return result
+}
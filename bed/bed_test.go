@@ -0,0 +1,91 @@
+package bed_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gaissmai/interval/bed"
+)
+
+const bedFile = `track name=genes
+# comment
+chr1	11873	14409	DDX11L1	0	+
+chr1	14361	29370	WASH7P	0	-
+chr1	29370	31000	abutting
+chr1	11873	14409	DDX11L1-dupe
+chr2	100	200
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	records, err := bed.Parse(strings.NewReader(bedFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 5 {
+		t.Fatalf("Parse(), got: %d records, want: 5", len(records))
+	}
+
+	want := bed.Record{Chrom: "chr1", Start: 11873, End: 14409, Fields: []string{"DDX11L1", "0", "+"}}
+	if !reflect.DeepEqual(records[0], want) {
+		t.Fatalf("Parse(), got: %#v, want: %#v", records[0], want)
+	}
+
+	if _, err := bed.Parse(strings.NewReader("chr1\t200\t100\n")); err == nil {
+		t.Fatal("Parse(start > end), got: nil, want: error")
+	}
+
+	if _, err := bed.Parse(strings.NewReader("chr1 100 x\n")); err == nil {
+		t.Fatal("Parse(bad chromEnd), got: nil, want: error")
+	}
+}
+
+func TestIndex(t *testing.T) {
+	t.Parallel()
+
+	records, err := bed.Parse(strings.NewReader(bedFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idx := bed.NewIndex(records...)
+
+	if got := idx.Chroms(); !reflect.DeepEqual(got, []string{"chr1", "chr2"}) {
+		t.Fatalf("Chroms(), got: %v", got)
+	}
+
+	names := func(recs []bed.Record) (s []string) {
+		for _, r := range recs {
+			s = append(s, r.Name())
+		}
+		return
+	}
+
+	// half-open, WASH7P ends where 'abutting' starts
+	if got, want := names(idx.Intersections("chr1", 14000, 29370)), []string{"DDX11L1", "DDX11L1-dupe", "WASH7P"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Intersections(), got: %v, want: %v", got, want)
+	}
+
+	if got, want := names(idx.Intersections("chr1", 29369, 29371)), []string{"WASH7P", "abutting"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Intersections(), got: %v, want: %v", got, want)
+	}
+
+	if got, want := names(idx.CoveredBy("chr1", 14000, 31000)), []string{"WASH7P", "abutting"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("CoveredBy(), got: %v, want: %v", got, want)
+	}
+
+	if idx.Intersects("chr2", 200, 300) {
+		t.Fatal("Intersects(chr2, 200, 300), got: true, want: false")
+	}
+
+	if !idx.Intersects("chr2", 199, 300) {
+		t.Fatal("Intersects(chr2, 199, 300), got: false, want: true")
+	}
+
+	if idx.Intersects("chrX", 0, 1<<40) {
+		t.Fatal("Intersects(chrX), got: true, want: false")
+	}
+}
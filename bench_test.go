@@ -1,7 +1,9 @@
 package interval_test
 
 import (
+	"io"
 	"math/rand"
+	"runtime"
 	"testing"
 
 	"github.com/gaissmai/interval"
@@ -126,6 +128,21 @@ func BenchmarkUnion(b *testing.B) {
 	}
 }
 
+func BenchmarkUnionImmutableConcurrent(b *testing.B) {
+	this100_000 := interval.NewTree(cmpUintInterval, genUintIvals(100_000)...)
+	for n := 10; n <= 100_000; n *= 10 {
+		tree := interval.NewTree(cmpUintInterval, genUintIvals(n)...)
+		name := "size100_000with" + intMap[n]
+
+		b.Run(name, func(b *testing.B) {
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				_ = this100_000.UnionImmutableConcurrent(runtime.NumCPU(), tree, false)
+			}
+		})
+	}
+}
+
 func BenchmarkIntersects(b *testing.B) {
 	for n := 1; n <= 1_000_000; n *= 10 {
 		ivals := genUintIvals(n)
@@ -368,3 +385,17 @@ func BenchmarkMax(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkFprint(b *testing.B) {
+	for n := 1; n <= 100_000; n *= 10 {
+		tree := interval.NewTree(cmpUintInterval, genUintIvals(n)...)
+		name := intMap[n]
+
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				_ = tree.Fprint(io.Discard)
+			}
+		})
+	}
+}
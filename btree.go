@@ -0,0 +1,198 @@
+package interval
+
+// BTree is an adapter with the method names of google/btree (BTreeG) over the interval tree,
+// for projects migrating from a plain btree to interval-aware lookups. The items are ordered
+// by the left point, supersets first, see [NewTree]. The interval queries are available on [BTree.Tree].
+//
+// A BTree is not safe for concurrent use, like [Tree].
+type BTree[T any] struct {
+	tree *Tree[T]
+}
+
+// NewBTree returns an empty adapter, the tree is created with the compare function and the options, see [New].
+func NewBTree[T any](cmp func(a, b T) (ll, rr, lr, rl int), opts ...Option) *BTree[T] {
+	return &BTree[T]{tree: New(cmp, opts...)}
+}
+
+// ReplaceOrInsert adds the item, an equal item is replaced and returned with true.
+func (b *BTree[T]) ReplaceOrInsert(item T) (old T, replaced bool) {
+	if n := b.tree.find(item); n != nil {
+		old, replaced = n.item, true
+	}
+	b.tree.insertInPlace(item)
+	return
+}
+
+// Delete removes an item equal to the passed in item and returns it with true.
+func (b *BTree[T]) Delete(item T) (old T, ok bool) {
+	n := b.tree.find(item)
+	if n == nil {
+		return
+	}
+	old = n.item
+	return old, b.tree.deleteInPlace(item)
+}
+
+// DeleteMin removes the smallest item and returns it with true, false if the tree is empty.
+func (b *BTree[T]) DeleteMin() (old T, ok bool) {
+	if b.tree.min == nil {
+		return
+	}
+	return b.Delete(b.tree.min.item)
+}
+
+// DeleteMax removes the largest item and returns it with true, false if the tree is empty.
+func (b *BTree[T]) DeleteMax() (old T, ok bool) {
+	if b.tree.max == nil {
+		return
+	}
+	return b.Delete(b.tree.max.item)
+}
+
+// Get returns the item equal to key and true, false if not found.
+func (b *BTree[T]) Get(key T) (item T, ok bool) {
+	return b.tree.Find(key)
+}
+
+// Has returns true if an item equal to key exists.
+func (b *BTree[T]) Has(key T) bool {
+	return b.tree.find(key) != nil
+}
+
+// Len returns the number of items.
+func (b *BTree[T]) Len() int {
+	if b.tree.root == nil {
+		return 0
+	}
+	return b.tree.root.size
+}
+
+// Min returns the smallest item and true, false if the tree is empty.
+func (b *BTree[T]) Min() (item T, ok bool) {
+	if b.tree.min == nil {
+		return
+	}
+	return b.tree.min.item, true
+}
+
+// Max returns the largest item and true, false if the tree is empty.
+func (b *BTree[T]) Max() (item T, ok bool) {
+	if b.tree.max == nil {
+		return
+	}
+	return b.tree.max.item, true
+}
+
+// Clear removes all items.
+func (b *BTree[T]) Clear() {
+	b.tree.acquire()
+	b.tree.root = nil
+	b.tree.changed()
+}
+
+// Clone returns a copy in O(1), see [Tree.Clone].
+func (b *BTree[T]) Clone() *BTree[T] {
+	return &BTree[T]{tree: b.tree.Clone()}
+}
+
+// Tree returns a snapshot of the tree in O(1) for the interval queries, see [Tree.Clone].
+// Changes of the snapshot don't affect b.
+func (b *BTree[T]) Tree() *Tree[T] {
+	return b.tree.Clone()
+}
+
+// Ascend calls iter for all items in ascending order, until iter returns false.
+func (b *BTree[T]) Ascend(iter func(item T) bool) {
+	b.ascend(b.tree.root, nil, nil, iter)
+}
+
+// AscendRange calls iter for the items in the range [greaterOrEqual, lessThan) in ascending order,
+// until iter returns false.
+func (b *BTree[T]) AscendRange(greaterOrEqual, lessThan T, iter func(item T) bool) {
+	b.ascend(b.tree.root, &greaterOrEqual, &lessThan, iter)
+}
+
+// AscendGreaterOrEqual calls iter for the items in the range [pivot, last] in ascending order,
+// until iter returns false.
+func (b *BTree[T]) AscendGreaterOrEqual(pivot T, iter func(item T) bool) {
+	b.ascend(b.tree.root, &pivot, nil, iter)
+}
+
+// AscendLessThan calls iter for the items in the range [first, pivot) in ascending order,
+// until iter returns false.
+func (b *BTree[T]) AscendLessThan(pivot T, iter func(item T) bool) {
+	b.ascend(b.tree.root, nil, &pivot, iter)
+}
+
+// Descend calls iter for all items in descending order, until iter returns false.
+func (b *BTree[T]) Descend(iter func(item T) bool) {
+	b.descend(b.tree.root, nil, nil, iter)
+}
+
+// DescendRange calls iter for the items in the range [lessOrEqual, greaterThan) in descending order,
+// until iter returns false.
+func (b *BTree[T]) DescendRange(lessOrEqual, greaterThan T, iter func(item T) bool) {
+	b.descend(b.tree.root, &lessOrEqual, &greaterThan, iter)
+}
+
+// DescendLessOrEqual calls iter for the items in the range [pivot, first] in descending order,
+// until iter returns false.
+func (b *BTree[T]) DescendLessOrEqual(pivot T, iter func(item T) bool) {
+	b.descend(b.tree.root, &pivot, nil, iter)
+}
+
+// DescendGreaterThan calls iter for the items in the range [last, pivot) in descending order,
+// until iter returns false.
+func (b *BTree[T]) DescendGreaterThan(pivot T, iter func(item T) bool) {
+	b.descend(b.tree.root, nil, &pivot, iter)
+}
+
+// ascend rec-descent, in-order traversal of [lo, hi), nil bounds are unbounded.
+func (b *BTree[T]) ascend(n *node[T], lo, hi *T, iter func(item T) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	aboveLo := lo == nil || b.tree.compare(n.item, *lo) >= 0
+	belowHi := hi == nil || b.tree.compare(n.item, *hi) < 0
+
+	// left subtree only if n is greater than lo
+	if aboveLo && !b.ascend(n.left, lo, hi, iter) {
+		return false
+	}
+
+	if aboveLo && belowHi && !iter(n.item) {
+		return false
+	}
+
+	// right subtree only if n is less than hi
+	if belowHi {
+		return b.ascend(n.right, lo, hi, iter)
+	}
+	return true
+}
+
+// descend rec-descent, reverse in-order traversal of (lo, hi], nil bounds are unbounded.
+func (b *BTree[T]) descend(n *node[T], hi, lo *T, iter func(item T) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	belowHi := hi == nil || b.tree.compare(n.item, *hi) <= 0
+	aboveLo := lo == nil || b.tree.compare(n.item, *lo) > 0
+
+	// right subtree only if n is less than hi
+	if belowHi && !b.descend(n.right, hi, lo, iter) {
+		return false
+	}
+
+	if belowHi && aboveLo && !iter(n.item) {
+		return false
+	}
+
+	// left subtree only if n is greater than lo
+	if aboveLo {
+		return b.descend(n.left, hi, lo, iter)
+	}
+	return true
+}
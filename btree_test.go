@@ -0,0 +1,102 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestBTree(t *testing.T) {
+	t.Parallel()
+
+	b := interval.NewBTree(cmpUintInterval)
+
+	if _, ok := b.Min(); ok || b.Len() != 0 {
+		t.Fatal("empty BTree, Min() or Len() is wrong")
+	}
+
+	for _, item := range ps {
+		b.ReplaceOrInsert(item)
+	}
+
+	// sorted and deduplicated
+	want := sortedItems(interval.NewTree(cmpUintInterval, ps...))
+
+	if b.Len() != len(want) {
+		t.Fatalf("Len(), got: %d, want: %d", b.Len(), len(want))
+	}
+
+	if old, ok := b.ReplaceOrInsert(want[3]); !ok || old != want[3] || b.Len() != len(want) {
+		t.Fatalf("ReplaceOrInsert(existing), got: %v, %v", old, ok)
+	}
+
+	if !b.Has(want[1]) || b.Has(uintInterval{999, 1000}) {
+		t.Fatal("Has() is wrong")
+	}
+
+	collect := func(each func(iter func(uintInterval) bool)) (items []uintInterval) {
+		each(func(item uintInterval) bool {
+			items = append(items, item)
+			return true
+		})
+		return items
+	}
+
+	reversed := slices.Clone(want)
+	slices.Reverse(reversed)
+
+	lo, hi := want[2], want[len(want)-3]
+
+	tests := []struct {
+		name string
+		got  []uintInterval
+		want []uintInterval
+	}{
+		{"Ascend", collect(b.Ascend), want},
+		{"Descend", collect(b.Descend), reversed},
+		{"AscendRange", collect(func(it func(uintInterval) bool) { b.AscendRange(lo, hi, it) }), want[2 : len(want)-3]},
+		{"AscendGreaterOrEqual", collect(func(it func(uintInterval) bool) { b.AscendGreaterOrEqual(lo, it) }), want[2:]},
+		{"AscendLessThan", collect(func(it func(uintInterval) bool) { b.AscendLessThan(hi, it) }), want[:len(want)-3]},
+		{"DescendRange", collect(func(it func(uintInterval) bool) { b.DescendRange(hi, lo, it) }), reversed[2 : len(want)-3]},
+		{"DescendLessOrEqual", collect(func(it func(uintInterval) bool) { b.DescendLessOrEqual(hi, it) }), reversed[2:]},
+		{"DescendGreaterThan", collect(func(it func(uintInterval) bool) { b.DescendGreaterThan(lo, it) }), reversed[:len(want)-3]},
+	}
+
+	for _, tt := range tests {
+		if !slices.Equal(tt.got, tt.want) {
+			t.Errorf("%s(), got: %v, want: %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// early exit
+	var n int
+	b.Ascend(func(uintInterval) bool { n++; return n < 2 })
+	if n != 2 {
+		t.Errorf("Ascend() with early exit, got %d calls, want 2", n)
+	}
+
+	c := b.Clone()
+
+	if item, ok := b.DeleteMin(); !ok || item != want[0] {
+		t.Errorf("DeleteMin(), got: %v, %v, want: %v", item, ok, want[0])
+	}
+
+	if item, ok := b.DeleteMax(); !ok || item != want[len(want)-1] {
+		t.Errorf("DeleteMax(), got: %v, %v, want: %v", item, ok, want[len(want)-1])
+	}
+
+	if _, ok := b.Delete(want[0]); ok || b.Len() != len(want)-2 {
+		t.Errorf("Delete(deleted), got: %v, Len(): %d", ok, b.Len())
+	}
+
+	b.Clear()
+	if b.Len() != 0 || c.Len() != len(want) {
+		t.Errorf("Clear(), Len(): %d, clone Len(): %d", b.Len(), c.Len())
+	}
+
+	// interval queries on the snapshot
+	if got, ok := c.Tree().CoverLCP(want[1]); !ok || got != want[1] {
+		t.Errorf("Tree().CoverLCP(), got: %v, %v, want: %v", got, ok, want[1])
+	}
+}
@@ -0,0 +1,29 @@
+package interval
+
+// Builder is used for the mutable construction of a tree, the final tree is handed out by [Builder.Freeze].
+//
+// Builder is a thin wrapper over [MutTree], restricted to the construction methods.
+type Builder[T any] struct {
+	m *MutTree[T]
+}
+
+// NewBuilder returns a builder for a tree with the compare function and the options, see [New].
+func NewBuilder[T any](cmp func(a, b T) (ll, rr, lr, rl int), opts ...Option) *Builder[T] {
+	return &Builder[T]{m: NewMutTree[T](cmp, opts...)}
+}
+
+// Insert items in place, duplicates are replaced, see [MutTree.Insert].
+func (b *Builder[T]) Insert(items ...T) {
+	b.m.Insert(items...)
+}
+
+// Delete removes an item in place, returns true if it exists, false otherwise.
+func (b *Builder[T]) Delete(item T) bool {
+	return b.m.Delete(item)
+}
+
+// Freeze hands out the tree built so far in O(1), see [MutTree.Freeze].
+// The builder can be used further, the frozen tree remains unchanged.
+func (b *Builder[T]) Freeze() *Tree[T] {
+	return b.m.Freeze()
+}
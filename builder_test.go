@@ -0,0 +1,38 @@
+package interval_test
+
+import (
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(1_000)
+
+	b := interval.NewBuilder(cmpUintInterval)
+	b.Insert(ivals...)
+	b.Insert(ps...)
+
+	for _, item := range ps {
+		if !b.Delete(item) {
+			t.Fatalf("Delete(%v), got: false, want: true", item)
+		}
+	}
+
+	tree1 := b.Freeze()
+	want := interval.NewTree(cmpUintInterval, ivals...)
+
+	if !equalsSizeAndOrder(tree1, want) {
+		t.Fatal("Freeze() differs with NewTree()")
+	}
+
+	// go on building, the frozen tree must not change
+	b.Insert(ps...)
+	b.Delete(ivals[0])
+
+	if !equalsSizeAndOrder(tree1, want) {
+		t.Fatal("Builder changed the frozen tree")
+	}
+}
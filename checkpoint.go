@@ -0,0 +1,66 @@
+package interval
+
+import (
+	"errors"
+	"slices"
+)
+
+// ErrUnknownCheckpoint is returned by [Store.Restore] if there is no checkpoint with the name.
+var ErrUnknownCheckpoint = errors.New("interval: unknown checkpoint")
+
+// Checkpoint keeps the current version under name as restore point, e.g. "pre-maintenance".
+// An existing checkpoint with the same name is replaced.
+//
+// Unlike the history, checkpoints are never trimmed, they are kept until dropped
+// with [Store.DropCheckpoint].
+func (s *Store[T]) Checkpoint(name string) {
+	v := s.p.Load()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.checkpoints == nil {
+		s.checkpoints = make(map[string]Version[T])
+	}
+
+	if v == nil {
+		s.checkpoints[name] = Version[T]{}
+		return
+	}
+	s.checkpoints[name] = *v
+}
+
+// Restore publishes the tree of the checkpoint name again, as a new version labeled "restore <name>".
+func (s *Store[T]) Restore(name string) error {
+	s.mu.Lock()
+	v, ok := s.checkpoints[name]
+	s.mu.Unlock()
+
+	if !ok {
+		return ErrUnknownCheckpoint
+	}
+
+	s.Publish(v.Tree, "restore "+name)
+	return nil
+}
+
+// DropCheckpoint removes the checkpoint name, the nodes only referenced by it can be garbage collected.
+func (s *Store[T]) DropCheckpoint(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.checkpoints, name)
+}
+
+// Checkpoints returns the names of all checkpoints in sorted order.
+func (s *Store[T]) Checkpoints() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.checkpoints))
+	for name := range s.checkpoints {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,41 @@
+package interval_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestStoreCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	tree0 := interval.NewTree(cmpUintInterval, ps...)
+	want := tree0.String()
+
+	s := interval.NewStore(tree0)
+	s.Checkpoint("pre-maintenance")
+
+	s.Update(func(tree interval.Tree[uintInterval]) interval.Tree[uintInterval] {
+		tree.Insert(genUintIvals(100)...)
+		return tree
+	})
+	s.Checkpoint("post-maintenance")
+
+	if got := s.Checkpoints(); !slices.Equal(got, []string{"post-maintenance", "pre-maintenance"}) {
+		t.Fatalf("Checkpoints(), got %v", got)
+	}
+
+	if err := s.Restore("pre-maintenance"); err != nil {
+		t.Fatalf("Restore, unexpected error: %v", err)
+	}
+	if got := s.Load().String(); got != want {
+		t.Fatalf("Restore, got:\n%s\nwant:\n%s", got, want)
+	}
+
+	s.DropCheckpoint("pre-maintenance")
+	if err := s.Restore("pre-maintenance"); !errors.Is(err, interval.ErrUnknownCheckpoint) {
+		t.Fatalf("Restore dropped checkpoint, got err %v, want %v", err, interval.ErrUnknownCheckpoint)
+	}
+}
@@ -0,0 +1,125 @@
+// Package cidr is a ready-made interval tree for IP prefixes.
+//
+// The prefixes are compared by their address ranges, IPv4 and IPv6 prefixes may be mixed,
+// IPv4 sorts before IPv6. LookupIP is the longest-prefix-match of a routing table.
+//
+//	tbl, err := cidr.Parse("0.0.0.0/0", "10.0.0.0/8", "10.0.1.0/24", "::/0")
+//	pfx, ok := tbl.LookupIP(netip.MustParseAddr("10.0.1.17")) // 10.0.1.0/24, true
+package cidr
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/gaissmai/interval"
+)
+
+// Compare is the compare function for IP prefixes in an interval tree, see [interval.NewTree].
+// The prefixes must be valid, the host bits are ignored.
+func Compare(a, b netip.Prefix) (ll, rr, lr, rl int) {
+	aFirst, aLast := Range(a)
+	bFirst, bLast := Range(b)
+
+	return aFirst.Compare(bFirst), aLast.Compare(bLast), aFirst.Compare(bLast), aLast.Compare(bFirst)
+}
+
+// Range returns the first and last address of the prefix.
+func Range(pfx netip.Prefix) (first, last netip.Addr) {
+	pfx = pfx.Masked()
+	first = pfx.Addr()
+
+	a := first.As16()
+	bits := pfx.Bits()
+	if first.Is4() {
+		bits += 96
+	}
+
+	for i := bits; i < 128; i++ {
+		a[i/8] |= 1 << (7 - i%8)
+	}
+
+	last = netip.AddrFrom16(a)
+	if first.Is4() {
+		last = last.Unmap()
+	}
+	return first, last
+}
+
+// Table is an interval tree of IP prefixes.
+type Table struct {
+	tree *interval.Tree[netip.Prefix]
+}
+
+// New returns a table with the prefixes, the host bits are masked.
+func New(pfxs ...netip.Prefix) *Table {
+	t := &Table{tree: interval.New(Compare)}
+	t.Insert(pfxs...)
+	return t
+}
+
+// Parse returns a table with the prefixes parsed from strs, e.g. "10.0.0.0/8" or "2001:db8::/32".
+func Parse(strs ...string) (*Table, error) {
+	pfxs := make([]netip.Prefix, 0, len(strs))
+	for _, s := range strs {
+		pfx, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("cidr: %w", err)
+		}
+		pfxs = append(pfxs, pfx)
+	}
+	return New(pfxs...), nil
+}
+
+// MustParse is like [Parse] but panics on errors, for tests and static tables.
+func MustParse(strs ...string) *Table {
+	t, err := Parse(strs...)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Insert inserts the prefixes, the host bits are masked.
+func (t *Table) Insert(pfxs ...netip.Prefix) {
+	m := t.tree.Mutable()
+	for _, pfx := range pfxs {
+		m.Insert(pfx.Masked())
+	}
+	t.tree = m.Freeze()
+}
+
+// Delete removes the prefix, returns true if it exists, false otherwise.
+func (t *Table) Delete(pfx netip.Prefix) bool {
+	tree, ok := t.tree.DeleteImmutable(pfx.Masked())
+	t.tree = tree
+	return ok
+}
+
+// LookupIP returns the longest prefix that contains the address, the longest-prefix-match.
+// If no prefix contains addr, the zero value and false is returned.
+func (t *Table) LookupIP(addr netip.Addr) (netip.Prefix, bool) {
+	if !addr.IsValid() {
+		return netip.Prefix{}, false
+	}
+	return t.tree.CoverLCP(netip.PrefixFrom(addr, addr.BitLen()))
+}
+
+// LookupPfx returns the longest prefix that covers pfx, pfx itself if it is in the table.
+// If no prefix covers pfx, the zero value and false is returned.
+func (t *Table) LookupPfx(pfx netip.Prefix) (netip.Prefix, bool) {
+	if !pfx.IsValid() {
+		return netip.Prefix{}, false
+	}
+	return t.tree.CoverLCP(pfx.Masked())
+}
+
+// Tree returns the underlying interval tree for all other queries, e.g. Subnets with CoveredBy.
+// The returned tree is a snapshot, later changes of t are not visible in it.
+func (t *Table) Tree() *interval.Tree[netip.Prefix] {
+	return t.tree
+}
+
+// String returns the hierarchical tree of the prefixes, see [interval.Tree.String].
+func (t *Table) String() string {
+	return t.tree.String()
+}
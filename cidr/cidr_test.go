@@ -0,0 +1,88 @@
+package cidr_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/gaissmai/interval/cidr"
+)
+
+func TestLookupIP(t *testing.T) {
+	t.Parallel()
+
+	tbl := cidr.MustParse("0.0.0.0/0", "10.0.0.0/8", "10.0.1.0/24", "::/0", "2001:db8::/32", "127.0.0.1/32")
+
+	tests := []struct {
+		addr string
+		want string
+		ok   bool
+	}{
+		{"10.0.1.17", "10.0.1.0/24", true},
+		{"10.0.2.17", "10.0.0.0/8", true},
+		{"127.0.0.1", "127.0.0.1/32", true},
+		{"192.168.1.1", "0.0.0.0/0", true},
+		{"2001:db8::1", "2001:db8::/32", true},
+		{"fe80::1", "::/0", true},
+	}
+
+	for _, tt := range tests {
+		got, ok := tbl.LookupIP(netip.MustParseAddr(tt.addr))
+		if ok != tt.ok || got.String() != tt.want {
+			t.Errorf("LookupIP(%s), got %s %v, want %s %v", tt.addr, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if _, ok := cidr.MustParse("10.0.0.0/8").LookupIP(netip.MustParseAddr("11.0.0.1")); ok {
+		t.Errorf("LookupIP(11.0.0.1), got true, want false")
+	}
+}
+
+func TestLookupPfx(t *testing.T) {
+	t.Parallel()
+
+	tbl := cidr.MustParse("10.0.0.0/8", "10.0.1.0/24")
+
+	// host bits are masked
+	got, ok := tbl.LookupPfx(netip.MustParsePrefix("10.0.1.77/25"))
+	if !ok || got.String() != "10.0.1.0/24" {
+		t.Errorf("LookupPfx(10.0.1.77/25), got %s %v, want 10.0.1.0/24 true", got, ok)
+	}
+
+	got, ok = tbl.LookupPfx(netip.MustParsePrefix("10.0.0.0/8"))
+	if !ok || got.String() != "10.0.0.0/8" {
+		t.Errorf("LookupPfx(10.0.0.0/8), got %s %v, want 10.0.0.0/8 true", got, ok)
+	}
+
+	if _, ok := tbl.LookupPfx(netip.MustParsePrefix("0.0.0.0/0")); ok {
+		t.Errorf("LookupPfx(0.0.0.0/0), got true, want false")
+	}
+
+	if !tbl.Delete(netip.MustParsePrefix("10.0.1.0/24")) {
+		t.Errorf("Delete(10.0.1.0/24), got false, want true")
+	}
+	if got, _ := tbl.LookupPfx(netip.MustParsePrefix("10.0.1.0/25")); got.String() != "10.0.0.0/8" {
+		t.Errorf("LookupPfx after Delete, got %s, want 10.0.0.0/8", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	if _, err := cidr.Parse("10.0.0.0/8", "10.0.0.0/33"); err == nil {
+		t.Error("Parse(10.0.0.0/33), expected error")
+	}
+}
+
+func TestRange(t *testing.T) {
+	t.Parallel()
+
+	first, last := cidr.Range(netip.MustParsePrefix("10.0.1.0/23"))
+	if first.String() != "10.0.0.0" || last.String() != "10.0.1.255" {
+		t.Errorf("Range(10.0.1.0/23), got %s-%s, want 10.0.0.0-10.0.1.255", first, last)
+	}
+
+	first, last = cidr.Range(netip.MustParsePrefix("2001:db8::/127"))
+	if first.String() != "2001:db8::" || last.String() != "2001:db8::1" {
+		t.Errorf("Range(2001:db8::/127), got %s-%s", first, last)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/gaissmai/interval/cidr"
+)
+
+// kind of endpoint, the sort order between different kinds.
+const (
+	kindIP = iota
+	kindNumber
+	kindString
+)
+
+// endpoint, the parsed interval endpoint, the raw string is kept for output and the snapshots.
+type endpoint struct {
+	raw  string
+	kind int
+	ip   netip.Addr
+	num  float64
+}
+
+// parseEndpoint, detects the kind of the endpoint.
+func parseEndpoint(s string) endpoint {
+	s = strings.TrimSpace(s)
+	if ip, err := netip.ParseAddr(s); err == nil {
+		return endpoint{raw: s, kind: kindIP, ip: ip}
+	}
+	if num, err := strconv.ParseFloat(s, 64); err == nil {
+		return endpoint{raw: s, kind: kindNumber, num: num}
+	}
+	return endpoint{raw: s, kind: kindString}
+}
+
+func cmpEndpoint(a, b endpoint) int {
+	if a.kind != b.kind {
+		return cmp.Compare(a.kind, b.kind)
+	}
+
+	switch a.kind {
+	case kindIP:
+		return a.ip.Compare(b.ip)
+	case kindNumber:
+		return cmp.Compare(a.num, b.num)
+	default:
+		return strings.Compare(a.raw, b.raw)
+	}
+}
+
+// ival, the interval type of the tool.
+type ival [2]endpoint
+
+// String implements fmt.Stringer.
+func (p ival) String() string {
+	return p[0].raw + "," + p[1].raw
+}
+
+func cmpIval(a, b ival) (ll, rr, lr, rl int) {
+	return cmpEndpoint(a[0], b[0]),
+		cmpEndpoint(a[1], b[1]),
+		cmpEndpoint(a[0], b[1]),
+		cmpEndpoint(a[1], b[0])
+}
+
+// parseItem, "from,to" or a single point or CIDR prefix.
+func parseItem(fields []string) (ival, error) {
+	switch len(fields) {
+	case 2:
+		return ival{parseEndpoint(fields[0]), parseEndpoint(fields[1])}, nil
+	case 1:
+		s := strings.TrimSpace(fields[0])
+		if pfx, err := netip.ParsePrefix(s); err == nil {
+			first, last := cidr.Range(pfx)
+			return ival{{raw: first.String(), kind: kindIP, ip: first}, {raw: last.String(), kind: kindIP, ip: last}}, nil
+		}
+		p := parseEndpoint(s)
+		return ival{p, p}, nil
+	default:
+		return ival{}, fmt.Errorf("want 'from,to', a point or a prefix, got %d fields", len(fields))
+	}
+}
+
+// encodeIval, the snapshot codec, the raw endpoints separated by a tab.
+func encodeIval(p ival) ([]byte, error) {
+	return []byte(p[0].raw + "\t" + p[1].raw), nil
+}
+
+func decodeIval(data []byte) (ival, error) {
+	from, to, ok := strings.Cut(string(data), "\t")
+	if !ok {
+		return ival{}, errors.New("missing separator")
+	}
+	return ival{parseEndpoint(from), parseEndpoint(to)}, nil
+}
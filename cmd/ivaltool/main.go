@@ -0,0 +1,283 @@
+// Command ivaltool inspects interval trees stored as snapshots or CSV files.
+//
+// Usage:
+//
+//	ivaltool print [-ascii] [-depth n] FILE
+//	ivaltool lookup FILE ITEM
+//	ivaltool stats FILE
+//	ivaltool diff FILE1 FILE2
+//	ivaltool snapshot FILE OUT
+//
+// A FILE is either a snapshot written by the snapshot subcommand or a CSV file with
+// one interval per line, "from,to" or a single point or CIDR prefix, e.g.
+//
+//	10.0.0.0,10.0.0.255
+//	192.168.0.0/16
+//	2001:db8::1
+//
+// An ITEM has the same format as a CSV line. The endpoints are compared as IP addresses
+// if both are IP addresses, as numbers if both are numbers, as strings otherwise.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gaissmai/interval"
+)
+
+const usage = `usage:
+  ivaltool print [-ascii] [-depth n] FILE
+  ivaltool lookup FILE ITEM
+  ivaltool stats FILE
+  ivaltool diff FILE1 FILE2
+  ivaltool snapshot FILE OUT
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "ivaltool: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+var errUsage = errors.New("invalid arguments\n" + usage)
+
+// run the subcommand in args, the output goes to w.
+func run(args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "print":
+		return cmdPrint(args, w)
+	case "lookup":
+		return cmdLookup(args, w)
+	case "stats":
+		return cmdStats(args, w)
+	case "diff":
+		return cmdDiff(args, w)
+	case "snapshot":
+		return cmdSnapshot(args)
+	default:
+		return fmt.Errorf("unknown command %q\n%s", cmd, usage)
+	}
+}
+
+func cmdPrint(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("print", flag.ContinueOnError)
+	ascii := fs.Bool("ascii", false, "ASCII glyphs only")
+	depth := fs.Int("depth", 0, "max depth, 0 is unlimited")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+
+	tree, err := load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	opts := []interval.PrintOption{interval.WithMaxDepth(*depth)}
+	if *ascii {
+		opts = append(opts, interval.WithASCII())
+	}
+
+	return tree.Fprint(w, opts...)
+}
+
+func cmdLookup(args []string, w io.Writer) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+
+	tree, err := load(args[0])
+	if err != nil {
+		return err
+	}
+
+	item, err := parseItem(strings.Split(args[1], ","))
+	if err != nil {
+		return err
+	}
+
+	if lcp, ok := tree.CoverLCP(item); ok {
+		fmt.Fprintf(w, "lcp:           %v\n", lcp)
+	} else {
+		fmt.Fprintf(w, "lcp:           -\n")
+	}
+
+	if scp, ok := tree.CoverSCP(item); ok {
+		fmt.Fprintf(w, "scp:           %v\n", scp)
+	} else {
+		fmt.Fprintf(w, "scp:           -\n")
+	}
+
+	printList(w, "covers:       ", tree.Covers(item))
+	printList(w, "covered by:   ", tree.CoveredBy(item))
+	printList(w, "intersections:", tree.Intersections(item))
+
+	return nil
+}
+
+func printList(w io.Writer, label string, items []ival) {
+	fmt.Fprintf(w, "%s %d\n", label, len(items))
+	for _, item := range items {
+		fmt.Fprintf(w, "  %v\n", item)
+	}
+}
+
+func cmdStats(args []string, w io.Writer) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	tree, err := load(args[0])
+	if err != nil {
+		return err
+	}
+
+	size, maxDepth, average, deviation := tree.Statistics()
+	fmt.Fprintf(w, "size:      %d\n", size)
+	fmt.Fprintf(w, "maxDepth:  %d\n", maxDepth)
+	fmt.Fprintf(w, "average:   %.2f\n", average)
+	fmt.Fprintf(w, "deviation: %.2f\n", deviation)
+
+	if err := tree.CheckInvariants(); err != nil {
+		fmt.Fprintf(w, "invariants: %v\n", err)
+	} else {
+		fmt.Fprintf(w, "invariants: ok\n")
+	}
+
+	return nil
+}
+
+// cmdDiff, prints the items only in the first file with '-' and only in the second file with '+'.
+func cmdDiff(args []string, w io.Writer) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+
+	a, err := load(args[0])
+	if err != nil {
+		return err
+	}
+
+	b, err := load(args[1])
+	if err != nil {
+		return err
+	}
+
+	as, bs := items(a), items(b)
+	cmp := a.CompareFunc()
+
+	// merge the sorted items
+	for len(as) > 0 || len(bs) > 0 {
+		switch {
+		case len(bs) == 0:
+			fmt.Fprintf(w, "- %v\n", as[0])
+			as = as[1:]
+		case len(as) == 0:
+			fmt.Fprintf(w, "+ %v\n", bs[0])
+			bs = bs[1:]
+		default:
+			switch c := compare(cmp, as[0], bs[0]); {
+			case c < 0:
+				fmt.Fprintf(w, "- %v\n", as[0])
+				as = as[1:]
+			case c > 0:
+				fmt.Fprintf(w, "+ %v\n", bs[0])
+				bs = bs[1:]
+			default:
+				as, bs = as[1:], bs[1:]
+			}
+		}
+	}
+
+	return nil
+}
+
+// compare, the sort order of the tree, left point first, supersets to the left.
+func compare(cmp func(a, b ival) (ll, rr, lr, rl int), a, b ival) int {
+	ll, rr, _, _ := cmp(a, b)
+	if ll == 0 {
+		return -rr
+	}
+	return ll
+}
+
+// items, all items of the tree in sorted order.
+func items(tree *interval.Tree[ival]) (result []ival) {
+	tree.Visit(tree.Min(), tree.Max(), func(item ival) bool {
+		result = append(result, item)
+		return true
+	})
+	return
+}
+
+func cmdSnapshot(args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+
+	tree, err := load(args[0])
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(args[1])
+	if err != nil {
+		return err
+	}
+
+	if err := tree.Store(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// newTree, the tree for the tool items with the snapshot codec.
+func newTree() *interval.Tree[ival] {
+	return interval.New(cmpIval, interval.WithCodec(encodeIval, decodeIval))
+}
+
+// load the tree from a snapshot or CSV file.
+func load(path string) (*interval.Tree[ival], error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := newTree()
+
+	if bytes.HasPrefix(data, []byte("IVS")) {
+		if err := tree.Load(bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return tree, nil
+	}
+
+	items, err := interval.ReadItems(bytes.NewReader(data), parseItem)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	tree, err = tree.InsertStrict(items...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return tree, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func runOK(t *testing.T, args ...string) string {
+	t.Helper()
+	w := new(strings.Builder)
+	if err := run(args, w); err != nil {
+		t.Fatalf("run(%v): %v", args, err)
+	}
+	return w.String()
+}
+
+const acl = `# ACL
+0.0.0.0/0
+10.0.0.0/8
+10.0.0.0,10.0.0.255
+192.168.0.0/16
+::/0
+2001:db8::1
+`
+
+func TestPrint(t *testing.T) {
+	t.Parallel()
+	path := writeFile(t, "acl.csv", acl)
+
+	want := `v
++- 0.0.0.0,255.255.255.255
+|  +- 10.0.0.0,10.255.255.255
+|  |  ` + "`" + `- 10.0.0.0,10.0.0.255
+|  ` + "`" + `- 192.168.0.0,192.168.255.255
+` + "`" + `- ::,ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff
+   ` + "`" + `- 2001:db8::1,2001:db8::1
+`
+	if got := runOK(t, "print", "-ascii", path); got != want {
+		t.Errorf("print\nwant:\n%sgot:\n%s", want, got)
+	}
+}
+
+func TestLookupAndSnapshot(t *testing.T) {
+	t.Parallel()
+	path := writeFile(t, "acl.csv", acl)
+	snap := filepath.Join(t.TempDir(), "acl.ivs")
+
+	runOK(t, "snapshot", path, snap)
+
+	for _, file := range []string{path, snap} {
+		got := runOK(t, "lookup", file, "10.0.0.17")
+		if !strings.HasPrefix(got, "lcp:           10.0.0.0,10.0.0.255\nscp:           0.0.0.0,255.255.255.255\ncovers:        3\n") {
+			t.Errorf("lookup %s, got:\n%s", file, got)
+		}
+	}
+
+	if got := runOK(t, "stats", snap); !strings.Contains(got, "size:      6\n") || !strings.Contains(got, "invariants: ok\n") {
+		t.Errorf("stats, got:\n%s", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	a := writeFile(t, "a.csv", "1,5\n2,3\n7,9\n")
+	b := writeFile(t, "b.csv", "1,5\n7,9\n10,20\n")
+
+	want := "- 2,3\n+ 10,20\n"
+	if got := runOK(t, "diff", a, b); got != want {
+		t.Errorf("diff\nwant:\n%sgot:\n%s", want, got)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		nil,
+		{"unknown"},
+		{"lookup", "missing.csv", "1"},
+		{"lookup", writeFile(t, "bad.csv", "9,1\n"), "1"},
+		{"diff", "only-one.csv"},
+	} {
+		if err := run(args, new(strings.Builder)); err == nil {
+			t.Errorf("run(%v), got: nil, want: error", args)
+		}
+	}
+}
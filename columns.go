@@ -0,0 +1,70 @@
+package interval
+
+import "fmt"
+
+// Columns holds the items of a tree as parallel columns, the left points, the right points
+// and the payloads, all of the same length and in sorted order. The columns map directly
+// to columnar formats like Apache Arrow or Parquet, without per-item reflection.
+type Columns[P, V any] struct {
+	Lower   []P
+	Upper   []P
+	Payload []V
+}
+
+// Len returns the number of rows, the length of the columns.
+func (c Columns[P, V]) Len() int {
+	return len(c.Lower)
+}
+
+// ExportColumns returns all items of the tree in sorted order as parallel columns,
+// split returns the left point, the right point and the payload of an item.
+//
+//	cols := interval.ExportColumns(tree, func(r rule) (netip.Addr, netip.Addr, string) {
+//		return r.from, r.to, r.action
+//	})
+func ExportColumns[T, P, V any](t *Tree[T], split func(item T) (lower, upper P, payload V)) Columns[P, V] {
+	var size int
+	if t.root != nil {
+		size = t.root.size
+	}
+
+	c := Columns[P, V]{
+		Lower:   make([]P, 0, size),
+		Upper:   make([]P, 0, size),
+		Payload: make([]V, 0, size),
+	}
+
+	t.traverse(t.root, inorder, 0, func(n *node[T], _ int) bool {
+		lower, upper, payload := split(n.item)
+		c.Lower = append(c.Lower, lower)
+		c.Upper = append(c.Upper, upper)
+		c.Payload = append(c.Payload, payload)
+		return true
+	})
+
+	return c
+}
+
+// ImportColumns returns the items joined row by row from the columns, e.g. read from Arrow
+// or Parquet, join builds the item from the left point, the right point and the payload.
+// The columns must be of the same length, a nil Payload column is allowed, join gets the
+// zero value then.
+//
+// See [ReadItems] for the bulk build with the items.
+func ImportColumns[T, P, V any](c Columns[P, V], join func(lower, upper P, payload V) T) ([]T, error) {
+	if len(c.Upper) != len(c.Lower) || c.Payload != nil && len(c.Payload) != len(c.Lower) {
+		return nil, fmt.Errorf("interval: columns of different length, lower: %d, upper: %d, payload: %d",
+			len(c.Lower), len(c.Upper), len(c.Payload))
+	}
+
+	items := make([]T, len(c.Lower))
+	for i := range items {
+		var payload V
+		if c.Payload != nil {
+			payload = c.Payload[i]
+		}
+		items[i] = join(c.Lower[i], c.Upper[i], payload)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,53 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestColumns(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpTaggedInterval,
+		taggedInterval{uintInterval{3, 13}, []string{"b"}},
+		taggedInterval{uintInterval{0, 100}, []string{"a"}},
+		taggedInterval{uintInterval{7, 9}, nil},
+	)
+
+	split := func(item taggedInterval) (uint, uint, []string) { return item.ival[0], item.ival[1], item.tags }
+	join := func(lo, hi uint, tags []string) taggedInterval { return taggedInterval{uintInterval{lo, hi}, tags} }
+
+	cols := interval.ExportColumns(tree, split)
+
+	if cols.Len() != 3 || !slices.Equal(cols.Lower, []uint{0, 3, 7}) || !slices.Equal(cols.Upper, []uint{100, 13, 9}) {
+		t.Fatalf("ExportColumns(), got: %v", cols)
+	}
+
+	items, err := interval.ImportColumns(cols, join)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := interval.NewTree(cmpTaggedInterval, items...)
+	if got.String() != tree.String() {
+		t.Fatalf("ImportColumns(ExportColumns()), got:\n%s\nwant:\n%s", got, tree)
+	}
+
+	// without payload column
+	cols.Payload = nil
+	if items, err = interval.ImportColumns(cols, join); err != nil || len(items) != 3 || items[0].tags != nil {
+		t.Fatalf("ImportColumns() without payload, got: %v, %v", items, err)
+	}
+
+	cols.Upper = cols.Upper[:2]
+	if _, err := interval.ImportColumns(cols, join); err == nil {
+		t.Fatal("ImportColumns() with different lengths, expected error")
+	}
+
+	// empty tree
+	if cols := interval.ExportColumns(interval.NewTree(cmpTaggedInterval), split); cols.Len() != 0 {
+		t.Fatalf("ExportColumns(empty), got: %v", cols)
+	}
+}
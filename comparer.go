@@ -48,8 +48,9 @@ package interval
 //
 // [Allen's Interval Algebra]: https://www.ics.uci.edu/~alspaugh/cls/shr/allen.html
 
-// compare is for sorting keys into the BST, the sort key is the left point of the intervals.
+// compareIval is for sorting keys into the BST, the sort key is the left point of the intervals.
 // If the left point is equal, sort the supersets to the left (definite order).
+// The query algorithms rely on this order, see also compare.
 //
 //  e.g. special treatment for all relations with ll == 0
 //  =================================================================|
@@ -67,7 +68,7 @@ package interval
 //  |  B1----B2              |    |    |    |    |                   |
 //  -------------------------|----------------------------------------
 //
-func (t *Tree[T]) compare(a, b T) int {
+func (t *Tree[T]) compareIval(a, b T) int {
 	ll, rr, _, _ := t.cmp(a, b)
 	switch {
 	case ll == 0:
@@ -80,6 +81,14 @@ func (t *Tree[T]) compare(a, b T) int {
 	}
 }
 
+// compare is the BST order, compareIval with the optional tiebreak for equal intervals, see [WithTiebreak].
+func (t *Tree[T]) compare(a, b T) int {
+	if c := t.compareIval(a, b); c != 0 || t.tiebreak == nil {
+		return c
+	}
+	return t.tiebreak(a, b)
+}
+
 // cmpCovers, returns true if a covers b.
 //
 //  =================================================================|
@@ -124,6 +133,12 @@ func (t *Tree[T]) cmpIntersects(a, b T) bool {
 	return !((ll == -1 && rr == -1 && lr == -1 && rl == -1) || (ll == 1 && rr == 1 && lr == 1 && rl == 1))
 }
 
+// cmpLL, compares just the left point of the intervals.
+func (t *Tree[T]) cmpLL(a, b T) int {
+	ll, _, _, _ := t.cmp(a, b)
+	return ll
+}
+
 // cmpRR, compares just the right point of the intervals.
 func (t *Tree[T]) cmpRR(a, b T) int {
 	_, rr, _, _ := t.cmp(a, b)
@@ -141,3 +156,25 @@ func (t *Tree[T]) cmpRL(a, b T) int {
 	_, _, _, rl := t.cmp(a, b)
 	return rl
 }
+
+// contiguous, returns true if b starts at or before the right point of a, no gap between a and b.
+// Half-open intervals that meet, e.g. [0,5) and [5,10), are contiguous, but don't intersect.
+func (t *Tree[T]) contiguous(a, b T) bool {
+	if t.cmpLR(b, a) <= 0 {
+		return true
+	}
+
+	if t.opts == nil || !t.opts.halfOpen {
+		return false
+	}
+
+	closed := t.opts.closedCmp.(func(a, b T) (ll, rr, lr, rl int))
+	_, _, lr, _ := closed(b, a)
+	return lr <= 0
+}
+
+// cmpValid, returns false if the left point of a is greater than the right point of a.
+func (t *Tree[T]) cmpValid(a T) bool {
+	_, _, lr, _ := t.cmp(a, a)
+	return lr <= 0
+}
@@ -0,0 +1,29 @@
+package interval
+
+// Shortest returns the shortest interval that covers the item, the names of the earlier slice-based version.
+//
+// Deprecated: Use [Tree.CoverLCP].
+func (t Tree[T]) Shortest(item T) (result T, ok bool) {
+	return t.CoverLCP(item)
+}
+
+// Largest returns the largest interval that covers the item, the names of the earlier slice-based version.
+//
+// Deprecated: Use [Tree.CoverSCP].
+func (t Tree[T]) Largest(item T) (result T, ok bool) {
+	return t.CoverSCP(item)
+}
+
+// Subsets returns all intervals covered by the item, the names of the earlier slice-based version.
+//
+// Deprecated: Use [Tree.CoveredBy].
+func (t Tree[T]) Subsets(item T) []T {
+	return t.CoveredBy(item)
+}
+
+// Supersets returns all intervals that cover the item, the names of the earlier slice-based version.
+//
+// Deprecated: Use [Tree.Covers].
+func (t Tree[T]) Supersets(item T) []T {
+	return t.Covers(item)
+}
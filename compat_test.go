@@ -0,0 +1,35 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestCompatAliases(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, ps...)
+
+	for _, probe := range genUintIvals(100) {
+		got, gotOK := tree.Shortest(probe)
+		want, wantOK := tree.CoverLCP(probe)
+		if got != want || gotOK != wantOK {
+			t.Fatalf("Shortest(%v), got %v %v, want %v %v", probe, got, gotOK, want, wantOK)
+		}
+
+		got, gotOK = tree.Largest(probe)
+		want, wantOK = tree.CoverSCP(probe)
+		if got != want || gotOK != wantOK {
+			t.Fatalf("Largest(%v), got %v %v, want %v %v", probe, got, gotOK, want, wantOK)
+		}
+
+		if !slices.Equal(tree.Subsets(probe), tree.CoveredBy(probe)) {
+			t.Fatalf("Subsets(%v) differs from CoveredBy", probe)
+		}
+		if !slices.Equal(tree.Supersets(probe), tree.Covers(probe)) {
+			t.Fatalf("Supersets(%v) differs from Covers", probe)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !interval_debug
+
+package interval
+
+// checkedCmp returns the compare function unchanged, build with the tag 'interval_debug'
+// for consistency checks of the compare function.
+func checkedCmp[T any](cmp func(a, b T) (ll, rr, lr, rl int)) func(a, b T) (ll, rr, lr, rl int) {
+	return cmp
+}
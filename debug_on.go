@@ -0,0 +1,73 @@
+//go:build interval_debug
+
+package interval
+
+import "fmt"
+
+// checkedCmp wraps the compare function, every comparison is cross-checked for internal consistency.
+// Panics with a helpful message if the compare function is broken, otherwise broken comparators
+// silently result in wrong query results.
+//
+// This is expensive, each comparison calls the compare function five times.
+// Selected with the build tag 'interval_debug'.
+func checkedCmp[T any](cmp func(a, b T) (ll, rr, lr, rl int)) func(a, b T) (ll, rr, lr, rl int) {
+	if cmp == nil {
+		return nil
+	}
+
+	return func(a, b T) (ll, rr, lr, rl int) {
+		ll, rr, lr, rl = cmp(a, b)
+
+		if msg := cmpConsistency(cmp, a, b, ll, rr, lr, rl); msg != "" {
+			panic(fmt.Sprintf("interval: inconsistent compare function: cmp(%v, %v) = (ll:%d, rr:%d, lr:%d, rl:%d), %s",
+				a, b, ll, rr, lr, rl, msg))
+		}
+
+		return
+	}
+}
+
+// cmpConsistency returns a description of the first violated rule, or the empty string.
+func cmpConsistency[T any](cmp func(a, b T) (ll, rr, lr, rl int), a, b T, ll, rr, lr, rl int) string {
+	for _, v := range [4]int{ll, rr, lr, rl} {
+		if v < -1 || v > 1 {
+			return "values must be -1, 0 or +1"
+		}
+	}
+
+	// self comparison, malformed intervals are detected by InsertStrict, not here
+	proper := true
+	for _, x := range [2]T{a, b} {
+		sll, srr, slr, srl := cmp(x, x)
+		if sll != 0 || srr != 0 {
+			return fmt.Sprintf("cmp(%v, %v) must be equal", x, x)
+		}
+		if slr != -srl {
+			return fmt.Sprintf("cmp(%v, %v) must have lr == -rl", x, x)
+		}
+		proper = proper && slr <= 0
+	}
+
+	// antisymmetry
+	if bll, brr, blr, brl := cmp(b, a); bll != -ll || brr != -rr || blr != -rl || brl != -lr {
+		return fmt.Sprintf("not antisymmetric, cmp(b, a) = (ll:%d, rr:%d, lr:%d, rl:%d)", bll, brr, blr, brl)
+	}
+
+	if !proper {
+		return ""
+	}
+
+	// with a.left <= a.right and b.left <= b.right
+	switch {
+	case lr > ll:
+		return "violates lr <= ll"
+	case lr > rr:
+		return "violates lr <= rr"
+	case rl < ll:
+		return "violates rl >= ll"
+	case rl < rr:
+		return "violates rl >= rr"
+	}
+
+	return ""
+}
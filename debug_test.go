@@ -0,0 +1,36 @@
+//go:build interval_debug
+
+package interval_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestDebugBrokenCmp(t *testing.T) {
+	t.Parallel()
+
+	// broken, distinct intervals are always less than each other, not antisymmetric for every pair.
+	// Any first comparison panics, independent of the random shape of the treap.
+	broken := func(p, q uintInterval) (ll, rr, lr, rl int) {
+		ll, rr, lr, rl = cmpUintInterval(p, q)
+		if p != q {
+			ll = -1
+		}
+		return ll, rr, lr, rl
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("broken compare function, want panic")
+		}
+		if msg, _ := r.(string); !strings.Contains(msg, "inconsistent compare function") || !strings.Contains(msg, "not antisymmetric") {
+			t.Fatalf("unexpected panic: %v", r)
+		}
+	}()
+
+	interval.NewTree(broken, ps...)
+}
@@ -0,0 +1,44 @@
+package interval
+
+// NodeInfo describes a node of the binary search tree, see [Tree.DumpBST].
+type NodeInfo[T any] struct {
+	Item  T      // the item of the node
+	Prio  uint32 // the heap priority of the node, see [Tree.InsertWithPriority]
+	Depth int    // the depth of the node, the root has depth 0
+	Left  int    // index of the left child in the dump, -1 if none
+	Right int    // index of the right child in the dump, -1 if none
+}
+
+// DumpBST returns the nodes of the binary search tree as data, the same information
+// as [Tree.FprintBST] writes as diagram, e.g. to analyze the shape and the balance
+// of the tree with external tools.
+//
+// The nodes are in preorder, the root first at index 0, followed by the left and
+// then the right subtree. The dump of an empty tree is empty.
+func (t Tree[T]) DumpBST() []NodeInfo[T] {
+	if t.root == nil {
+		return nil
+	}
+
+	dump := make([]NodeInfo[T], 0, t.root.size)
+	dumpBST(t.root, 0, &dump)
+	return dump
+}
+
+// dumpBST appends the subtree at n in preorder and returns the index of n.
+func dumpBST[T any](n *node[T], depth int, dump *[]NodeInfo[T]) int {
+	if n == nil {
+		return -1
+	}
+
+	idx := len(*dump)
+	*dump = append(*dump, NodeInfo[T]{Item: n.item, Prio: n.prio, Depth: depth})
+
+	left := dumpBST(n.left, depth+1, dump)
+	right := dumpBST(n.right, depth+1, dump)
+
+	(*dump)[idx].Left = left
+	(*dump)[idx].Right = right
+
+	return idx
+}
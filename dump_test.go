@@ -0,0 +1,66 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestDumpBST(t *testing.T) {
+	t.Parallel()
+
+	var zero interval.Tree[uintInterval]
+	if got := zero.DumpBST(); len(got) != 0 {
+		t.Fatalf("DumpBST of empty tree, got %v", got)
+	}
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+	size, _, _, _ := tree.Statistics()
+	dump := tree.DumpBST()
+
+	if len(dump) != size {
+		t.Fatalf("DumpBST, got %d nodes, want %d", len(dump), size)
+	}
+
+	if dump[0].Depth != 0 {
+		t.Fatalf("DumpBST, root has depth %d, want 0", dump[0].Depth)
+	}
+
+	height := 0
+	for i, ni := range dump {
+		height = max(height, ni.Depth+1)
+		for _, c := range [...]int{ni.Left, ni.Right} {
+			if c == -1 {
+				continue
+			}
+			if c <= i || dump[c].Depth != ni.Depth+1 {
+				t.Fatalf("DumpBST, node %d: child %d not in preorder or wrong depth", i, c)
+			}
+			if dump[c].Prio > ni.Prio {
+				t.Fatalf("DumpBST, node %d: child %d has higher priority", i, c)
+			}
+		}
+	}
+
+	if height != tree.Height() {
+		t.Fatalf("DumpBST, max depth+1: %d, Height: %d", height, tree.Height())
+	}
+
+	// walk the links in order, must be the sorted items
+	var items []uintInterval
+	var inorder func(i int)
+	inorder = func(i int) {
+		if i == -1 {
+			return
+		}
+		inorder(dump[i].Left)
+		items = append(items, dump[i].Item)
+		inorder(dump[i].Right)
+	}
+	inorder(0)
+
+	if !slices.Equal(items, sortedItems(tree)) {
+		t.Fatal("DumpBST, the links in order don't match the sorted items")
+	}
+}
@@ -0,0 +1,122 @@
+package interval
+
+import "cmp"
+
+// NewTreeByEndpoints initializes the interval tree with items from type T. The compare function
+// is derived from the two accessor functions for the left (low) and right (high) point of
+// the intervals, the points must be of an ordered type.
+//
+// This is more ergonomic than writing the compare function returning the four int values by hand:
+//
+//	type event struct {
+//		start, stop int64
+//		name        string
+//	}
+//
+//	tree := interval.NewTreeByEndpoints(
+//		func(e event) int64 { return e.start },
+//		func(e event) int64 { return e.stop },
+//		events...)
+func NewTreeByEndpoints[P cmp.Ordered, T any](low, high func(T) P, items ...T) *Tree[T] {
+	return NewTree[T](cmpByEndpoints(low, high), items...)
+}
+
+// cmpByEndpoints, derive the compare function from the endpoint accessor functions.
+func cmpByEndpoints[P cmp.Ordered, T any](low, high func(T) P) func(a, b T) (ll, rr, lr, rl int) {
+	return func(a, b T) (ll, rr, lr, rl int) {
+		aLow, aHigh := low(a), high(a)
+		bLow, bHigh := low(b), high(b)
+
+		return cmp.Compare(aLow, bLow),
+			cmp.Compare(aHigh, bHigh),
+			cmp.Compare(aLow, bHigh),
+			cmp.Compare(aHigh, bLow)
+	}
+}
+
+// NewTreeFromLowerUpper initializes the interval tree with items from type T. The compare function
+// is derived from the two accessor functions for the lower and upper point of the intervals
+// and the compare function for two points, returning -1, 0 or +1.
+//
+// Two comparators for the lower and the upper points alone are not sufficient, the interval relations
+// also need the lower point of one interval compared with the upper point of the other.
+// Use this constructor for points of non-ordered types like netip.Addr or time.Time,
+// for ordered types see [NewTreeByEndpoints].
+//
+//	type span struct{ from, to time.Time }
+//
+//	tree := interval.NewTreeFromLowerUpper(
+//		func(s span) time.Time { return s.from },
+//		func(s span) time.Time { return s.to },
+//		time.Time.Compare,
+//		spans...)
+func NewTreeFromLowerUpper[P, T any](lower, upper func(T) P, cmpPoint func(a, b P) int, items ...T) *Tree[T] {
+	return NewTree[T](cmpFromLowerUpper(lower, upper, cmpPoint), items...)
+}
+
+// cmpFromLowerUpper, derive the compare function from the point accessor functions and the point comparator.
+func cmpFromLowerUpper[P, T any](lower, upper func(T) P, cmpPoint func(a, b P) int) func(a, b T) (ll, rr, lr, rl int) {
+	return func(a, b T) (ll, rr, lr, rl int) {
+		aLow, aHigh := lower(a), upper(a)
+		bLow, bHigh := lower(b), upper(b)
+
+		return cmpPoint(aLow, bLow),
+			cmpPoint(aHigh, bHigh),
+			cmpPoint(aLow, bHigh),
+			cmpPoint(aHigh, bLow)
+	}
+}
+
+// NewTreeOf initializes the interval tree with simple intervals of type [2]P, the left point at index 0
+// and the right point at index 1. The compare function is built-in, no boilerplate is needed.
+//
+//	tree := interval.NewTreeOf([2]int{0, 6}, [2]int{1, 8}, [2]int{7, 9})
+func NewTreeOf[P cmp.Ordered](items ...[2]P) *Tree[[2]P] {
+	return NewTree(cmpPair[P], items...)
+}
+
+// Point returns the degenerate interval {p, p}.
+//
+// Single points are just intervals with identical endpoints, all queries handle them like
+// any other interval, e.g. the most specific range containing an address:
+//
+//	tree := interval.NewTreeOf(ranges...)
+//	lcp, ok := tree.CoverLCP(interval.Point(addr))
+func Point[P any](p P) [2]P {
+	return [2]P{p, p}
+}
+
+// IsPoint returns true if item is a degenerate interval, the left point equals the right point.
+func (t Tree[T]) IsPoint(item T) bool {
+	if t.cmp == nil {
+		return false
+	}
+	_, _, lr, _ := t.cmp(item, item)
+	return lr == 0
+}
+
+// cmpPair, the compare function for intervals of type [2]P.
+func cmpPair[P cmp.Ordered](a, b [2]P) (ll, rr, lr, rl int) {
+	return cmp.Compare(a[0], b[0]),
+		cmp.Compare(a[1], b[1]),
+		cmp.Compare(a[0], b[1]),
+		cmp.Compare(a[1], b[0])
+}
+
+// Interface is the constraint for interval types with the compare function as method,
+// with the same contract as the compare function of [NewTree], the receiver is interval a.
+type Interface[T any] interface {
+	Compare(b T) (ll, rr, lr, rl int)
+}
+
+// NewTreeOfInterface initializes the interval tree with items of a type implementing [Interface],
+// the compare function is derived from the Compare method, no standalone compare function is needed.
+//
+//	type cidr netip.Prefix
+//
+//	func (a cidr) Compare(b cidr) (ll, rr, lr, rl int) { ... }
+//
+//	tree := interval.NewTreeOfInterface(cidrs...)
+func NewTreeOfInterface[T Interface[T]](items ...T) *Tree[T] {
+	return NewTree(T.Compare, items...)
+}
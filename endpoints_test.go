@@ -0,0 +1,155 @@
+package interval_test
+
+import (
+	"cmp"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gaissmai/interval"
+)
+
+type event struct {
+	start, stop int64
+	name        string
+}
+
+func TestNewTreeByEndpoints(t *testing.T) {
+	t.Parallel()
+
+	events := []event{
+		{0, 100, "all"},
+		{3, 13, "early"},
+		{41, 102, "late"},
+		{42, 67, "mid"},
+	}
+
+	tree := interval.NewTreeByEndpoints(
+		func(e event) int64 { return e.start },
+		func(e event) int64 { return e.stop },
+		events...)
+
+	probe := event{start: 45, stop: 50}
+
+	got, ok := tree.CoverLCP(probe)
+	if want := events[3]; !ok || got != want {
+		t.Errorf("CoverLCP(%v), got: (%v, %v), want: (%v, true)", probe, got, ok, want)
+	}
+
+	want := []event{events[0], events[2], events[3]}
+	if got := tree.Covers(probe); !reflect.DeepEqual(got, want) {
+		t.Errorf("Covers(%v), got: %v, want: %v", probe, got, want)
+	}
+
+	// only the endpoints are compared, not the payload
+	if _, ok := tree.Find(event{start: 3, stop: 13, name: "other"}); !ok {
+		t.Errorf("Find(), got: false, want: true")
+	}
+}
+
+type span struct{ from, to time.Time }
+
+func TestNewTreeFromLowerUpper(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time { return time.Date(2023, time.January, d, 0, 0, 0, 0, time.UTC) }
+
+	spans := []span{
+		{day(1), day(31)},
+		{day(2), day(5)},
+		{day(5), day(9)},
+		{day(20), day(25)},
+	}
+
+	tree := interval.NewTreeFromLowerUpper(
+		func(s span) time.Time { return s.from },
+		func(s span) time.Time { return s.to },
+		time.Time.Compare,
+		spans...)
+
+	probe := span{day(4), day(6)}
+
+	want := []span{spans[0], spans[1], spans[2]}
+	if got := tree.Intersections(probe); !reflect.DeepEqual(got, want) {
+		t.Errorf("Intersections(%v), got: %v, want: %v", probe, got, want)
+	}
+
+	want = []span{spans[3]}
+	if got := tree.PrecededBy(probe); !reflect.DeepEqual(got, want) {
+		t.Errorf("PrecededBy(%v), got: %v, want: %v", probe, got, want)
+	}
+}
+
+func TestNewTreeOf(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTreeOf([2]int{0, 6}, [2]int{0, 5}, [2]int{1, 8}, [2]int{7, 9})
+
+	got, ok := tree.CoverLCP([2]int{2, 4})
+	if want := [2]int{1, 8}; !ok || got != want {
+		t.Errorf("CoverLCP(), got: (%v, %v), want: (%v, true)", got, ok, want)
+	}
+
+	want := [][2]int{{0, 6}, {1, 8}, {7, 9}}
+	if got := tree.Intersections([2]int{6, 7}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Intersections(), got: %v, want: %v", got, want)
+	}
+}
+
+func TestPoint(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTreeOf([2]int{0, 100}, [2]int{3, 13}, [2]int{7, 7}, [2]int{41, 102})
+
+	if p := interval.Point(7); p != [2]int{7, 7} {
+		t.Fatalf("Point(7), got: %v, want: [7 7]", p)
+	}
+
+	if !tree.IsPoint(interval.Point(7)) || tree.IsPoint([2]int{3, 13}) {
+		t.Fatal("IsPoint() is wrong")
+	}
+
+	if lcp, ok := tree.CoverLCP(interval.Point(7)); !ok || lcp != [2]int{7, 7} {
+		t.Fatalf("CoverLCP(Point(7)), got: (%v, %v), want: ([7 7], true)", lcp, ok)
+	}
+
+	want := [][2]int{{0, 100}, {3, 13}, {7, 7}}
+	if got := tree.Covers(interval.Point(7)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Covers(Point(7)), got: %v, want: %v", got, want)
+	}
+
+	want = [][2]int{{0, 100}, {41, 102}}
+	if got := tree.Intersections(interval.Point(50)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Intersections(Point(50)), got: %v, want: %v", got, want)
+	}
+}
+
+// intSpan implements interval.Interface
+type intSpan [2]int
+
+func (a intSpan) Compare(b intSpan) (ll, rr, lr, rl int) {
+	return cmp.Compare(a[0], b[0]),
+		cmp.Compare(a[1], b[1]),
+		cmp.Compare(a[0], b[1]),
+		cmp.Compare(a[1], b[0])
+}
+
+func TestNewTreeOfInterface(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTreeOfInterface(intSpan{0, 6}, intSpan{0, 5}, intSpan{1, 8}, intSpan{7, 9})
+
+	if err := tree.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := tree.CoverLCP(intSpan{2, 4})
+	if want := (intSpan{1, 8}); !ok || got != want {
+		t.Errorf("CoverLCP(), got: (%v, %v), want: (%v, true)", got, ok, want)
+	}
+
+	want := []intSpan{{0, 6}, {1, 8}, {7, 9}}
+	if got := tree.Intersections(intSpan{6, 7}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Intersections(), got: %v, want: %v", got, want)
+	}
+}
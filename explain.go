@@ -0,0 +1,76 @@
+package interval
+
+import "github.com/gaissmai/interval/internal/query"
+
+// Decision is taken at a visited node during a lookup, see [Step].
+type Decision uint8
+
+// The decisions have the same values as in the shared query algorithms, see explainer.
+const (
+	DecisionPrune     Decision = iota // the augmented upper value excludes the subtree
+	DecisionLeft                      // the node and the right subtree sort behind the item, go left
+	DecisionMatch                     // the node item is a result
+	DecisionMiss                      // the node item is no result, the search goes on
+	DecisionSkipRight                 // the right subtree sorts behind the item, skip it
+)
+
+// String implements fmt.Stringer.
+func (d Decision) String() string {
+	switch d {
+	case DecisionPrune:
+		return "prune"
+	case DecisionLeft:
+		return "left"
+	case DecisionMatch:
+		return "match"
+	case DecisionMiss:
+		return "miss"
+	case DecisionSkipRight:
+		return "skip right"
+	default:
+		return "unknown"
+	}
+}
+
+// Step of an explained lookup, the visited node and the decision taken there.
+type Step[T any] struct {
+	Item     T   // the item of the visited node
+	Depth    int // the depth of the node, the root has depth 0
+	Decision Decision
+}
+
+// treapNodes, the read access to the treap nodes for the traced query algorithms.
+type treapNodes[T any] struct{}
+
+func (treapNodes[T]) IsNil(n *node[T]) bool     { return n == nil }
+func (treapNodes[T]) Left(n *node[T]) *node[T]  { return n.left }
+func (treapNodes[T]) Right(n *node[T]) *node[T] { return n.right }
+func (treapNodes[T]) Item(n *node[T]) T         { return n.item }
+func (treapNodes[T]) MinUpper(n *node[T]) T     { return n.minUpperItem() }
+func (treapNodes[T]) MaxUpper(n *node[T]) T     { return n.maxUpperItem() }
+
+// explainer, the query algorithms on the treap, each decision is appended to the trace.
+func (t *Tree[T]) explainer(trace *[]Step[T]) *query.Query[*node[T], T] {
+	return &query.Query[*node[T], T]{
+		Nodes: treapNodes[T]{},
+		Cmp:   t.cmp,
+		Trace: func(n *node[T], depth int, d query.Decision) {
+			*trace = append(*trace, Step[T]{Item: n.item, Depth: depth, Decision: Decision(d)})
+		},
+	}
+}
+
+// ExplainCoverLCP is [Tree.CoverLCP] with the trace of the visited nodes and the decisions
+// taken, in the order of the lookup. Useful to debug why an item matched an unexpected
+// interval or to see how the shape of the tree affects the lookups.
+func (t Tree[T]) ExplainCoverLCP(item T) (result T, ok bool, trace []Step[T]) {
+	result, ok = t.explainer(&trace).CoverLCP(t.root, item)
+	return result, ok, trace
+}
+
+// ExplainIntersections is [Tree.Intersections] with the trace of the visited nodes
+// and the decisions taken, in the order of the lookup, see [Tree.ExplainCoverLCP].
+func (t Tree[T]) ExplainIntersections(item T) (result []T, trace []Step[T]) {
+	result = t.explainer(&trace).Intersections(t.root, item, nil)
+	return result, trace
+}
@@ -0,0 +1,57 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+	size, maxDepth, _, _ := tree.Statistics()
+
+	for _, probe := range genUintIvals(100) {
+		want, wantOK := tree.CoverLCP(probe)
+
+		got, ok, trace := tree.ExplainCoverLCP(probe)
+		if got != want || ok != wantOK {
+			t.Fatalf("ExplainCoverLCP(%v), got %v, %v, want %v, %v", probe, got, ok, want, wantOK)
+		}
+
+		if len(trace) == 0 || len(trace) >= size {
+			t.Fatalf("ExplainCoverLCP(%v), got %d steps", probe, len(trace))
+		}
+
+		last := trace[len(trace)-1]
+		if ok != (last.Decision == interval.DecisionMatch) || ok && last.Item != got {
+			t.Fatalf("ExplainCoverLCP(%v), last step %v", probe, last)
+		}
+
+		hits, trace := tree.ExplainIntersections(probe)
+		if !slices.Equal(hits, tree.Intersections(probe)) {
+			t.Fatalf("ExplainIntersections(%v), results differ from Intersections", probe)
+		}
+
+		// the matches in the trace are the results
+		var matched []uintInterval
+		for _, s := range trace {
+			if s.Depth < 0 || s.Depth > maxDepth {
+				t.Fatalf("ExplainIntersections(%v), step %v has invalid depth", probe, s)
+			}
+			if s.Decision == interval.DecisionMatch {
+				matched = append(matched, s.Item)
+			}
+		}
+
+		if !slices.Equal(hits, matched) {
+			t.Fatalf("ExplainIntersections(%v), the matching steps differ from the results", probe)
+		}
+	}
+
+	if got := interval.DecisionSkipRight.String(); got != "skip right" {
+		t.Errorf("Decision.String(), got %q, want %q", got, "skip right")
+	}
+}
@@ -0,0 +1,175 @@
+package interval
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Forest partitions the items into shards by a user-supplied shard key, e.g. the IP version,
+// the first octet or the chromosome, every shard is a separate [Tree].
+//
+// A single treap with 100M+ items hits the GC and depth limits, many smaller trees avoid that.
+// The queries fan out to all shards and the results are merged in sorted order,
+// the items of different shards may still cover or intersect each other.
+//
+// A Forest is not safe for concurrent use, like [Tree].
+type Forest[K cmp.Ordered, T any] struct {
+	cmp      func(a, b T) (ll, rr, lr, rl int)
+	opts     []Option
+	shardKey func(T) K
+
+	// ref, an empty tree for the compare methods, with the effects of the options
+	ref    *Tree[T]
+	shards map[K]*Tree[T]
+}
+
+// NewForest initializes an empty forest with the compare function, the shard key function
+// and the options for the trees in the shards, see [New].
+func NewForest[K cmp.Ordered, T any](cmp func(a, b T) (ll, rr, lr, rl int), shardKey func(T) K, opts ...Option) *Forest[K, T] {
+	return &Forest[K, T]{
+		cmp:      cmp,
+		opts:     opts,
+		shardKey: shardKey,
+		ref:      New(cmp, opts...),
+		shards:   make(map[K]*Tree[T]),
+	}
+}
+
+// Insert inserts the items in place into the trees of their shards, new shards are created as needed.
+func (f *Forest[K, T]) Insert(items ...T) {
+	// group the items by shard, one bulk insert per shard
+	groups := make(map[K][]T)
+	for _, item := range items {
+		k := f.shardKey(item)
+		groups[k] = append(groups[k], item)
+	}
+
+	for k, group := range groups {
+		t := f.shards[k]
+		if t == nil {
+			t = New(f.cmp, f.opts...)
+			f.shards[k] = t
+		}
+		t.Insert(group...)
+	}
+}
+
+// Delete removes the item from its shard, returns true if it exists, false otherwise.
+// Empty shards are dropped.
+func (f *Forest[K, T]) Delete(item T) bool {
+	k := f.shardKey(item)
+	t := f.shards[k]
+	if t == nil {
+		return false
+	}
+
+	ok := t.Delete(item)
+	if t.root == nil {
+		delete(f.shards, k)
+	}
+	return ok
+}
+
+// Keys returns the shard keys in ascending order.
+func (f *Forest[K, T]) Keys() []K {
+	keys := make([]K, 0, len(f.shards))
+	for k := range f.shards {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Shard returns the tree for the shard key or nil if there is no such shard.
+// Changes of the returned tree are changes of f.
+func (f *Forest[K, T]) Shard(key K) *Tree[T] {
+	return f.shards[key]
+}
+
+// Find returns the item in its shard, see [Tree.Find].
+func (f *Forest[K, T]) Find(item T) (result T, ok bool) {
+	if t := f.shards[f.shardKey(item)]; t != nil {
+		return t.Find(item)
+	}
+	return
+}
+
+// CoverLCP returns the longest-prefix-match over all shards, see [Tree.CoverLCP].
+func (f *Forest[K, T]) CoverLCP(item T) (result T, ok bool) {
+	for _, t := range f.shards {
+		if r, found := t.CoverLCP(item); found {
+			// the LCP sorts to the right of all other covering intervals
+			if !ok || f.ref.compare(r, result) > 0 {
+				result, ok = r, true
+			}
+		}
+	}
+	return
+}
+
+// CoverSCP returns the shortest-prefix-match over all shards, see [Tree.CoverSCP].
+func (f *Forest[K, T]) CoverSCP(item T) (result T, ok bool) {
+	for _, t := range f.shards {
+		if r, found := t.CoverSCP(item); found {
+			// the SCP sorts to the left of all other covering intervals
+			if !ok || f.ref.compare(r, result) < 0 {
+				result, ok = r, true
+			}
+		}
+	}
+	return
+}
+
+// Intersects returns true if any interval in any shard intersects item.
+func (f *Forest[K, T]) Intersects(item T) bool {
+	for _, t := range f.shards {
+		if t.Intersects(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers returns all intervals of all shards that cover the item, in sorted order.
+func (f *Forest[K, T]) Covers(item T) []T {
+	return f.merge(func(t *Tree[T]) []T { return t.Covers(item) })
+}
+
+// CoveredBy returns all intervals of all shards that are covered by item, in sorted order.
+func (f *Forest[K, T]) CoveredBy(item T) []T {
+	return f.merge(func(t *Tree[T]) []T { return t.CoveredBy(item) })
+}
+
+// Intersections returns all intervals of all shards that intersect with item, in sorted order.
+func (f *Forest[K, T]) Intersections(item T) []T {
+	return f.merge(func(t *Tree[T]) []T { return t.Intersections(item) })
+}
+
+// Precedes returns all intervals of all shards that precede the item, in sorted order.
+func (f *Forest[K, T]) Precedes(item T) []T {
+	return f.merge(func(t *Tree[T]) []T { return t.Precedes(item) })
+}
+
+// PrecededBy returns all intervals of all shards that are preceded by the item, in sorted order.
+func (f *Forest[K, T]) PrecededBy(item T) []T {
+	return f.merge(func(t *Tree[T]) []T { return t.PrecededBy(item) })
+}
+
+// merge, fan out the query to all shards and merge the results in sorted order.
+func (f *Forest[K, T]) merge(query func(t *Tree[T]) []T) []T {
+	var result []T
+	var hits int
+
+	for _, t := range f.shards {
+		if r := query(t); len(r) > 0 {
+			result = append(result, r...)
+			hits++
+		}
+	}
+
+	// the results of a single shard are already sorted
+	if hits > 1 {
+		slices.SortFunc(result, f.ref.compare)
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestForest(t *testing.T) {
+	t.Parallel()
+
+	shardKey := func(a uintInterval) uint { return a[0] % 7 }
+
+	ivals := genUintIvals(10_000)
+	tree := interval.NewTree(cmpUintInterval, ivals...)
+
+	forest := interval.NewForest(cmpUintInterval, shardKey)
+	forest.Insert(ivals...)
+
+	if n := len(forest.Keys()); n != 7 {
+		t.Fatalf("Keys(), got %d shards, want 7", n)
+	}
+
+	for _, probe := range genUintIvals(100) {
+		if got, want := forest.Covers(probe), tree.Covers(probe); !slices.Equal(got, want) {
+			t.Fatalf("Covers(%v), got %v, want %v", probe, got, want)
+		}
+		if got, want := forest.CoveredBy(probe), tree.CoveredBy(probe); !slices.Equal(got, want) {
+			t.Fatalf("CoveredBy(%v), got %v, want %v", probe, got, want)
+		}
+		if got, want := forest.Intersections(probe), tree.Intersections(probe); !slices.Equal(got, want) {
+			t.Fatalf("Intersections(%v), got %v, want %v", probe, got, want)
+		}
+		if got, want := forest.Precedes(probe), tree.Precedes(probe); !slices.Equal(got, want) {
+			t.Fatalf("Precedes(%v), got %v, want %v", probe, got, want)
+		}
+		if got, want := forest.PrecededBy(probe), tree.PrecededBy(probe); !slices.Equal(got, want) {
+			t.Fatalf("PrecededBy(%v), got %v, want %v", probe, got, want)
+		}
+		if got, want := forest.Intersects(probe), tree.Intersects(probe); got != want {
+			t.Fatalf("Intersects(%v), got %v, want %v", probe, got, want)
+		}
+
+		got, gotOK := forest.CoverLCP(probe)
+		want, wantOK := tree.CoverLCP(probe)
+		if got != want || gotOK != wantOK {
+			t.Fatalf("CoverLCP(%v), got %v %v, want %v %v", probe, got, gotOK, want, wantOK)
+		}
+
+		got, gotOK = forest.CoverSCP(probe)
+		want, wantOK = tree.CoverSCP(probe)
+		if got != want || gotOK != wantOK {
+			t.Fatalf("CoverSCP(%v), got %v %v, want %v %v", probe, got, gotOK, want, wantOK)
+		}
+	}
+
+	for _, item := range ivals {
+		forest.Delete(item)
+	}
+
+	if n := len(forest.Keys()); n != 0 {
+		t.Fatalf("Keys() after deleting all items, got %d shards, want 0", n)
+	}
+	if _, ok := forest.Find(ivals[0]); ok {
+		t.Fatalf("Find(%v) in empty forest, got true, want false", ivals[0])
+	}
+}
@@ -0,0 +1,155 @@
+// Package frozen is a compact, read-only interval tree in a single sorted slice.
+//
+// The tree is implicit, the root of the items in [lo, hi) is the middle item, the left
+// and right subtrees are the halves. There are no child links and no priorities, just the
+// items and the augmented upper bounds per item. For read-only hot paths this is smaller and
+// more cache friendly than pointer-chasing the treap, the [interval.Tree] remains the mutable front end:
+//
+//	ft := frozen.Freeze(tree)
+//	lcp, ok := ft.CoverLCP(item)
+package frozen
+
+import (
+	"github.com/gaissmai/interval"
+	"github.com/gaissmai/interval/internal/query"
+)
+
+// Tree is the read-only interval tree, created by [Freeze].
+type Tree[T any] struct {
+	items    []T
+	minUpper []int32 // index of item with min upper value in subtree
+	maxUpper []int32 // index of item with max upper value in subtree
+	cmp      func(a, b T) (ll, rr, lr, rl int)
+	query    query.Query[span, T]
+}
+
+// Freeze converts the tree into the compact read-only representation in O(n).
+// The compare function and its options, e.g. [interval.WithHalfOpen] or [interval.WithTiebreak], are taken from t.
+func Freeze[T any](t *interval.Tree[T]) *Tree[T] {
+	ft := &Tree[T]{cmp: t.CompareFunc()}
+	ft.query = query.Query[span, T]{Nodes: nodes[T]{ft}, Cmp: ft.cmp, Order: t.OrderFunc()}
+
+	t.Visit(t.Min(), t.Max(), func(item T) bool {
+		ft.items = append(ft.items, item)
+		return true
+	})
+
+	ft.minUpper = make([]int32, len(ft.items))
+	ft.maxUpper = make([]int32, len(ft.items))
+	ft.build(0, len(ft.items))
+
+	return ft
+}
+
+// build rec-descent, calculates the augmented indices bottom-up, returns the index of the subtree root.
+func (ft *Tree[T]) build(lo, hi int) int {
+	if lo >= hi {
+		return -1
+	}
+	mid := lo + (hi-lo)/2
+
+	ft.minUpper[mid] = int32(mid)
+	ft.maxUpper[mid] = int32(mid)
+
+	for _, c := range [2]int{ft.build(lo, mid), ft.build(mid+1, hi)} {
+		if c < 0 {
+			continue
+		}
+		if ft.cmpRR(ft.items[ft.minUpper[mid]], ft.items[ft.minUpper[c]]) > 0 {
+			ft.minUpper[mid] = ft.minUpper[c]
+		}
+		if ft.cmpRR(ft.items[ft.maxUpper[mid]], ft.items[ft.maxUpper[c]]) < 0 {
+			ft.maxUpper[mid] = ft.maxUpper[c]
+		}
+	}
+
+	return mid
+}
+
+// cmpRR, compares just the right point of the intervals.
+func (ft *Tree[T]) cmpRR(a, b T) int {
+	_, rr, _, _ := ft.cmp(a, b)
+	return rr
+}
+
+// span of the implicit subtree, the items in [lo, hi), the root is the middle item.
+type span struct{ lo, hi int }
+
+// mid, the index of the subtree root.
+func (s span) mid() int { return s.lo + (s.hi-s.lo)/2 }
+
+// nodes, the read access to the implicit tree for the query algorithms.
+type nodes[T any] struct{ ft *Tree[T] }
+
+func (ns nodes[T]) IsNil(s span) bool { return s.lo >= s.hi }
+func (ns nodes[T]) Left(s span) span  { return span{s.lo, s.mid()} }
+func (ns nodes[T]) Right(s span) span { return span{s.mid() + 1, s.hi} }
+func (ns nodes[T]) Item(s span) T     { return ns.ft.items[s.mid()] }
+func (ns nodes[T]) MinUpper(s span) T { return ns.ft.items[ns.ft.minUpper[s.mid()]] }
+func (ns nodes[T]) MaxUpper(s span) T { return ns.ft.items[ns.ft.maxUpper[s.mid()]] }
+
+// root, the span of all items.
+func (ft *Tree[T]) root() span {
+	return span{0, len(ft.items)}
+}
+
+// Len returns the number of items.
+func (ft *Tree[T]) Len() int {
+	return len(ft.items)
+}
+
+// Min returns the min item.
+func (ft *Tree[T]) Min() (min T) {
+	if len(ft.items) == 0 {
+		return
+	}
+	return ft.items[0]
+}
+
+// Max returns the max item.
+func (ft *Tree[T]) Max() (max T) {
+	if len(ft.items) == 0 {
+		return
+	}
+	return ft.items[len(ft.items)-1]
+}
+
+// Items returns all items in sorted order, the returned slice must not be modified.
+func (ft *Tree[T]) Items() []T {
+	return ft.items
+}
+
+// Find, see [interval.Tree.Find].
+func (ft *Tree[T]) Find(item T) (result T, ok bool) {
+	return ft.query.Find(ft.root(), item)
+}
+
+// CoverLCP, see [interval.Tree.CoverLCP].
+func (ft *Tree[T]) CoverLCP(item T) (result T, ok bool) {
+	return ft.query.CoverLCP(ft.root(), item)
+}
+
+// CoverSCP, see [interval.Tree.CoverSCP].
+func (ft *Tree[T]) CoverSCP(item T) (result T, ok bool) {
+	return ft.query.CoverSCP(ft.root(), item)
+}
+
+// Covers, see [interval.Tree.Covers].
+func (ft *Tree[T]) Covers(item T) []T {
+	return ft.query.Covers(ft.root(), item, nil)
+}
+
+// CoveredBy, see [interval.Tree.CoveredBy].
+func (ft *Tree[T]) CoveredBy(item T) []T {
+	return ft.query.CoveredBy(ft.root(), item, nil)
+}
+
+// Intersects, see [interval.Tree.Intersects].
+func (ft *Tree[T]) Intersects(item T) bool {
+	return ft.query.Intersects(ft.root(), item)
+}
+
+// Intersections, see [interval.Tree.Intersections].
+func (ft *Tree[T]) Intersections(item T) []T {
+	return ft.query.Intersections(ft.root(), item, nil)
+}
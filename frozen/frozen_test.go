@@ -0,0 +1,179 @@
+package frozen_test
+
+import (
+	"cmp"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gaissmai/interval"
+	"github.com/gaissmai/interval/frozen"
+)
+
+func cmpIval(p, q [2]uint64) (ll, rr, lr, rl int) {
+	return cmp.Compare(p[0], q[0]),
+		cmp.Compare(p[1], q[1]),
+		cmp.Compare(p[0], q[1]),
+		cmp.Compare(p[1], q[0])
+}
+
+func genIvals(n int) [][2]uint64 {
+	is := make([][2]uint64, n)
+	for i := range is {
+		// no points, they are empty for half-open intervals
+		a := rand.Uint64() % 10_000
+		b := a + 1 + rand.Uint64()%1_000
+		is[i] = [2]uint64{a, b}
+	}
+	return is
+}
+
+func TestFreezeEmpty(t *testing.T) {
+	t.Parallel()
+
+	ft := frozen.Freeze(interval.NewTree(cmpIval))
+
+	if ft.Len() != 0 {
+		t.Fatalf("Len(), got: %d, want: 0", ft.Len())
+	}
+
+	probe := [2]uint64{1, 2}
+	if _, ok := ft.CoverLCP(probe); ok {
+		t.Fatal("CoverLCP() on empty tree, got: true, want: false")
+	}
+
+	if ft.Intersects(probe) || ft.Intersections(probe) != nil {
+		t.Fatal("Intersects() on empty tree, got: true, want: false")
+	}
+}
+
+func TestFreezeMatchesTree(t *testing.T) {
+	t.Parallel()
+
+	for _, opts := range [][]interval.Option{nil, {interval.WithHalfOpen()}} {
+		want := interval.New(cmpIval, opts...)
+		want.Insert(genIvals(2_000)...)
+
+		ft := frozen.Freeze(want)
+
+		if size, _, _, _ := want.Statistics(); ft.Len() != size {
+			t.Fatalf("Len(), got: %d, want: %d", ft.Len(), size)
+		}
+
+		if ft.Min() != want.Min() || ft.Max() != want.Max() {
+			t.Fatalf("Min(), Max() differs")
+		}
+
+		for _, probe := range genIvals(1_000) {
+			got, gotOK := ft.CoverLCP(probe)
+			w, wOK := want.CoverLCP(probe)
+			if got != w || gotOK != wOK {
+				t.Fatalf("CoverLCP(%v), got: (%v, %v), want: (%v, %v)", probe, got, gotOK, w, wOK)
+			}
+
+			got, gotOK = ft.CoverSCP(probe)
+			w, wOK = want.CoverSCP(probe)
+			if got != w || gotOK != wOK {
+				t.Fatalf("CoverSCP(%v), got: (%v, %v), want: (%v, %v)", probe, got, gotOK, w, wOK)
+			}
+
+			got, gotOK = ft.Find(probe)
+			w, wOK = want.Find(probe)
+			if got != w || gotOK != wOK {
+				t.Fatalf("Find(%v), got: (%v, %v), want: (%v, %v)", probe, got, gotOK, w, wOK)
+			}
+
+			if ft.Intersects(probe) != want.Intersects(probe) {
+				t.Fatalf("Intersects(%v) differs", probe)
+			}
+
+			if got, w := ft.Covers(probe), want.Covers(probe); !reflect.DeepEqual(got, w) {
+				t.Fatalf("Covers(%v), got: %v, want: %v", probe, got, w)
+			}
+
+			if got, w := ft.CoveredBy(probe), want.CoveredBy(probe); !reflect.DeepEqual(got, w) {
+				t.Fatalf("CoveredBy(%v), got: %v, want: %v", probe, got, w)
+			}
+
+			if got, w := ft.Intersections(probe), want.Intersections(probe); !reflect.DeepEqual(got, w) {
+				t.Fatalf("Intersections(%v), got: %v, want: %v", probe, got, w)
+			}
+		}
+	}
+}
+
+type rule struct {
+	ival [2]uint64
+	id   int
+}
+
+func cmpRule(p, q rule) (ll, rr, lr, rl int) {
+	return cmpIval(p.ival, q.ival)
+}
+
+func TestFreezeTiebreak(t *testing.T) {
+	t.Parallel()
+
+	m := interval.NewMutTree(cmpRule, interval.WithTiebreak(func(a, b rule) int {
+		return cmp.Compare(a.id, b.id)
+	}))
+
+	// equal intervals, distinct items
+	var rules []rule
+	for i, ival := range genIvals(500) {
+		for j := range 3 {
+			rules = append(rules, rule{ival, 3*i + j})
+		}
+	}
+	m.Insert(rules...)
+
+	want := m.Freeze()
+	ft := frozen.Freeze(want)
+
+	var wantItems []rule
+	want.Visit(want.Min(), want.Max(), func(item rule) bool {
+		wantItems = append(wantItems, item)
+		return true
+	})
+
+	if !reflect.DeepEqual(ft.Items(), wantItems) {
+		t.Fatal("Items(), order differs with the tiebreak order of the tree")
+	}
+
+	for _, item := range rules {
+		if got, ok := ft.Find(item); !ok || got != item {
+			t.Fatalf("Find(%v), got: (%v, %v), want: (%v, true)", item, got, ok, item)
+		}
+
+		// any of the equal intervals is the longest-prefix-match
+		got, gotOK := ft.CoverLCP(item)
+		w, wOK := want.CoverLCP(item)
+		if got.ival != w.ival || gotOK != wOK {
+			t.Fatalf("CoverLCP(%v), got: (%v, %v), want: (%v, %v)", item, got, gotOK, w, wOK)
+		}
+
+		if got, w := ft.Covers(item), want.Covers(item); !reflect.DeepEqual(got, w) {
+			t.Fatalf("Covers(%v), got: %v, want: %v", item, got, w)
+		}
+	}
+}
+
+func BenchmarkCoverLCP(b *testing.B) {
+	ivals := genIvals(100_000)
+	probe := genIvals(1)[0]
+
+	tree := interval.NewTree(cmpIval, ivals...)
+	ft := frozen.Freeze(tree)
+
+	b.Run("treap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = tree.CoverLCP(probe)
+		}
+	})
+
+	b.Run("frozen", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = ft.CoverLCP(probe)
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package interval
+
+import "sync/atomic"
+
+// genSeq, the source for the generations, shared by all trees.
+var genSeq atomic.Uint64
+
+// Generation returns the generation of the tree, a number that is stamped on the tree
+// by every insert, delete or union, mutable or immutable.
+//
+// The generations are drawn from a single process-wide counter, a tree derived from another
+// tree has always a greater generation than its origin and two trees with the same generation
+// have the same content. A new tree from [New] has generation 0.
+//
+// Consumers can use it to order snapshots or for optimistic concurrency:
+// remember the generation of a read and compare it before the write.
+//
+// The generation is not part of any serialization, a tree restored with e.g. [Tree.Load]
+// gets a new one.
+func (t Tree[T]) Generation() uint64 {
+	return t.gen
+}
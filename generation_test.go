@@ -0,0 +1,52 @@
+package interval_test
+
+import (
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestGeneration(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.New(cmpUintInterval)
+	if g := tree.Generation(); g != 0 {
+		t.Fatalf("Generation() of a new tree, got %d, want 0", g)
+	}
+
+	t1 := tree.InsertImmutable(genUintIvals(100)...)
+	if t1.Generation() <= tree.Generation() {
+		t.Fatalf("InsertImmutable, generation did not increase: %d <= %d", t1.Generation(), tree.Generation())
+	}
+	if tree.Generation() != 0 {
+		t.Fatalf("InsertImmutable changed the generation of the receiver")
+	}
+
+	g1 := t1.Generation()
+	t2, _ := t1.DeleteImmutable(t1.Min())
+	if t2.Generation() <= g1 {
+		t.Fatalf("DeleteImmutable, generation did not increase: %d <= %d", t2.Generation(), g1)
+	}
+	if t1.Generation() != g1 {
+		t.Fatalf("DeleteImmutable changed the generation of the receiver")
+	}
+
+	t3 := t2.UnionImmutable(t1, false)
+	if t3.Generation() <= t2.Generation() {
+		t.Fatalf("UnionImmutable, generation did not increase: %d <= %d", t3.Generation(), t2.Generation())
+	}
+
+	clone := t3.Clone()
+	if clone.Generation() != t3.Generation() {
+		t.Fatalf("Clone, got generation %d, want %d", clone.Generation(), t3.Generation())
+	}
+
+	g3 := t3.Generation()
+	t3.Insert(uintInterval{1, 2})
+	if t3.Generation() <= g3 {
+		t.Fatalf("Insert, generation did not increase: %d <= %d", t3.Generation(), g3)
+	}
+	if clone.Generation() != g3 {
+		t.Fatalf("Insert changed the generation of the clone")
+	}
+}
@@ -0,0 +1,52 @@
+package interval
+
+// WithHash, every node maintains an order-independent digest of the items in its subtree,
+// with h as the hash function for a single item. [Tree.Hash] returns the digest in O(1).
+//
+// The digests are updated along the changed paths only, subtrees shared between versions are
+// never rehashed. Equal item sets have equal digests, independent of insertion order and tree shape.
+// Trees combined by Union must be configured with the same hash function.
+//
+// The digests cost 8 bytes per node, they are only compiled in with the build tag 'interval_hash'.
+// Without the tag WithHash is an invalid option, see [Option].
+func WithHash[T any](h func(T) uint64) Option {
+	return func(o *options) {
+		o.hash = h
+	}
+}
+
+// Hash returns the digest of all items in the tree, a cheap "did anything change" check
+// for replication and cache invalidation. The tree must be configured [WithHash], otherwise Hash returns 0.
+func (t Tree[T]) Hash() uint64 {
+	if t.root == nil {
+		return 0
+	}
+	return t.root.subtreeHash()
+}
+
+// rehash the digest of node n from the item and the digests of the children.
+// The digest is the sum of the mixed item hashes, commutative and therefore independent of the shape.
+func (t *Tree[T]) rehash(n *node[T]) {
+	if t.hashFn == nil {
+		return
+	}
+
+	h := mix64(t.hashFn(n.item))
+	if n.left != nil {
+		h += n.left.subtreeHash()
+	}
+	if n.right != nil {
+		h += n.right.subtreeHash()
+	}
+	n.setSubtreeHash(h)
+}
+
+// mix64, the splitmix64 finalizer, spreads the bits of weak item hashes before summing.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
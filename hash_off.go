@@ -0,0 +1,17 @@
+//go:build !interval_hash
+
+package interval
+
+// hashSupported, the nodes have no digests, [WithHash] is rejected, see hash_on.go.
+const hashSupported = false
+
+// hashAugment, no digest, zero size.
+type hashAugment struct{}
+
+// subtreeHash, not maintained.
+func (n *node[T]) subtreeHash() uint64 {
+	return 0
+}
+
+// setSubtreeHash, not maintained, no-op.
+func (n *node[T]) setSubtreeHash(uint64) {}
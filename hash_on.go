@@ -0,0 +1,24 @@
+//go:build interval_hash
+
+package interval
+
+// hashSupported, the nodes maintain the digests, see [WithHash].
+const hashSupported = true
+
+// hashAugment, the digest of the subtree, see [WithHash].
+//
+// Build with the tag 'interval_hash' to select this representation. It costs 8 bytes
+// per node, also for trees without [WithHash].
+type hashAugment struct {
+	hash uint64 // digest of the subtree
+}
+
+// subtreeHash returns the digest of the subtree.
+func (n *node[T]) subtreeHash() uint64 {
+	return n.hash
+}
+
+// setSubtreeHash stores the digest of the subtree.
+func (n *node[T]) setSubtreeHash(h uint64) {
+	n.hash = h
+}
@@ -0,0 +1,110 @@
+//go:build interval_hash
+
+package interval_test
+
+import (
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func hashUintIval(p uintInterval) uint64 {
+	return uint64(p[0])<<32 | uint64(p[1])
+}
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(1_000)
+
+	tree1 := interval.New(cmpUintInterval, interval.WithHash(hashUintIval))
+	tree1.Insert(ivals...)
+
+	// reverse insertion order, other shape
+	tree2 := interval.New(cmpUintInterval, interval.WithHash(hashUintIval), interval.WithSeed(42))
+	for i := len(ivals) - 1; i >= 0; i-- {
+		tree2.Insert(ivals[i])
+	}
+
+	if tree1.Hash() == 0 || tree1.Hash() != tree2.Hash() {
+		t.Fatalf("Hash(), same items, got: %x and %x", tree1.Hash(), tree2.Hash())
+	}
+
+	tree3 := tree1.InsertImmutable(uintInterval{1 << 20, 1 << 21})
+	if tree3.Hash() == tree1.Hash() {
+		t.Fatal("Hash() unchanged after insert")
+	}
+
+	tree3, _ = tree3.DeleteImmutable(uintInterval{1 << 20, 1 << 21})
+	if tree3.Hash() != tree1.Hash() {
+		t.Fatal("Hash() differs after insert and delete")
+	}
+
+	other := interval.New(cmpUintInterval, interval.WithHash(hashUintIval))
+	other.Insert(ivals[:10]...)
+
+	tree2.Union(other, true)
+	if tree1.Hash() != tree2.Hash() {
+		t.Fatal("Hash() differs after union with duplicates")
+	}
+
+	if h := interval.NewTree(cmpUintInterval, ivals...).Hash(); h != 0 {
+		t.Fatalf("Hash() without WithHash, got: %x, want: 0", h)
+	}
+}
+
+func TestHashParallel(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(100_000)
+
+	want := interval.New(cmpUintInterval, interval.WithHash(hashUintIval))
+	want.Insert(uintInterval{0, 0})
+	want.Insert(ivals...)
+
+	// bulk insert into a non-empty tree, fan out
+	tree := interval.New(cmpUintInterval, interval.WithHash(hashUintIval), interval.WithParallelism(4))
+	tree.Insert(uintInterval{0, 0})
+	tree.Insert(ivals...)
+
+	if err := tree.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	if tree.Hash() != want.Hash() {
+		t.Fatalf("Hash() after parallel insert, got: %x, want: %x", tree.Hash(), want.Hash())
+	}
+}
+
+func TestHashBulk(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(20_000)
+
+	// the digests are independent of the shape
+	bulk := interval.New(cmpUintInterval, interval.WithHash(hashUintIval))
+	bulk.Insert(ivals...)
+
+	single := interval.New(cmpUintInterval, interval.WithHash(hashUintIval))
+	for _, ival := range ivals {
+		single.Insert(ival)
+	}
+
+	if bulk.Hash() != single.Hash() {
+		t.Fatal("bulk insert, the hash differs from single inserts")
+	}
+
+	// big batches into a small tree, with shared nodes, the deferred recalculation
+	base := interval.New(cmpUintInterval, interval.WithHash(hashUintIval))
+	base.Insert(ivals[:1_000]...)
+	clone := base.Clone()
+	clone.Insert(ivals[1_000:]...)
+
+	if err := clone.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	if clone.Hash() != single.Hash() {
+		t.Fatal("deferred insert, the hash differs from single inserts")
+	}
+}
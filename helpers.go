@@ -5,6 +5,7 @@ import (
 	"io"
 	"math"
 	"strings"
+	"unsafe"
 )
 
 type traverseOrder uint8
@@ -16,45 +17,51 @@ const (
 
 // traverse the BST in some order, call the visitor function for each node.
 // Prematurely stop traversion if visitor function returns false.
+//
+// Iterative walk with an explicit stack of nodes and their depth, no recursion.
 func (t *Tree[T]) traverse(n *node[T], order traverseOrder, depth int, visitFn func(n *node[T], depth int) bool) bool {
-	if n == nil {
-		return true
+	type frame struct {
+		n     *node[T]
+		depth int
 	}
 
-	switch order {
-	case inorder:
-		// left, do-it, right
-		if !t.traverse(n.left, order, depth+1, visitFn) {
-			return false
+	var buf [maxPathLen]frame
+	stack := buf[:0]
+
+	for n != nil || len(stack) > 0 {
+		// push the spine down to the first node in this order
+		for n != nil {
+			stack = append(stack, frame{n, depth})
+			depth++
+
+			switch order {
+			case inorder:
+				// left, do-it, right
+				n = n.left
+			case reverse:
+				// right, do-it, left
+				n = n.right
+			default:
+				panic("unreachable")
+			}
 		}
 
-		if !visitFn(n, depth) {
-			return false
-		}
+		// pop
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
 
-		if !t.traverse(n.right, order, depth+1, visitFn) {
+		if !visitFn(top.n, top.depth) {
 			return false
 		}
 
-		return true
-	case reverse:
-		// right, do-it, left
-		if !t.traverse(n.right, order, depth+1, visitFn) {
-			return false
+		// continue with the other subtree
+		n, depth = top.n.right, top.depth+1
+		if order == reverse {
+			n = top.n.left
 		}
-
-		if !visitFn(n, depth) {
-			return false
-		}
-
-		if !t.traverse(n.left, order, depth+1, visitFn) {
-			return false
-		}
-
-		return true
-	default:
-		panic("unreachable")
 	}
+
+	return true
 }
 
 // String returns a hierarchical tree diagram of the ordered intervals as string, just a wrapper for [Fprint].
@@ -91,62 +98,175 @@ func (t Tree[T]) String() string {
 //	   └─ ff00::/8
 //
 // If the interval items don't implement fmt.Stringer they are stringified with
-// their default format %v.
-func (t Tree[T]) Fprint(w io.Writer) error {
-	// pcm = parent-child-mapping
-	var pcm parentChildsMap[T]
+// their default format %v, see also [WithStringer] and the print options [WithFormatter], [WithASCII],
+// [WithMaxDepth] and [WithMaxChildren].
+func (t Tree[T]) Fprint(w io.Writer, opts ...PrintOption) error {
+	var po printOptions
+	for _, opt := range opts {
+		opt(&po)
+	}
 
-	// init map
-	pcm.pcMap = make(map[*node[T]][]*node[T])
+	format, err := printFormatter(po, t.stringer)
+	if err != nil {
+		return err
+	}
 
-	pcm = t.buildParentChildsMap(t.root, pcm)
+	glyphs := unicodeGlyphs
+	if po.ascii {
+		glyphs = asciiGlyphs
+	}
 
-	if len(pcm.pcMap) == 0 {
+	if t.root == nil {
 		return nil
 	}
 
 	// start symbol
-	if _, err := fmt.Fprint(w, "▼\n"); err != nil {
+	if _, err := fmt.Fprint(w, glyphs.start); err != nil {
 		return err
 	}
 
-	// start recursion with nil parent and empty padding
-	return t.hierarchyStringify(w, nil, pcm, "")
+	p := &printer[T]{w: w, format: format, glyphs: glyphs, maxDepth: po.maxDepth, maxChildren: po.maxChildren}
+
+	// the virtual root level for the top level items
+	p.stack = append(p.stack, printLevel[T]{})
+
+	// stream the items in sorted order, the parent is found on the stack
+	t.traverse(t.root, inorder, 0, func(n *node[T], _ int) bool {
+		p.err = t.printNode(p, n)
+		return p.err == nil
+	})
+	if p.err != nil {
+		return p.err
+	}
+
+	// close all open levels, print the pending markers
+	return p.pop(0)
 }
 
-func (t *Tree[T]) hierarchyStringify(w io.Writer, n *node[T], pcm parentChildsMap[T], pad string) error {
-	// the prefix (pad + glyphe) is already printed on the line on upper level
-	if n != nil {
-		if _, err := fmt.Fprintf(w, "%v\n", n.item); err != nil {
-			return err
-		}
+// printer, the state for the streaming hierarchy printing.
+type printer[T any] struct {
+	w           io.Writer
+	format      func(T) string
+	glyphs      glyphSet
+	maxDepth    int
+	maxChildren int
+
+	stack []printLevel[T] // the open parents, the virtual root at the bottom
+	err   error
+}
+
+// printLevel, an open parent in the hierarchy.
+type printLevel[T any] struct {
+	n      *node[T] // nil for the virtual root
+	pad    string   // the padding for the childs
+	childs int      // number of childs so far
+	elided int      // number of elided childs, see WithMaxChildren
+	hidden bool     // the node and its childs are not printed
+}
+
+// glyphSet, the tree glyphs for printing.
+type glyphSet struct {
+	start, glyphe, spacer, lastGlyphe, lastSpacer, more string
+}
+
+var (
+	unicodeGlyphs = glyphSet{"▼\n", "├─ ", "│  ", "└─ ", "   ", "… (%d more)\n"}
+	asciiGlyphs   = glyphSet{"v\n", "+- ", "|  ", "`- ", "   ", "... (%d more)\n"}
+)
+
+// printNode, the next node in sorted order, the parent is the topmost item on the stack covering it.
+//
+// Remember: sort order of intervals is lower-left, superset to the left:
+// the subtree of a parent in the hierarchy is the contiguous run of the following items
+// covered by it, the first item not covered by the parent closes the level.
+func (t *Tree[T]) printNode(p *printer[T], n *node[T]) error {
+	top := len(p.stack) - 1
+	for top > 0 && !t.cmpCovers(p.stack[top].n.item, n.item) {
+		top--
+	}
+	if err := p.pop(top + 1); err != nil {
+		return err
 	}
 
-	glyphe := "├─ "
-	spacer := "│  "
+	parent := &p.stack[top]
+	parent.childs++
 
-	// dereference child-slice for clearer code
-	childs := pcm.pcMap[n]
+	this := printLevel[T]{n: n, hidden: true}
 
-	// for all childs do, but ...
-	for i, child := range childs {
-		// ... treat last child special
-		if i == len(childs)-1 {
-			glyphe = "└─ "
-			spacer = "   "
+	switch {
+	case parent.hidden || p.maxDepth > 0 && len(p.stack) > p.maxDepth:
+		// depth cutoff, skip silently
+	case p.maxChildren > 0 && parent.childs > p.maxChildren:
+		parent.elided++
+	default:
+		// treat last child special
+		glyphe, spacer := p.glyphs.glyphe, p.glyphs.spacer
+		if !t.hasNextSibling(n, parent.n) {
+			glyphe, spacer = p.glyphs.lastGlyphe, p.glyphs.lastSpacer
 		}
 
-		// print prefix for next item
-		if _, err := fmt.Fprint(w, pad+glyphe); err != nil {
+		if _, err := fmt.Fprintf(p.w, "%s%s%s\n", parent.pad, glyphe, p.format(n.item)); err != nil {
 			return err
 		}
+		this = printLevel[T]{n: n, pad: parent.pad + spacer}
+	}
 
-		// recdescent down
-		if err := t.hierarchyStringify(w, child, pcm, pad+spacer); err != nil {
-			return err
+	p.stack = append(p.stack, this)
+	return nil
+}
+
+// pop the levels down to length k, print the markers for the elided childs.
+func (p *printer[T]) pop(k int) error {
+	for len(p.stack) > k {
+		top := p.stack[len(p.stack)-1]
+		p.stack = p.stack[:len(p.stack)-1]
+
+		if top.elided > 0 {
+			if _, err := fmt.Fprintf(p.w, "%s%s"+p.glyphs.more, top.pad, p.glyphs.lastGlyphe, top.elided); err != nil {
+				return err
+			}
 		}
 	}
+	return nil
+}
+
+// hasNextSibling, reports whether a later item in sorted order has the same parent in the hierarchy.
+//
+// The next item not covered by n closes the level of n, it's a sibling if the parent covers it.
+func (t *Tree[T]) hasNextSibling(n, parent *node[T]) bool {
+	next := t.nextNotCovered(t.root, n.item)
+	if next == nil {
+		return false
+	}
+	return parent == nil || t.cmpCovers(parent.item, next.item)
+}
+
+// nextNotCovered rec-descent, the first node behind item in sorted order that isn't covered by item.
+// Behind item, that are the nodes with a greater right point.
+func (t *Tree[T]) nextNotCovered(n *node[T], item T) *node[T] {
+	for n != nil {
+		// nope, no right point in this subtree is greater
+		if t.cmpRR(n.maxUpperItem(), item) <= 0 {
+			return nil
+		}
+
+		// n and the left subtree are not behind the item
+		if t.compare(n.item, item) <= 0 {
+			n = n.right
+			continue
+		}
+
+		// leftmost first
+		if m := t.nextNotCovered(n.left, item); m != nil {
+			return m
+		}
 
+		if t.cmpRR(n.item, item) > 0 {
+			return n
+		}
+
+		n = n.right
+	}
 	return nil
 }
 
@@ -185,8 +305,13 @@ func (t Tree[T]) FprintBST(w io.Writer) error {
 // binarytreeStringify, traverse the tree, stringify the nodes in preorder
 func (t *Tree[T]) binarytreeStringify(w io.Writer, n *node[T], pad string) error {
 	// stringify this node
+	var item any = n.item
+	if t.stringer != nil {
+		item = t.stringer(n.item)
+	}
+
 	_, err := fmt.Fprintf(w, "%v [prio:%.4g] [%p|l:%p|r:%p]\n",
-		n.item, float64(n.prio)/math.MaxUint32, n, n.left, n.right)
+		item, float64(n.prio)/math.MaxUint32, n, n.left, n.right)
 	if err != nil {
 		return err
 	}
@@ -227,70 +352,16 @@ func (t *Tree[T]) binarytreeStringify(w io.Writer, n *node[T], pad string) error
 	return nil
 }
 
-// parentChildsMap, needed for interval tree printing, this is not BST printing!
-//
-// Interval tree, parent->childs relation printed. A parent interval covers a child interval.
-//
-//	▼
-//	├─ 0...6
-//	│  └─ 0...5
-//	├─ 1...8
-//	│  ├─ 1...7
-//	│  │  └─ 1...5
-//	│  │     └─ 1...4
-//	│  └─ 2...8
-//	│     ├─ 2...7
-//	│     └─ 4...8
-//	│        └─ 6...7
-//	└─ 7...9
-type parentChildsMap[T any] struct {
-	pcMap map[*node[T]][]*node[T] // parent -> []child map
-	stack []*node[T]              // just needed for the algo
-}
-
-// buildParentChildsMap, in-order traversal
-func (t *Tree[T]) buildParentChildsMap(n *node[T], pcm parentChildsMap[T]) parentChildsMap[T] {
-	if n == nil {
-		return pcm
-	}
-
-	// in-order traversal, left tree
-	pcm = t.buildParentChildsMap(n.left, pcm)
-
-	// detect parent-child-mapping for this node
-	pcm = t.pcmForNode(n, pcm)
-
-	// in-order traversal, right tree
-	return t.buildParentChildsMap(n.right, pcm)
-}
-
-// pcmForNode, find parent in stack, remove items from stack, put this item on stack.
-func (t *Tree[T]) pcmForNode(n *node[T], pcm parentChildsMap[T]) parentChildsMap[T] {
-	// if this item is covered by a prev item on stack
-	for j := len(pcm.stack) - 1; j >= 0; j-- {
-
-		that := pcm.stack[j]
-		if t.cmpCovers(that.item, n.item) {
-			// item in node j is parent to item
-			pcm.pcMap[that] = append(pcm.pcMap[that], n)
-			break
-		}
-
-		// Remember: sort order of intervals is lower-left, superset to the left:
-		// if this item wasn't covered by j, remove node at j from stack
-		pcm.stack = pcm.stack[:j]
-	}
-
-	// stack is emptied, no item on stack covers current item
-	if len(pcm.stack) == 0 {
-		// parent is root
-		pcm.pcMap[nil] = append(pcm.pcMap[nil], n)
-	}
-
-	// put current neode on stack for next node
-	pcm.stack = append(pcm.stack, n)
-
-	return pcm
+// Height returns the number of nodes on the longest path from the root to a leaf,
+// 0 for an empty tree and maxDepth+1 of [Tree.Statistics] otherwise.
+// The height of a treap is O(log n) with high probability, it is computed in O(n).
+func (t Tree[T]) Height() int {
+	var height int
+	t.traverse(t.root, inorder, 1, func(_ *node[T], depth int) bool {
+		height = max(height, depth)
+		return true
+	})
+	return height
 }
 
 // Statistics, returns the maxDepth, average and standard deviation of the nodes.
@@ -299,15 +370,7 @@ func (t *Tree[T]) pcmForNode(n *node[T], pcm parentChildsMap[T]) parentChildsMap
 // 0.x.y. In future versions this will be removed without increasing the main
 // semantic version, so please do not rely on it for now.
 func (t Tree[T]) Statistics() (size int, maxDepth int, average, deviation float64) {
-	// key is depth, value is the sum of nodes with this depth
-	depths := make(map[int]int)
-
-	// get the depths, sum up the size
-	t.traverse(t.root, inorder, 0, func(n *node[T], depth int) bool {
-		depths[depth] += 1
-		size += 1
-		return true
-	})
+	depths := t.DepthHistogram()
 
 	var weightedSum, sum int
 	for k, v := range depths {
@@ -317,6 +380,7 @@ func (t Tree[T]) Statistics() (size int, maxDepth int, average, deviation float6
 			maxDepth = k
 		}
 	}
+	size = sum
 
 	average = float64(weightedSum) / float64(sum)
 
@@ -330,30 +394,105 @@ func (t Tree[T]) Statistics() (size int, maxDepth int, average, deviation float6
 	return size, maxDepth, math.Round(average*10000) / 10000, math.Round(deviation*10000) / 10000
 }
 
-// Min returns the min item in tree.
+// DepthHistogram returns the number of nodes per depth, the root has depth 0, e.g. to plot
+// the balance of the tree over time. The histogram of an empty tree is empty.
+func (t Tree[T]) DepthHistogram() map[int]int {
+	// key is depth, value is the sum of nodes with this depth
+	depths := make(map[int]int)
+
+	t.traverse(t.root, inorder, 0, func(n *node[T], depth int) bool {
+		depths[depth] += 1
+		return true
+	})
+
+	return depths
+}
+
+// MemStats returns the number of nodes and an estimate of the memory in bytes used by the tree,
+// in O(1). The estimate covers the nodes with the items and the augmentation, as laid out
+// in memory, but not the memory referenced by the items, e.g. the bytes of strings or slices.
+// Nodes shared with other trees are counted fully, the unused rest of an arena chunk not at all.
+func (t Tree[T]) MemStats() (nodes int, bytes uintptr) {
+	if t.root != nil {
+		nodes = t.root.size
+	}
+	return nodes, unsafe.Sizeof(t) + uintptr(nodes)*unsafe.Sizeof(node[T]{})
+}
+
+// Min returns the min item in tree, in O(1).
 func (t Tree[T]) Min() (min T) {
-	n := t.root
-	if n == nil {
+	if t.min == nil {
 		return
 	}
+	return t.min.item
+}
 
-	for n.left != nil {
-		n = n.left
+// Max returns the max item in tree, in O(1).
+func (t Tree[T]) Max() (max T) {
+	if t.max == nil {
+		return
 	}
-	return n.item
+	return t.max.item
 }
 
-// Max returns the max item in tree.
-func (t Tree[T]) Max() (max T) {
-	n := t.root
-	if n == nil {
+// CompareFunc returns the compare function of the tree, including the effects of the options,
+// e.g. [WithHalfOpen]. Useful for derived structures with the same semantics, see package frozen.
+func (t Tree[T]) CompareFunc() func(a, b T) (ll, rr, lr, rl int) {
+	return t.cmp
+}
+
+// OrderFunc returns the sort order of the items in the tree, including the effects of the options,
+// e.g. [WithTiebreak] for items with equal intervals. Useful for derived structures with the same order.
+func (t Tree[T]) OrderFunc() func(a, b T) int {
+	return t.compare
+}
+
+// mustCmp, panics with ErrNoCompareFunc if n items are to be added to a tree without compare function.
+func (t *Tree[T]) mustCmp(n int) {
+	if t.cmp == nil && n > 0 {
+		panic(ErrNoCompareFunc)
+	}
+}
+
+// adopt, a zero value tree takes over the compare function and the configuration of other,
+// before the union with other.
+func (t *Tree[T]) adopt(other *Tree[T]) {
+	if t.cmp != nil || other.cmp == nil {
 		return
 	}
 
-	for n.right != nil {
-		n = n.right
+	t.cmp = other.cmp
+	t.arena = other.arena
+	t.opts = other.opts
+	t.hashFn = other.hashFn
+	t.onInsert = other.onInsert
+	t.onDelete = other.onDelete
+	t.tiebreak = other.tiebreak
+	t.tags = other.tags
+	t.stringer = other.stringer
+}
+
+// changed, must be called after every change of the tree, refreshes the cached
+// leftmost and rightmost node and stamps the tree with a new generation.
+func (t *Tree[T]) changed() {
+	t.updateMinMax()
+	t.gen = genSeq.Add(1)
+}
+
+// updateMinMax, cache the leftmost and rightmost node, see changed.
+func (t *Tree[T]) updateMinMax() {
+	t.min, t.max = t.root, t.root
+	if t.root == nil {
+		return
+	}
+
+	for t.min.left != nil {
+		t.min = t.min.left
+	}
+
+	for t.max.right != nil {
+		t.max = t.max.right
 	}
-	return n.item
 }
 
 // Visit traverses the tree with item >= start to item <= stop in ascending order,
@@ -379,34 +518,69 @@ func (t Tree[T]) Visit(start, stop T, visitFn func(item T) bool) {
 		order = reverse
 	}
 
-	// treaps are really cool datastructures!!!
-	_, mid1, r := t.split(t.root, start, true)
-	l, mid2, _ := t.split(r, stop, true)
-
-	span := (&t).join(mid1, (&t).join(l, mid2, true), true)
-
-	t.traverse(span, order, 0, func(n *node[T], _ int) bool {
-		return visitFn(n.item)
-	})
-}
-
-// Clone, deep cloning of the tree structure.
-func (t Tree[T]) Clone() *Tree[T] {
-	c := t
-	c.root = t.clone(t.root)
-	return &c
+	t.visit(t.root, start, stop, order, visitFn)
 }
 
-// clone rec-descent
-func (t *Tree[T]) clone(n *node[T]) *node[T] {
+// visit rec-descent, bounded in-order (or reverse) traversal, skip subtrees outside [start, stop].
+func (t *Tree[T]) visit(n *node[T], start, stop T, order traverseOrder, visitFn func(item T) bool) bool {
 	if n == nil {
-		return n
+		return true
 	}
-	n = n.copyNode()
 
-	n.left = t.clone(n.left)
-	n.right = t.clone(n.right)
-	t.recalc(n)
+	// n is in span?
+	cmpStart := t.compare(n.item, start)
+	cmpStop := t.compare(n.item, stop)
+
+	switch order {
+	case inorder:
+		// left subtree only if n is greater than start
+		if cmpStart > 0 && !t.visit(n.left, start, stop, order, visitFn) {
+			return false
+		}
+
+		if cmpStart >= 0 && cmpStop <= 0 && !visitFn(n.item) {
+			return false
+		}
+
+		// right subtree only if n is less than stop
+		if cmpStop < 0 && !t.visit(n.right, start, stop, order, visitFn) {
+			return false
+		}
+
+		return true
+	case reverse:
+		// right subtree only if n is less than stop
+		if cmpStop < 0 && !t.visit(n.right, start, stop, order, visitFn) {
+			return false
+		}
+
+		if cmpStart >= 0 && cmpStop <= 0 && !visitFn(n.item) {
+			return false
+		}
+
+		// left subtree only if n is greater than start
+		if cmpStart > 0 && !t.visit(n.left, start, stop, order, visitFn) {
+			return false
+		}
+
+		return true
+	default:
+		panic("unreachable")
+	}
+}
 
-	return n
+// Clone returns a copy of the tree in O(1), the copy shares all nodes with the tree.
+//
+// Both trees give up the ownership of the shared nodes, subsequent in place modifications
+// of either tree copy the changed nodes first (copy-on-write).
+//
+// A tree that owns no nodes, e.g. a version published in a [Store] or the result of an immutable
+// operation, is not changed, Clone is then safe for concurrent use. Otherwise the receiver
+// gives up the ownership, like any in place modification it must not run concurrently.
+func (t *Tree[T]) Clone() *Tree[T] {
+	if t.owner != 0 {
+		t.owner = 0
+	}
+	c := *t
+	return &c
 }
@@ -0,0 +1,74 @@
+package interval
+
+import (
+	"slices"
+	"testing"
+)
+
+// the traversal is unexported, tested in package interval on a tree of known shape
+func TestTraverse(t *testing.T) {
+	t.Parallel()
+
+	//	        4
+	//	    2       6
+	//	  1   3   5   7
+	prios := map[int]uint32{4: 3, 2: 2, 6: 2, 1: 1, 3: 1, 5: 1, 7: 1}
+
+	var pairs []Prioritized[[2]int]
+	for i := 1; i <= 7; i++ {
+		pairs = append(pairs, Prioritized[[2]int]{Item: [2]int{i, i}, Prio: prios[i]})
+	}
+	tree := NewTreeWithPriorities(cmpPair[int], pairs...)
+
+	tests := []struct {
+		name       string
+		order      traverseOrder
+		depth      int // start depth
+		stop       int // stop after k callbacks, 0: never
+		wantItems  []int
+		wantDepths []int
+		wantOK     bool
+	}{
+		{"inorder", inorder, 0, 0, []int{1, 2, 3, 4, 5, 6, 7}, []int{2, 1, 2, 0, 2, 1, 2}, true},
+		{"reverse", reverse, 0, 0, []int{7, 6, 5, 4, 3, 2, 1}, []int{2, 1, 2, 0, 2, 1, 2}, true},
+		{"inorder, start depth 1", inorder, 1, 0, []int{1, 2, 3, 4, 5, 6, 7}, []int{3, 2, 3, 1, 3, 2, 3}, true},
+		{"inorder, stop after 1", inorder, 0, 1, []int{1}, []int{2}, false},
+		{"inorder, stop after 4", inorder, 0, 4, []int{1, 2, 3, 4}, []int{2, 1, 2, 0}, false},
+		{"reverse, stop after 3", reverse, 0, 3, []int{7, 6, 5}, []int{2, 1, 2}, false},
+		{"reverse, stop after 7", reverse, 0, 7, []int{7, 6, 5, 4, 3, 2, 1}, []int{2, 1, 2, 0, 2, 1, 2}, false},
+	}
+
+	for _, tt := range tests {
+		var items, depths []int
+		ok := tree.traverse(tree.root, tt.order, tt.depth, func(n *node[[2]int], depth int) bool {
+			items = append(items, n.item[0])
+			depths = append(depths, depth)
+			return tt.stop == 0 || len(items) < tt.stop
+		})
+
+		if ok != tt.wantOK {
+			t.Errorf("%s: got %v, want %v", tt.name, ok, tt.wantOK)
+		}
+		if !slices.Equal(items, tt.wantItems) {
+			t.Errorf("%s: items, got %v, want %v", tt.name, items, tt.wantItems)
+		}
+		if !slices.Equal(depths, tt.wantDepths) {
+			t.Errorf("%s: depths, got %v, want %v", tt.name, depths, tt.wantDepths)
+		}
+	}
+
+	// empty subtree, no callbacks
+	if !tree.traverse(nil, inorder, 0, func(*node[[2]int], int) bool { panic("unreachable") }) {
+		t.Error("traverse of empty subtree, got false, want true")
+	}
+
+	// a subtree, depths relative to the start depth
+	var items []int
+	tree.traverse(tree.root.right, reverse, 1, func(n *node[[2]int], depth int) bool {
+		items = append(items, n.item[0], depth)
+		return true
+	})
+	if want := []int{7, 2, 6, 1, 5, 2}; !slices.Equal(items, want) {
+		t.Errorf("traverse of right subtree, got %v, want %v", items, want)
+	}
+}
@@ -0,0 +1,67 @@
+package interval
+
+// WithOnInsert, fn is called for each item inserted into the tree, by Insert, the bulk and
+// immutable variants and by Union. An item replacing a duplicate counts as inserted,
+// a duplicate kept by Union without overwrite does not.
+//
+// The hooks are called synchronously in the goroutine of the operation, in no definite order.
+// They must not modify the tree. Useful for audit trails or to keep secondary indexes in sync.
+// All trees derived from this tree by immutable operations call the same hooks.
+// For the type parameter see [Option].
+func WithOnInsert[T any](fn func(item T)) Option {
+	return func(o *options) {
+		o.onInsert = fn
+	}
+}
+
+// WithOnDelete, fn is called for each item removed from the tree by Delete or DeleteImmutable,
+// with the item as stored in the tree. See [WithOnInsert] for the details.
+func WithOnDelete[T any](fn func(item T)) Option {
+	return func(o *options) {
+		o.onDelete = fn
+	}
+}
+
+// inserted, reports the inserted items to the metrics and hooks, if configured.
+func (t *Tree[T]) inserted(items ...T) {
+	if m := t.metrics(); m != nil {
+		m.Inserted(len(items))
+	}
+
+	if t.onInsert != nil {
+		for _, item := range items {
+			t.onInsert(item)
+		}
+	}
+}
+
+// deleted, reports the deleted item to the metrics and hooks, if configured.
+func (t *Tree[T]) deleted(item T) {
+	if m := t.metrics(); m != nil {
+		m.Deleted(1)
+	}
+
+	if t.onDelete != nil {
+		t.onDelete(item)
+	}
+}
+
+// unioned, must be called before the union with other, reports the items of other
+// that will be inserted to the hooks, if configured.
+func (t *Tree[T]) unioned(other *Tree[T], overwrite bool) {
+	if t.onInsert == nil || other.root == nil {
+		return
+	}
+
+	other.traverse(other.root, inorder, 0, func(n *node[T], _ int) bool {
+		if overwrite {
+			t.onInsert(n.item)
+			return true
+		}
+
+		if t.find(n.item) == nil {
+			t.onInsert(n.item)
+		}
+		return true
+	})
+}
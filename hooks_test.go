@@ -0,0 +1,62 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestHooks(t *testing.T) {
+	t.Parallel()
+
+	var inserted, deleted []uintInterval
+	tree := interval.New(cmpUintInterval,
+		interval.WithOnInsert(func(item uintInterval) { inserted = append(inserted, item) }),
+		interval.WithOnDelete(func(item uintInterval) { deleted = append(deleted, item) }),
+	)
+
+	tree.Insert(uintInterval{0, 10}, uintInterval{2, 5})
+	tree = tree.InsertImmutable(uintInterval{3, 4})
+
+	if want := []uintInterval{{0, 10}, {2, 5}, {3, 4}}; !slices.Equal(inserted, want) {
+		t.Fatalf("OnInsert, got %v, want %v", inserted, want)
+	}
+
+	tree.Delete(uintInterval{2, 5})
+	tree.Delete(uintInterval{2, 5})
+	tree, _ = tree.DeleteImmutable(uintInterval{3, 4})
+
+	if want := []uintInterval{{2, 5}, {3, 4}}; !slices.Equal(deleted, want) {
+		t.Fatalf("OnDelete, got %v, want %v", deleted, want)
+	}
+
+	// union without overwrite, the duplicate {0,10} is kept and not reported
+	inserted = nil
+	other := interval.NewTree(cmpUintInterval, uintInterval{0, 10}, uintInterval{7, 9})
+	tree.Union(other, false)
+
+	if want := []uintInterval{{7, 9}}; !slices.Equal(inserted, want) {
+		t.Fatalf("OnInsert by Union, got %v, want %v", inserted, want)
+	}
+
+	// union with overwrite, all items of other are reported
+	inserted = nil
+	tree = tree.UnionImmutable(other, true)
+
+	if want := []uintInterval{{0, 10}, {7, 9}}; !slices.Equal(inserted, want) {
+		t.Fatalf("OnInsert by UnionImmutable, got %v, want %v", inserted, want)
+	}
+}
+
+func TestHooksTypeMismatch(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("New with mismatched OnInsert hook, expected panic")
+		}
+	}()
+
+	interval.New(cmpUintInterval, interval.WithOnInsert(func(string) {}))
+}
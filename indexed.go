@@ -0,0 +1,79 @@
+package interval
+
+// Indexed is a tree with a secondary index, the items are also found by an ID derived
+// from the items, e.g. the UUID of a rule. The IDs must be unique, an item replaces the
+// item with the same ID, even with different endpoints.
+//
+// An Indexed is not safe for concurrent use, like [Tree].
+type Indexed[K comparable, T any] struct {
+	tree  *Tree[T]
+	id    func(item T) K
+	index map[K]T
+}
+
+// NewIndexed returns an empty tree with the secondary index for the ID function, the tree
+// is created with the compare function and the options, see [New].
+func NewIndexed[K comparable, T any](cmp func(a, b T) (ll, rr, lr, rl int), id func(item T) K, opts ...Option) *Indexed[K, T] {
+	return &Indexed[K, T]{tree: New(cmp, opts...), id: id, index: make(map[K]T)}
+}
+
+// Insert inserts the items in place and updates the index. Duplicate intervals and items
+// with the same ID are replaced.
+func (x *Indexed[K, T]) Insert(items ...T) {
+	for _, item := range items {
+		k := x.id(item)
+
+		// the same ID with other endpoints
+		if old, ok := x.index[k]; ok {
+			x.tree.deleteInPlace(old)
+		}
+
+		// the same endpoints with another ID
+		if n := x.tree.find(item); n != nil {
+			delete(x.index, x.id(n.item))
+		}
+
+		x.tree.insertInPlace(item)
+		x.index[k] = item
+	}
+}
+
+// Delete removes the item and its ID, returns true if it exists, false otherwise.
+func (x *Indexed[K, T]) Delete(item T) bool {
+	n := x.tree.find(item)
+	if n == nil {
+		return false
+	}
+
+	delete(x.index, x.id(n.item))
+	return x.tree.deleteInPlace(item)
+}
+
+// FindByID returns the item with the ID in O(1), ok is false if not found.
+func (x *Indexed[K, T]) FindByID(k K) (item T, ok bool) {
+	item, ok = x.index[k]
+	return
+}
+
+// DeleteByID removes the item with the ID in O(1)+O(log n), returns the removed item and true,
+// false if not found. The endpoints of the item are not needed.
+func (x *Indexed[K, T]) DeleteByID(k K) (item T, ok bool) {
+	if item, ok = x.index[k]; !ok {
+		return
+	}
+
+	delete(x.index, k)
+	x.tree.deleteInPlace(item)
+	return item, true
+}
+
+// Len returns the number of items.
+func (x *Indexed[K, T]) Len() int {
+	return len(x.index)
+}
+
+// Tree returns a snapshot of the tree in O(1) for the interval queries, see [Tree.Clone].
+// Changes of the snapshot don't affect x.
+func (x *Indexed[K, T]) Tree() *Tree[T] {
+	return x.tree.Clone()
+}
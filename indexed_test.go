@@ -0,0 +1,77 @@
+package interval_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+type rule struct {
+	id   string
+	ival uintInterval
+}
+
+func cmpRule(a, b rule) (ll, rr, lr, rl int) {
+	return cmpUintInterval(a.ival, b.ival)
+}
+
+func TestIndexed(t *testing.T) {
+	t.Parallel()
+
+	x := interval.NewIndexed(cmpRule, func(r rule) string { return r.id })
+
+	for i, ival := range genUintIvals(1_000) {
+		x.Insert(rule{id: fmt.Sprint(i), ival: ival})
+	}
+
+	tree := x.Tree()
+	if size, _, _, _ := tree.Statistics(); size != x.Len() {
+		t.Fatalf("Len(), got %d, tree has %d items", x.Len(), size)
+	}
+
+	// every item in the tree is found by its ID
+	tree.Visit(tree.Min(), tree.Max(), func(r rule) bool {
+		if got, ok := x.FindByID(r.id); !ok || got != r {
+			t.Fatalf("FindByID(%s), got %v, %v, want %v", r.id, got, ok, r)
+		}
+		return true
+	})
+
+	// same ID, other endpoints
+	x.Insert(rule{id: "a", ival: uintInterval{1, 2}})
+	x.Insert(rule{id: "a", ival: uintInterval{3, 4}})
+	if _, ok := x.Tree().Find(rule{ival: uintInterval{1, 2}}); ok {
+		t.Fatalf("the item with the replaced ID is still in the tree")
+	}
+
+	// same endpoints, other ID
+	x.Insert(rule{id: "b", ival: uintInterval{3, 4}})
+	if _, ok := x.FindByID("a"); ok {
+		t.Fatalf("FindByID(a), the replaced item is still in the index")
+	}
+
+	got, ok := x.DeleteByID("b")
+	if !ok || got.ival != (uintInterval{3, 4}) {
+		t.Fatalf("DeleteByID(b), got %v, %v", got, ok)
+	}
+	if _, ok := x.Tree().Find(got); ok {
+		t.Fatalf("DeleteByID(b), the item is still in the tree")
+	}
+	if _, ok := x.DeleteByID("b"); ok {
+		t.Fatalf("DeleteByID(b), deleted twice")
+	}
+
+	r, _ := x.FindByID("0")
+	if !x.Delete(rule{ival: r.ival}) {
+		t.Fatalf("Delete(%v), got false", r.ival)
+	}
+	if _, ok := x.FindByID("0"); ok {
+		t.Fatalf("Delete, the ID is still in the index")
+	}
+
+	// the snapshot is unchanged
+	if size, _, _, _ := tree.Statistics(); size != x.Len()+1 {
+		t.Fatalf("snapshot changed, got %d items, want %d", size, x.Len()+1)
+	}
+}
@@ -0,0 +1,135 @@
+package interval
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ReadItems reads CSV records from r and parses each record with parse into an item.
+// Lines starting with '#' are comments, the number of fields may vary from record to record.
+// The record slice is reused, parse must not retain it.
+//
+// Insert the items with a single call to [Tree.Insert], for huge inputs into a tree created
+// with [WithParallelism] for the concurrent bulk build:
+//
+//	items, err := interval.ReadItems(r, parseRange)
+//	tree := interval.New(cmpRange, interval.WithParallelism(0))
+//	tree.Insert(items...)
+func ReadItems[T any](r io.Reader, parse func(record []string) (T, error)) ([]T, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	var items []T
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return items, nil
+		}
+		if err != nil {
+			return items, fmt.Errorf("interval: reading CSV: %w", err)
+		}
+
+		item, err := parse(record)
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return items, fmt.Errorf("interval: parsing CSV line %d: %w", line, err)
+		}
+		items = append(items, item)
+	}
+}
+
+// MaxNDJSONLineSize is the maximum length of a line for [ReadItemsNDJSON], including the newline.
+const MaxNDJSONLineSize = 1 << 20
+
+// ReadItemsNDJSON reads newline delimited JSON from r, each non-empty line is parsed with parse into an item.
+// If parse is nil, the lines are decoded with [json.Unmarshal]. Lines longer than [MaxNDJSONLineSize]
+// are rejected with an error wrapping [bufio.ErrTooLong].
+//
+// See [ReadItems] for the bulk build.
+func ReadItemsNDJSON[T any](r io.Reader, parse func(line []byte) (T, error)) ([]T, error) {
+	if parse == nil {
+		parse = func(line []byte) (item T, err error) {
+			err = json.Unmarshal(line, &item)
+			return
+		}
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, MaxNDJSONLineSize)
+
+	var items []T
+	lineNo := 1
+	for ; sc.Scan(); lineNo++ {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		item, err := parse(line)
+		if err != nil {
+			return items, fmt.Errorf("interval: parsing NDJSON line %d: %w", lineNo, err)
+		}
+		items = append(items, item)
+	}
+
+	if err := sc.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return items, fmt.Errorf("interval: reading NDJSON line %d, longer than %d bytes: %w", lineNo, MaxNDJSONLineSize, err)
+		}
+		return items, fmt.Errorf("interval: reading NDJSON: %w", err)
+	}
+
+	return items, nil
+}
+
+// WriteItems writes all items of the tree in sorted order as CSV records to w,
+// format returns the fields of the record for an item.
+func WriteItems[T any](w io.Writer, t *Tree[T], format func(item T) []string) error {
+	cw := csv.NewWriter(w)
+
+	var err error
+	t.traverse(t.root, inorder, 0, func(n *node[T], _ int) bool {
+		err = cw.Write(format(n.item))
+		return err == nil
+	})
+	if err != nil {
+		return fmt.Errorf("interval: writing CSV: %w", err)
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteItemsNDJSON writes all items of the tree in sorted order as newline delimited JSON to w.
+// If encode is nil, the items are encoded with [json.Marshal].
+func WriteItemsNDJSON[T any](w io.Writer, t *Tree[T], encode func(item T) ([]byte, error)) error {
+	if encode == nil {
+		encode = func(item T) ([]byte, error) { return json.Marshal(item) }
+	}
+
+	bw := bufio.NewWriter(w)
+
+	var err error
+	t.traverse(t.root, inorder, 0, func(n *node[T], _ int) bool {
+		var data []byte
+		if data, err = encode(n.item); err != nil {
+			err = fmt.Errorf("interval: encoding item %v: %w", n.item, err)
+			return false
+		}
+		bw.Write(data)
+		bw.WriteByte('\n')
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
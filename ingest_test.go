@@ -0,0 +1,96 @@
+package interval_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func parseUintIvalRecord(record []string) (p uintInterval, err error) {
+	for i := range p {
+		var u uint64
+		if u, err = strconv.ParseUint(record[i], 10, 64); err != nil {
+			return
+		}
+		p[i] = uint(u)
+	}
+	return
+}
+
+func formatUintIvalRecord(p uintInterval) []string {
+	return []string{strconv.FormatUint(uint64(p[0]), 10), strconv.FormatUint(uint64(p[1]), 10)}
+}
+
+func TestReadWriteItems(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(60_000)...)
+
+	var buf bytes.Buffer
+	if err := interval.WriteItems(&buf, tree, formatUintIvalRecord); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := interval.ReadItems(&buf, parseUintIvalRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// bulk insert into a non-empty tree, the concurrent path
+	got := interval.New(cmpUintInterval, interval.WithParallelism(4))
+	got.Insert(items[0])
+	got.Insert(items...)
+
+	if err := got.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !equalsSizeAndOrder(tree, got) {
+		t.Fatal("ReadItems(WriteItems()) differs")
+	}
+
+	// comments and parse errors with line numbers
+	csv := "# from,to\n1,5\n2,x\n"
+	if _, err := interval.ReadItems(strings.NewReader(csv), parseUintIvalRecord); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("ReadItems(%q), got: %v, want: error in line 3", csv, err)
+	}
+}
+
+func TestReadWriteItemsNDJSON(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, ps...)
+
+	var buf bytes.Buffer
+	if err := interval.WriteItemsNDJSON(&buf, tree, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(buf.String(), "[0,6]\n[0,5]\n") {
+		t.Fatalf("WriteItemsNDJSON(), got: %q", buf.String())
+	}
+
+	items, err := interval.ReadItemsNDJSON[uintInterval](&buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := interval.NewTree(cmpUintInterval, items...); !equalsSizeAndOrder(tree, got) {
+		t.Fatal("ReadItemsNDJSON(WriteItemsNDJSON()) differs")
+	}
+
+	long := "[1,5]\n" + strings.Repeat(" ", interval.MaxNDJSONLineSize) + "[2,6]\n"
+	if _, err := interval.ReadItemsNDJSON[uintInterval](strings.NewReader(long), nil); !errors.Is(err, bufio.ErrTooLong) || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("ReadItemsNDJSON(long line), got: %v, want: ErrTooLong in line 2", err)
+	}
+
+	ndjson := "[1,5]\n\n[2,\n"
+	if _, err := interval.ReadItemsNDJSON[uintInterval](strings.NewReader(ndjson), nil); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("ReadItemsNDJSON(%q), got: %v, want: error in line 3", ndjson, err)
+	}
+}
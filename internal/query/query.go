@@ -0,0 +1,327 @@
+// Package query implements the interval queries once for the read-only representations
+// of the augmented treap, e.g. the slab, the memory-mapped tree and the frozen slice.
+//
+// The representations differ in how the nodes are addressed, by pointers, by indices or by
+// ranges of an implicit tree, but share the BST order and the augmentation with the items of
+// min and max upper value per subtree. They provide the read access to their nodes with [Nodes],
+// the algorithms are the same as in the mutable treap, see there for the details.
+package query
+
+// Nodes is the read access to the nodes of an augmented interval BST,
+// N identifies a node, e.g. a pointer or an index.
+type Nodes[N, T any] interface {
+	IsNil(n N) bool // n is a missing child or the root of an empty tree
+	Left(n N) N     // left child of n
+	Right(n N) N    // right child of n
+	Item(n N) T     // item of n
+	MinUpper(n N) T // item in subtree of n with min upper value
+	MaxUpper(n N) T // item in subtree of n with max upper value
+}
+
+// Decision is taken at a visited node during a traced query, see [Query.Trace].
+type Decision uint8
+
+const (
+	Prune     Decision = iota // the augmented upper value excludes the subtree
+	Left                      // the node and the right subtree sort behind the item, go left
+	Match                     // the node item is a result
+	Miss                      // the node item is no result, the search goes on
+	SkipRight                 // the right subtree sorts behind the item, skip it
+)
+
+// Query runs the interval queries on the nodes, all methods start the descent at the root n.
+type Query[N, T any] struct {
+	Nodes Nodes[N, T]
+
+	// Cmp is the compare function of the intervals, see the parent package.
+	Cmp func(a, b T) (ll, rr, lr, rl int)
+
+	// Order is the BST order of the items for Find, nil for the order of the intervals.
+	Order func(a, b T) int
+
+	// Trace, if not nil, is called with the decisions of CoverLCP and Intersections,
+	// with the depth of the visited node, the root has depth 0.
+	Trace func(n N, depth int, d Decision)
+}
+
+// compareIval is the BST order of the intervals, the sort key is the left point.
+// If the left point is equal, the supersets sort to the left.
+func (q *Query[N, T]) compareIval(a, b T) int {
+	ll, rr, _, _ := q.Cmp(a, b)
+	if ll == 0 {
+		return -rr
+	}
+	return ll
+}
+
+// covers, returns true if a covers b.
+func (q *Query[N, T]) covers(a, b T) bool {
+	ll, rr, _, _ := q.Cmp(a, b)
+	return ll <= 0 && rr >= 0
+}
+
+// intersects, returns false if the intervals precede each other.
+func (q *Query[N, T]) intersects(a, b T) bool {
+	ll, rr, lr, rl := q.Cmp(a, b)
+	return !((ll == -1 && rr == -1 && lr == -1 && rl == -1) || (ll == 1 && rr == 1 && lr == 1 && rl == 1))
+}
+
+// cmpRR, compares just the right point of the intervals.
+func (q *Query[N, T]) cmpRR(a, b T) int {
+	_, rr, _, _ := q.Cmp(a, b)
+	return rr
+}
+
+// cmpLR, compares just the left point from a with right point from b.
+func (q *Query[N, T]) cmpLR(a, b T) int {
+	_, _, lr, _ := q.Cmp(a, b)
+	return lr
+}
+
+// cmpRL, compares just the right point from a with left point from b.
+func (q *Query[N, T]) cmpRL(a, b T) int {
+	_, _, _, rl := q.Cmp(a, b)
+	return rl
+}
+
+// trace the decision d at node n, if tracing is enabled.
+func (q *Query[N, T]) trace(n N, depth int, d Decision) {
+	if q.Trace != nil {
+		q.Trace(n, depth, d)
+	}
+}
+
+// Find returns the item equal to item in the BST order, see [Query.Order].
+func (q *Query[N, T]) Find(n N, item T) (result T, ok bool) {
+	order := q.Order
+	if order == nil {
+		order = q.compareIval
+	}
+
+	for !q.Nodes.IsNil(n) {
+		nItem := q.Nodes.Item(n)
+		switch cmp := order(item, nItem); {
+		case cmp == 0:
+			return nItem, true
+		case cmp < 0:
+			n = q.Nodes.Left(n)
+		default:
+			n = q.Nodes.Right(n)
+		}
+	}
+	return
+}
+
+// CoverLCP returns the interval with the longest-common-prefix that covers the item.
+func (q *Query[N, T]) CoverLCP(n N, item T) (result T, ok bool) {
+	return q.lcp(n, item, 0)
+}
+
+// lcp rec-descent
+func (q *Query[N, T]) lcp(n N, item T, depth int) (result T, ok bool) {
+	var nItem T
+	for {
+		if q.Nodes.IsNil(n) {
+			return
+		}
+
+		// fast exit, node has too small max upper interval value (augmented value)
+		if q.cmpRR(item, q.Nodes.MaxUpper(n)) > 0 {
+			q.trace(n, depth, Prune)
+			return
+		}
+
+		nItem = q.Nodes.Item(n)
+		cmp := q.compareIval(nItem, item)
+		if cmp == 0 {
+			// equality is always the shortest containing hull
+			q.trace(n, depth, Match)
+			return nItem, true
+		}
+
+		if cmp < 0 {
+			break
+		}
+
+		// item too big, go left
+		q.trace(n, depth, Left)
+		n = q.Nodes.Left(n)
+		depth++
+	}
+
+	// LCP => right backtracking
+	if result, ok = q.lcp(q.Nodes.Right(n), item, depth+1); ok {
+		return result, ok
+	}
+
+	// not found in right subtree, try this node
+	if q.covers(nItem, item) {
+		q.trace(n, depth, Match)
+		return nItem, true
+	}
+	q.trace(n, depth, Miss)
+
+	// left rec-descent
+	return q.lcp(q.Nodes.Left(n), item, depth+1)
+}
+
+// CoverSCP returns the interval with the shortest-common-prefix that covers the item.
+func (q *Query[N, T]) CoverSCP(n N, item T) (result T, ok bool) {
+	for {
+		if q.Nodes.IsNil(n) {
+			return
+		}
+
+		// fast exit, node has too small max upper interval value (augmented value)
+		if q.cmpRR(item, q.Nodes.MaxUpper(n)) > 0 {
+			return
+		}
+
+		// node and the right subtree sort behind the item, go left
+		nItem := q.Nodes.Item(n)
+		if q.compareIval(nItem, item) > 0 {
+			n = q.Nodes.Left(n)
+			continue
+		}
+
+		// SCP => left backtracking
+		if result, ok = q.CoverSCP(q.Nodes.Left(n), item); ok {
+			return result, ok
+		}
+
+		// this item
+		if q.covers(nItem, item) {
+			return nItem, true
+		}
+
+		// right descent
+		n = q.Nodes.Right(n)
+	}
+}
+
+// Covers appends all intervals that cover the item to result, in sorted order.
+func (q *Query[N, T]) Covers(n N, item T, result []T) []T {
+	if q.Nodes.IsNil(n) {
+		return result
+	}
+
+	// nope, subtree has too small upper interval value
+	if q.cmpRR(item, q.Nodes.MaxUpper(n)) > 0 {
+		return result
+	}
+
+	// in-order traversal for supersets, recursive call to left tree
+	result = q.Covers(q.Nodes.Left(n), item, result)
+
+	// node and the right subtree sort behind the item
+	nItem := q.Nodes.Item(n)
+	if q.compareIval(nItem, item) > 0 {
+		return result
+	}
+
+	// this item covers item
+	if q.covers(nItem, item) {
+		result = append(result, nItem)
+	}
+
+	// recursive call to right tree
+	return q.Covers(q.Nodes.Right(n), item, result)
+}
+
+// CoveredBy appends all intervals that are covered by item to result, in sorted order.
+func (q *Query[N, T]) CoveredBy(n N, item T, result []T) []T {
+	if q.Nodes.IsNil(n) {
+		return result
+	}
+
+	// nope, subtree has too big upper interval value
+	if q.cmpRR(item, q.Nodes.MinUpper(n)) < 0 {
+		return result
+	}
+
+	// node and the left subtree sort before the item, only the right subtree is left
+	nItem := q.Nodes.Item(n)
+	if q.compareIval(nItem, item) < 0 {
+		return q.CoveredBy(q.Nodes.Right(n), item, result)
+	}
+
+	// in-order traversal for subsets, recursive call to left tree
+	result = q.CoveredBy(q.Nodes.Left(n), item, result)
+
+	// item covers this item
+	if q.covers(item, nItem) {
+		result = append(result, nItem)
+	}
+
+	// recursive call to right tree
+	return q.CoveredBy(q.Nodes.Right(n), item, result)
+}
+
+// Intersects returns true if any interval intersects item.
+func (q *Query[N, T]) Intersects(n N, item T) bool {
+	for !q.Nodes.IsNil(n) {
+		// this item, fast exit
+		nItem := q.Nodes.Item(n)
+		if q.intersects(nItem, item) {
+			return true
+		}
+
+		// don't traverse this subtree, subtree has too small upper value for intersection
+		if q.cmpLR(item, q.Nodes.MaxUpper(n)) > 0 {
+			return false
+		}
+
+		// recursive call to left tree
+		if q.Intersects(q.Nodes.Left(n), item) {
+			return true
+		}
+
+		// don't traverse right subtree, subtree has too small left value for intersection.
+		if q.cmpRL(item, nItem) < 0 {
+			return false
+		}
+
+		// right descent
+		n = q.Nodes.Right(n)
+	}
+	return false
+}
+
+// Intersections appends all intervals that intersect with item to result, in sorted order.
+func (q *Query[N, T]) Intersections(n N, item T, result []T) []T {
+	return q.intersections(n, item, 0, result)
+}
+
+// intersections rec-descent
+func (q *Query[N, T]) intersections(n N, item T, depth int, result []T) []T {
+	if q.Nodes.IsNil(n) {
+		return result
+	}
+
+	// don't traverse this subtree, subtree has too small upper value for intersection
+	if q.cmpLR(item, q.Nodes.MaxUpper(n)) > 0 {
+		q.trace(n, depth, Prune)
+		return result
+	}
+
+	// in-order traversal for intersections, recursive call to left tree
+	result = q.intersections(q.Nodes.Left(n), item, depth+1, result)
+
+	// this item
+	nItem := q.Nodes.Item(n)
+	if q.intersects(nItem, item) {
+		q.trace(n, depth, Match)
+		result = append(result, nItem)
+	} else {
+		q.trace(n, depth, Miss)
+	}
+
+	// don't traverse right subtree, subtree has too small left value for intersection.
+	if q.cmpRL(item, nItem) < 0 {
+		q.trace(n, depth, SkipRight)
+		return result
+	}
+
+	// recursive call to right tree
+	return q.intersections(q.Nodes.Right(n), item, depth+1, result)
+}
@@ -0,0 +1,313 @@
+// Package intervaltest provides helpers to property-test compare functions and code built
+// on interval trees, the same way the interval package tests itself.
+//
+// The [Oracle] answers all queries by brute force in O(n) with the compare function alone,
+// [Check] compares a tree against the oracle for random probes and [CheckCompare] verifies
+// that a compare function is consistent:
+//
+//	r := rand.New(rand.NewPCG(42, 42))
+//	items := intervaltest.Random(r, 1_000, 10_000, mkRange)
+//
+//	if err := intervaltest.CheckCompare(cmpRange, items...); err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	tree := interval.NewTree(cmpRange, items...)
+//	oracle := intervaltest.NewOracle(cmpRange, items...)
+//
+//	if err := intervaltest.Check(tree, oracle, intervaltest.Random(r, 100, 10_000, mkRange)...); err != nil {
+//		t.Fatal(err)
+//	}
+package intervaltest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/gaissmai/interval"
+)
+
+// Random returns n random intervals, mk builds an interval from two random points
+// with 0 <= lo <= hi < limit.
+func Random[T any](r *rand.Rand, n, limit int, mk func(lo, hi int) T) []T {
+	items := make([]T, n)
+	for i := range items {
+		lo, hi := r.IntN(limit), r.IntN(limit)
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		items[i] = mk(lo, hi)
+	}
+	return items
+}
+
+// Pairs returns n random intervals of type [2]int, see [Random] and [interval.NewTreeOf].
+func Pairs(r *rand.Rand, n, limit int) [][2]int {
+	return Random(r, n, limit, func(lo, hi int) [2]int { return [2]int{lo, hi} })
+}
+
+// Oracle is the reference implementation of the interval tree queries by brute force.
+// The items are kept in a sorted slice, in the same order as in the tree, each query
+// scans all items in O(n).
+//
+// Like the tree, the oracle stores equal intervals only once. A tiebreak, see [interval.WithTiebreak],
+// isn't supported.
+type Oracle[T any] struct {
+	cmp   func(a, b T) (ll, rr, lr, rl int)
+	items []T
+}
+
+// NewOracle initializes the oracle with the compare function and items, see [interval.NewTree].
+func NewOracle[T any](cmp func(a, b T) (ll, rr, lr, rl int), items ...T) *Oracle[T] {
+	o := &Oracle[T]{cmp: cmp}
+	o.Insert(items...)
+	return o
+}
+
+// compare, the sort order of the tree, the left point first, supersets to the left.
+func (o *Oracle[T]) compare(a, b T) int {
+	ll, rr, _, _ := o.cmp(a, b)
+	if ll == 0 {
+		return -rr
+	}
+	return ll
+}
+
+// Insert items, an equal interval is replaced.
+func (o *Oracle[T]) Insert(items ...T) {
+	for _, item := range items {
+		i, found := slices.BinarySearchFunc(o.items, item, o.compare)
+		if found {
+			o.items[i] = item
+			continue
+		}
+		o.items = slices.Insert(o.items, i, item)
+	}
+}
+
+// Delete removes the item if it exists, returns true if the item was found.
+func (o *Oracle[T]) Delete(item T) bool {
+	i, found := slices.BinarySearchFunc(o.items, item, o.compare)
+	if found {
+		o.items = slices.Delete(o.items, i, i+1)
+	}
+	return found
+}
+
+// Len returns the number of items.
+func (o *Oracle[T]) Len() int {
+	return len(o.items)
+}
+
+// Items returns all items in sorted order.
+func (o *Oracle[T]) Items() []T {
+	return slices.Clone(o.items)
+}
+
+// Min returns the first item in sorted order, the zero value if the oracle is empty.
+func (o *Oracle[T]) Min() (min T) {
+	if len(o.items) > 0 {
+		min = o.items[0]
+	}
+	return
+}
+
+// Max returns the last item in sorted order, the zero value if the oracle is empty.
+func (o *Oracle[T]) Max() (max T) {
+	if len(o.items) > 0 {
+		max = o.items[len(o.items)-1]
+	}
+	return
+}
+
+// Find returns the equal interval and true, otherwise the zero value and false.
+func (o *Oracle[T]) Find(item T) (result T, ok bool) {
+	if i, found := slices.BinarySearchFunc(o.items, item, o.compare); found {
+		return o.items[i], true
+	}
+	return
+}
+
+// filter returns all items for which fn is true, in sorted order.
+func (o *Oracle[T]) filter(fn func(item T) bool) (result []T) {
+	for _, item := range o.items {
+		if fn(item) {
+			result = append(result, item)
+		}
+	}
+	return
+}
+
+// covers, a covers b.
+func (o *Oracle[T]) covers(a, b T) bool {
+	ll, rr, _, _ := o.cmp(a, b)
+	return ll <= 0 && rr >= 0
+}
+
+// precedes, a ends before b starts.
+func (o *Oracle[T]) precedes(a, b T) bool {
+	ll, rr, lr, rl := o.cmp(a, b)
+	return ll < 0 && rr < 0 && lr < 0 && rl < 0
+}
+
+// Covers returns all intervals that cover the item, see [interval.Tree.Covers].
+func (o *Oracle[T]) Covers(item T) []T {
+	return o.filter(func(x T) bool { return o.covers(x, item) })
+}
+
+// CoveredBy returns all intervals that are covered by the item, see [interval.Tree.CoveredBy].
+func (o *Oracle[T]) CoveredBy(item T) []T {
+	return o.filter(func(x T) bool { return o.covers(item, x) })
+}
+
+// Intersections returns all intervals that intersect with the item, see [interval.Tree.Intersections].
+func (o *Oracle[T]) Intersections(item T) []T {
+	return o.filter(func(x T) bool { return !o.precedes(x, item) && !o.precedes(item, x) })
+}
+
+// Intersects returns true if any interval intersects with the item, see [interval.Tree.Intersects].
+func (o *Oracle[T]) Intersects(item T) bool {
+	return len(o.Intersections(item)) > 0
+}
+
+// Precedes returns all intervals that precede the item, see [interval.Tree.Precedes].
+func (o *Oracle[T]) Precedes(item T) []T {
+	return o.filter(func(x T) bool { return o.precedes(x, item) })
+}
+
+// PrecededBy returns all intervals that are preceded by the item, see [interval.Tree.PrecededBy].
+func (o *Oracle[T]) PrecededBy(item T) []T {
+	return o.filter(func(x T) bool { return o.precedes(item, x) })
+}
+
+// CoverLCP returns the innermost interval that covers the item, the last of [Oracle.Covers],
+// see [interval.Tree.CoverLCP].
+func (o *Oracle[T]) CoverLCP(item T) (result T, ok bool) {
+	if covers := o.Covers(item); len(covers) > 0 {
+		return covers[len(covers)-1], true
+	}
+	return
+}
+
+// CoverSCP returns the outermost interval that covers the item, the first of [Oracle.Covers],
+// see [interval.Tree.CoverSCP].
+func (o *Oracle[T]) CoverSCP(item T) (result T, ok bool) {
+	if covers := o.Covers(item); len(covers) > 0 {
+		return covers[0], true
+	}
+	return
+}
+
+// Check compares the tree against the oracle, the invariants of the tree, the size, Min and Max,
+// and for each probe the results of all queries. The first mismatch is returned as error.
+//
+// The tree and the oracle must have the same items and the same compare function,
+// including the effects of the tree options, see [interval.Tree.CompareFunc].
+func Check[T any](tree *interval.Tree[T], oracle *Oracle[T], probes ...T) error {
+	if err := tree.CheckInvariants(); err != nil {
+		return err
+	}
+
+	if size, _, _, _ := tree.Statistics(); size != oracle.Len() {
+		return fmt.Errorf("size, tree: %d, oracle: %d", size, oracle.Len())
+	}
+
+	eq := func(a, b T) bool {
+		ll, rr, _, _ := oracle.cmp(a, b)
+		return ll == 0 && rr == 0
+	}
+
+	if got, want := tree.Min(), oracle.Min(); !eq(got, want) {
+		return fmt.Errorf("Min(), tree: %v, oracle: %v", got, want)
+	}
+
+	if got, want := tree.Max(), oracle.Max(); !eq(got, want) {
+		return fmt.Errorf("Max(), tree: %v, oracle: %v", got, want)
+	}
+
+	lists := []struct {
+		name   string
+		tree   func(T) []T
+		oracle func(T) []T
+	}{
+		{"Covers", tree.Covers, oracle.Covers},
+		{"CoveredBy", tree.CoveredBy, oracle.CoveredBy},
+		{"Intersections", tree.Intersections, oracle.Intersections},
+		{"Precedes", tree.Precedes, oracle.Precedes},
+		{"PrecededBy", tree.PrecededBy, oracle.PrecededBy},
+	}
+
+	singles := []struct {
+		name   string
+		tree   func(T) (T, bool)
+		oracle func(T) (T, bool)
+	}{
+		{"Find", tree.Find, oracle.Find},
+		{"CoverLCP", tree.CoverLCP, oracle.CoverLCP},
+		{"CoverSCP", tree.CoverSCP, oracle.CoverSCP},
+	}
+
+	for _, probe := range probes {
+		for _, q := range lists {
+			if got, want := q.tree(probe), q.oracle(probe); !slices.EqualFunc(got, want, eq) {
+				return fmt.Errorf("%s(%v), tree: %v, oracle: %v", q.name, probe, got, want)
+			}
+		}
+
+		for _, q := range singles {
+			got, gotOK := q.tree(probe)
+			want, wantOK := q.oracle(probe)
+			if gotOK != wantOK || gotOK && !eq(got, want) {
+				return fmt.Errorf("%s(%v), tree: %v, %v, oracle: %v, %v", q.name, probe, got, gotOK, want, wantOK)
+			}
+		}
+
+		if got, want := tree.Intersects(probe), oracle.Intersects(probe); got != want {
+			return fmt.Errorf("Intersects(%v), tree: %v, oracle: %v", probe, got, want)
+		}
+	}
+
+	return nil
+}
+
+// CheckCompare verifies the compare function with all pairs of items in O(n²).
+// Each item must be valid, the left point not greater than the right point,
+// and the four results must be antisymmetric and consistent with each other,
+// e.g. the left point of a compared with the right point of b is never
+// greater than the left points compared.
+//
+// An inconsistent compare function silently breaks the augmentation invariants of the tree.
+func CheckCompare[T any](cmp func(a, b T) (ll, rr, lr, rl int), items ...T) error {
+	for _, a := range items {
+		ll, rr, lr, rl := cmp(a, a)
+		if ll != 0 || rr != 0 || lr > 0 || lr != -rl {
+			return fmt.Errorf("cmp(%v, %v) = (%d, %d, %d, %d), invalid interval or not reflexive", a, a, ll, rr, lr, rl)
+		}
+	}
+
+	for _, a := range items {
+		for _, b := range items {
+			ll, rr, lr, rl := cmp(a, b)
+			for _, v := range [...]int{ll, rr, lr, rl} {
+				if v < -1 || v > 1 {
+					return fmt.Errorf("cmp(%v, %v) = (%d, %d, %d, %d), want -1, 0 or +1", a, b, ll, rr, lr, rl)
+				}
+			}
+
+			// with a.lo <= a.hi and b.lo <= b.hi:
+			// a.lo-b.hi <= a.lo-b.lo <= a.hi-b.lo and a.lo-b.hi <= a.hi-b.hi <= a.hi-b.lo
+			if lr > ll || ll > rl || lr > rr || rr > rl {
+				return fmt.Errorf("cmp(%v, %v) = (%d, %d, %d, %d), inconsistent", a, b, ll, rr, lr, rl)
+			}
+
+			bll, brr, blr, brl := cmp(b, a)
+			if ll != -bll || rr != -brr || lr != -brl || rl != -blr {
+				return fmt.Errorf("cmp(%v, %v) = (%d, %d, %d, %d) and cmp(%v, %v) = (%d, %d, %d, %d), not antisymmetric",
+					a, b, ll, rr, lr, rl, b, a, bll, brr, blr, brl)
+			}
+		}
+	}
+
+	return nil
+}
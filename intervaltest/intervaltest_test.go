@@ -0,0 +1,119 @@
+package intervaltest_test
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+	"github.com/gaissmai/interval/intervaltest"
+)
+
+func cmpPair(a, b [2]int) (ll, rr, lr, rl int) {
+	return cmp.Compare(a[0], b[0]),
+		cmp.Compare(a[1], b[1]),
+		cmp.Compare(a[0], b[1]),
+		cmp.Compare(a[1], b[0])
+}
+
+func TestRandom(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(42, 42))
+
+	for _, p := range intervaltest.Pairs(r, 1_000, 100) {
+		if p[0] > p[1] || p[0] < 0 || p[1] >= 100 {
+			t.Fatalf("Pairs(), invalid interval %v", p)
+		}
+	}
+}
+
+func TestOracle(t *testing.T) {
+	t.Parallel()
+
+	o := intervaltest.NewOracle(cmpPair, [2]int{1, 8}, [2]int{0, 6}, [2]int{1, 5}, [2]int{7, 9}, [2]int{0, 6})
+
+	if want := [][2]int{{0, 6}, {1, 8}, {1, 5}, {7, 9}}; !slices.Equal(o.Items(), want) {
+		t.Errorf("Items(), got %v, want %v", o.Items(), want)
+	}
+
+	if got, want := o.Covers([2]int{2, 4}), [][2]int{{0, 6}, {1, 8}, {1, 5}}; !slices.Equal(got, want) {
+		t.Errorf("Covers(), got %v, want %v", got, want)
+	}
+
+	if got, _ := o.CoverLCP([2]int{2, 4}); got != [2]int{1, 5} {
+		t.Errorf("CoverLCP(), got %v, want [1 5]", got)
+	}
+
+	if got, want := o.Precedes([2]int{7, 7}), [][2]int{{0, 6}, {1, 5}}; !slices.Equal(got, want) {
+		t.Errorf("Precedes(), got %v, want %v", got, want)
+	}
+
+	if !o.Delete([2]int{1, 5}) || o.Delete([2]int{1, 5}) || o.Len() != 3 {
+		t.Errorf("Delete(), unexpected result")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(42, 42))
+
+	items := intervaltest.Pairs(r, 2_000, 10_000)
+	tree := interval.NewTree(cmpPair, items...)
+	oracle := intervaltest.NewOracle(cmpPair, items...)
+
+	probes := append(intervaltest.Pairs(r, 200, 10_000), items[:50]...)
+
+	if err := intervaltest.Check(tree, oracle, probes...); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range items[:500] {
+		tree.Delete(item)
+		oracle.Delete(item)
+	}
+
+	if err := intervaltest.Check(tree, oracle, probes...); err != nil {
+		t.Fatal(err)
+	}
+
+	// mismatch is detected
+	oracle.Insert([2]int{-1, 20_000})
+
+	if err := intervaltest.Check(tree, oracle, probes...); err == nil {
+		t.Error("Check(), expected mismatch error, got nil")
+	}
+
+	// the empty tree
+	if err := intervaltest.Check(interval.NewTree(cmpPair), intervaltest.NewOracle(cmpPair), probes...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckCompare(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewPCG(42, 42))
+	items := intervaltest.Pairs(r, 200, 100)
+
+	if err := intervaltest.CheckCompare(cmpPair, items...); err != nil {
+		t.Fatal(err)
+	}
+
+	// lr and rl swapped
+	broken := func(a, b [2]int) (ll, rr, lr, rl int) {
+		ll, rr, lr, rl = cmpPair(a, b)
+		return ll, rr, rl, lr
+	}
+
+	if err := intervaltest.CheckCompare(broken, items...); err == nil {
+		t.Error("CheckCompare(broken), expected error, got nil")
+	}
+
+	// invalid interval
+	if err := intervaltest.CheckCompare(cmpPair, [2]int{5, 1}); err == nil {
+		t.Error("CheckCompare([5 1]), expected error, got nil")
+	}
+}
@@ -0,0 +1,108 @@
+package interval
+
+import "fmt"
+
+// CheckInvariants verifies the integrity of the tree and returns an error describing the first violation:
+//
+//   - heap order: the priority of a node is not less than the priorities of its children
+//   - BST order: the items are in strictly ascending order under the compare function
+//   - augmentation: the min and max upper values and the size of each subtree are correct
+//   - digests: the digest of each subtree is correct, if configured [WithHash]
+//   - the cached Min and Max nodes are the leftmost and rightmost nodes
+//
+// Useful to assert the integrity of trees in your own tests, e.g. after custom union pipelines
+// or to detect broken compare functions. The check is O(n).
+func (t Tree[T]) CheckInvariants() error {
+	if t.root == nil {
+		if t.min != nil || t.max != nil {
+			return fmt.Errorf("interval: cached min/max is corrupt")
+		}
+		return nil
+	}
+
+	if _, _, _, err := t.checkNode(t.root); err != nil {
+		return err
+	}
+
+	// BST order
+	var err error
+	var first, prev *node[T]
+	t.traverse(t.root, inorder, 0, func(n *node[T], _ int) bool {
+		if prev != nil && t.compare(prev.item, n.item) >= 0 {
+			err = fmt.Errorf("interval: BST order violated, %v is not less than %v", prev.item, n.item)
+			return false
+		}
+		if first == nil {
+			first = n
+		}
+		prev = n
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	// cached min and max
+	if t.min != first || t.max != prev {
+		return fmt.Errorf("interval: cached min/max is corrupt")
+	}
+
+	return nil
+}
+
+// checkNode rec-descent, verifies the heap order and the augmentation, returns the min and max upper items
+// and the size of the subtree.
+func (t *Tree[T]) checkNode(n *node[T]) (minUpper, maxUpper T, size int, err error) {
+	minUpper, maxUpper, size = n.item, n.item, 1
+
+	for _, c := range [2]*node[T]{n.left, n.right} {
+		if c == nil {
+			continue
+		}
+
+		if c.prio > n.prio {
+			return minUpper, maxUpper, size, fmt.Errorf("interval: heap order violated, child %v has higher priority than parent %v", c.item, n.item)
+		}
+
+		cMin, cMax, cSize, err := t.checkNode(c)
+		if err != nil {
+			return minUpper, maxUpper, size, err
+		}
+		size += cSize
+
+		if t.cmpRR(cMin, minUpper) < 0 {
+			minUpper = cMin
+		}
+		if t.cmpRR(cMax, maxUpper) > 0 {
+			maxUpper = cMax
+		}
+	}
+
+	if augmentMinUpper && t.cmpRR(n.minUpperItem(), minUpper) != 0 {
+		return minUpper, maxUpper, size, fmt.Errorf("interval: augmentation violated, min upper of %v is %v, want %v", n.item, n.minUpperItem(), minUpper)
+	}
+
+	if t.cmpRR(n.maxUpperItem(), maxUpper) != 0 {
+		return minUpper, maxUpper, size, fmt.Errorf("interval: augmentation violated, max upper of %v is %v, want %v", n.item, n.maxUpperItem(), maxUpper)
+	}
+
+	if n.size != size {
+		return minUpper, maxUpper, size, fmt.Errorf("interval: augmentation violated, size of %v is %d, want %d", n.item, n.size, size)
+	}
+
+	// the digests of the children are already verified
+	if t.hashFn != nil {
+		digest := mix64(t.hashFn(n.item))
+		if n.left != nil {
+			digest += n.left.subtreeHash()
+		}
+		if n.right != nil {
+			digest += n.right.subtreeHash()
+		}
+		if n.subtreeHash() != digest {
+			return minUpper, maxUpper, size, fmt.Errorf("interval: digest violated, digest of %v is %#x, want %#x", n.item, n.subtreeHash(), digest)
+		}
+	}
+
+	return minUpper, maxUpper, size, nil
+}
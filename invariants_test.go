@@ -0,0 +1,51 @@
+package interval_test
+
+import (
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestCheckInvariants(t *testing.T) {
+	t.Parallel()
+
+	var zeroTree interval.Tree[uintInterval]
+	if err := zeroTree.CheckInvariants(); err != nil {
+		t.Fatalf("zero tree, CheckInvariants(), got: %v, want: nil", err)
+	}
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(1_000)...)
+	if err := tree.CheckInvariants(); err != nil {
+		t.Fatalf("NewTree, CheckInvariants(), got: %v", err)
+	}
+
+	tree = tree.UnionImmutable(interval.NewTree(cmpUintInterval, gen2UintIvals(1_000)...), true)
+	if err := tree.CheckInvariants(); err != nil {
+		t.Fatalf("UnionImmutable, CheckInvariants(), got: %v", err)
+	}
+
+	for _, item := range genUintIvals(100) {
+		tree.Delete(item)
+		tree.InsertWithPriority(item, 42)
+	}
+	if err := tree.CheckInvariants(); err != nil {
+		t.Fatalf("Delete/InsertWithPriority, CheckInvariants(), got: %v", err)
+	}
+
+	// reverse the order after the tree is built
+	reversed := false
+	cmp := func(a, b uintInterval) (ll, rr, lr, rl int) {
+		ll, rr, lr, rl = cmpUintInterval(a, b)
+		if reversed {
+			return -ll, -rr, lr, rl
+		}
+		return
+	}
+
+	tree = interval.NewTree(cmp, ps...)
+	reversed = true
+
+	if err := tree.CheckInvariants(); err == nil {
+		t.Fatal("reversed compare function, CheckInvariants(), got: nil, want: error")
+	}
+}
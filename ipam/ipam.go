@@ -0,0 +1,119 @@
+// Package ipam is a free-prefix allocator for IP address management, built on an interval tree
+// of the allocated prefixes.
+//
+//	a := ipam.New()
+//	pfx, err := a.Allocate(netip.MustParsePrefix("10.0.0.0/16"), 24) // 10.0.0.0/24
+//	pfx, err = a.Allocate(netip.MustParsePrefix("10.0.0.0/16"), 24)  // 10.0.1.0/24
+//	err = a.Release(pfx)
+package ipam
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/gaissmai/interval"
+	"github.com/gaissmai/interval/cidr"
+)
+
+var (
+	// ErrExhausted is returned by [Allocator.Allocate] if there is no free prefix of the requested size.
+	ErrExhausted = errors.New("ipam: no free prefix")
+
+	// ErrOverlap is returned by [Allocator.AllocatePrefix] if the prefix overlaps an allocation.
+	ErrOverlap = errors.New("ipam: prefix overlaps an allocation")
+
+	// ErrNotAllocated is returned by [Allocator.Release] if the prefix is not allocated.
+	ErrNotAllocated = errors.New("ipam: prefix not allocated")
+)
+
+// Allocator keeps track of the allocated prefixes, the allocations never overlap.
+// An Allocator is not safe for concurrent use.
+type Allocator struct {
+	tree *interval.Tree[netip.Prefix]
+}
+
+// New returns an allocator without allocations.
+func New() *Allocator {
+	return &Allocator{tree: interval.New(cidr.Compare)}
+}
+
+// FindFreePrefix returns the first free prefix with bits length within the prefix within,
+// the free prefix with the lowest address (first-fit). It doesn't allocate the prefix.
+// The result is false if bits is out of range or no such prefix is free.
+func (a *Allocator) FindFreePrefix(within netip.Prefix, bits int) (netip.Prefix, bool) {
+	if !within.IsValid() || bits < within.Bits() || bits > within.Addr().BitLen() {
+		return netip.Prefix{}, false
+	}
+	within = within.Masked()
+
+	addr := within.Addr()
+	for {
+		cand := netip.PrefixFrom(addr, bits)
+		if !within.Contains(addr) {
+			return netip.Prefix{}, false
+		}
+
+		// the allocations don't overlap, the last one in sort order ends last
+		hits := a.tree.Intersections(cand)
+		if len(hits) == 0 {
+			return cand, true
+		}
+
+		// continue with the next aligned candidate behind the last hit
+		_, last := cidr.Range(hits[len(hits)-1])
+		if addr = last.Next(); !addr.IsValid() {
+			return netip.Prefix{}, false
+		}
+
+		if aligned := netip.PrefixFrom(addr, bits).Masked(); aligned.Addr() != addr {
+			_, last = cidr.Range(aligned)
+			if addr = last.Next(); !addr.IsValid() {
+				return netip.Prefix{}, false
+			}
+		}
+	}
+}
+
+// Allocate finds and allocates the first free prefix with bits length within the prefix within,
+// see [Allocator.FindFreePrefix]. Returns an error wrapping [ErrExhausted] if there is none.
+func (a *Allocator) Allocate(within netip.Prefix, bits int) (netip.Prefix, error) {
+	pfx, ok := a.FindFreePrefix(within, bits)
+	if !ok {
+		return netip.Prefix{}, fmt.Errorf("%w: /%d in %s", ErrExhausted, bits, within)
+	}
+
+	a.tree = a.tree.InsertImmutable(pfx)
+	return pfx, nil
+}
+
+// AllocatePrefix allocates the given prefix, the host bits are masked.
+// Returns an error wrapping [ErrOverlap] if the prefix overlaps an allocation.
+func (a *Allocator) AllocatePrefix(pfx netip.Prefix) error {
+	pfx = pfx.Masked()
+
+	if hits := a.tree.Intersections(pfx); len(hits) > 0 {
+		return fmt.Errorf("%w: %s overlaps %s", ErrOverlap, pfx, hits[0])
+	}
+
+	a.tree = a.tree.InsertImmutable(pfx)
+	return nil
+}
+
+// Release frees the allocated prefix, the host bits are masked.
+// Returns an error wrapping [ErrNotAllocated] if the prefix is not allocated.
+func (a *Allocator) Release(pfx netip.Prefix) error {
+	pfx = pfx.Masked()
+
+	tree, ok := a.tree.DeleteImmutable(pfx)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotAllocated, pfx)
+	}
+	a.tree = tree
+	return nil
+}
+
+// Allocated returns all allocations within the prefix within, in ascending order.
+func (a *Allocator) Allocated(within netip.Prefix) []netip.Prefix {
+	return a.tree.CoveredBy(within.Masked())
+}
@@ -0,0 +1,88 @@
+package ipam_test
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/gaissmai/interval/ipam"
+)
+
+var mpp = netip.MustParsePrefix
+
+func TestAllocate(t *testing.T) {
+	t.Parallel()
+
+	a := ipam.New()
+	within := mpp("10.0.0.0/22")
+
+	for _, want := range []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"} {
+		pfx, err := a.Allocate(within, 24)
+		if err != nil {
+			t.Fatalf("Allocate, unexpected error: %v", err)
+		}
+		if pfx.String() != want {
+			t.Fatalf("Allocate, got %s, want %s", pfx, want)
+		}
+	}
+
+	if _, err := a.Allocate(within, 24); !errors.Is(err, ipam.ErrExhausted) {
+		t.Fatalf("Allocate in full pool, got err %v, want %v", err, ipam.ErrExhausted)
+	}
+
+	if err := a.Release(mpp("10.0.1.0/24")); err != nil {
+		t.Fatalf("Release, unexpected error: %v", err)
+	}
+	if err := a.Release(mpp("10.0.1.0/24")); !errors.Is(err, ipam.ErrNotAllocated) {
+		t.Fatalf("Release twice, got err %v, want %v", err, ipam.ErrNotAllocated)
+	}
+
+	// the released block is split
+	for _, want := range []string{"10.0.1.0/26", "10.0.1.64/26"} {
+		pfx, err := a.Allocate(within, 26)
+		if err != nil || pfx.String() != want {
+			t.Fatalf("Allocate /26, got %s %v, want %s", pfx, err, want)
+		}
+	}
+
+	if got := len(a.Allocated(within)); got != 5 {
+		t.Fatalf("Allocated, got %d prefixes, want 5", got)
+	}
+}
+
+func TestFindFreePrefixAlignment(t *testing.T) {
+	t.Parallel()
+
+	a := ipam.New()
+	if err := a.AllocatePrefix(mpp("10.0.0.0/30")); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.AllocatePrefix(mpp("10.0.0.0/31")); !errors.Is(err, ipam.ErrOverlap) {
+		t.Fatalf("AllocatePrefix overlapping, got err %v, want %v", err, ipam.ErrOverlap)
+	}
+
+	// the next free /28 is aligned behind the /30
+	pfx, ok := a.FindFreePrefix(mpp("10.0.0.0/24"), 28)
+	if !ok || pfx.String() != "10.0.0.16/28" {
+		t.Fatalf("FindFreePrefix, got %s %v, want 10.0.0.16/28 true", pfx, ok)
+	}
+
+	if _, ok := a.FindFreePrefix(mpp("10.0.0.0/24"), 16); ok {
+		t.Fatal("FindFreePrefix with bits < within.Bits(), got true, want false")
+	}
+
+	// end of the address space
+	b := ipam.New()
+	if err := b.AllocatePrefix(mpp("255.255.255.0/25")); err != nil {
+		t.Fatal(err)
+	}
+	if pfx, ok := b.FindFreePrefix(mpp("255.255.255.0/24"), 25); !ok || pfx.String() != "255.255.255.128/25" {
+		t.Fatalf("FindFreePrefix at end of address space, got %s %v", pfx, ok)
+	}
+	if err := b.AllocatePrefix(mpp("255.255.255.128/25")); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := b.FindFreePrefix(mpp("255.255.255.0/24"), 25); ok {
+		t.Fatal("FindFreePrefix in full pool at end of address space, got true, want false")
+	}
+}
@@ -0,0 +1,117 @@
+// Package iprange is an adapter for arbitrary IP address ranges, not just CIDR prefixes.
+//
+// A [Range] is parsed from "10.0.0.5-10.0.1.77", a single address or a prefix and is
+// indexed in an interval tree with the compare function [Compare]:
+//
+//	r, err := iprange.Parse("10.0.0.5-10.0.1.77")
+//	tree := iprange.NewTree(r, iprange.MustParse("10.0.0.0/8"))
+//	hits := tree.Covers(iprange.MustParse("10.0.0.17"))
+package iprange
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/gaissmai/interval"
+	"github.com/gaissmai/interval/cidr"
+)
+
+// ErrInvalidRange is returned by [Parse] and [New] for malformed ranges.
+var ErrInvalidRange = errors.New("iprange: invalid range")
+
+// Range is the closed IP address range [From, To] of one address family.
+type Range struct {
+	From netip.Addr
+	To   netip.Addr
+}
+
+// New returns the range [from, to], from and to must be valid addresses of the same family
+// and from must not be greater than to.
+func New(from, to netip.Addr) (Range, error) {
+	if !from.IsValid() || !to.IsValid() || from.Is4() != to.Is4() || from.Compare(to) > 0 {
+		return Range{}, fmt.Errorf("%w: %s-%s", ErrInvalidRange, from, to)
+	}
+	return Range{from.Unmap(), to.Unmap()}, nil
+}
+
+// FromPrefix returns the range of the prefix.
+func FromPrefix(pfx netip.Prefix) Range {
+	from, to := cidr.Range(pfx)
+	return Range{from, to}
+}
+
+// Parse parses "from-to", a single address or a prefix into a range.
+// Whitespace around the addresses is ignored.
+func Parse(s string) (Range, error) {
+	s = strings.TrimSpace(s)
+
+	if from, to, ok := strings.Cut(s, "-"); ok {
+		a, err := netip.ParseAddr(strings.TrimSpace(from))
+		if err != nil {
+			return Range{}, fmt.Errorf("%w: %w", ErrInvalidRange, err)
+		}
+		b, err := netip.ParseAddr(strings.TrimSpace(to))
+		if err != nil {
+			return Range{}, fmt.Errorf("%w: %w", ErrInvalidRange, err)
+		}
+		return New(a, b)
+	}
+
+	if strings.Contains(s, "/") {
+		pfx, err := netip.ParsePrefix(s)
+		if err != nil {
+			return Range{}, fmt.Errorf("%w: %w", ErrInvalidRange, err)
+		}
+		return FromPrefix(pfx), nil
+	}
+
+	a, err := netip.ParseAddr(s)
+	if err != nil {
+		return Range{}, fmt.Errorf("%w: %w", ErrInvalidRange, err)
+	}
+	return New(a, a)
+}
+
+// MustParse is like [Parse] but panics on errors, for tests and static tables.
+func MustParse(s string) Range {
+	r, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// String returns "from-to", or just the address for a single address range.
+func (r Range) String() string {
+	if r.From == r.To {
+		return r.From.String()
+	}
+	return r.From.String() + "-" + r.To.String()
+}
+
+// Prefix returns the range as prefix and true, if the range is exactly a CIDR.
+func (r Range) Prefix() (netip.Prefix, bool) {
+	for bits := 0; bits <= r.From.BitLen(); bits++ {
+		pfx := netip.PrefixFrom(r.From, bits)
+		if pfx.Masked().Addr() != r.From {
+			continue
+		}
+		if _, last := cidr.Range(pfx); last == r.To {
+			return pfx, true
+		}
+	}
+	return netip.Prefix{}, false
+}
+
+// Compare is the compare function for ranges in an interval tree, see [interval.NewTree].
+// IPv4 ranges sort before IPv6 ranges.
+func Compare(a, b Range) (ll, rr, lr, rl int) {
+	return a.From.Compare(b.From), a.To.Compare(b.To), a.From.Compare(b.To), a.To.Compare(b.From)
+}
+
+// NewTree returns an interval tree with the ranges.
+func NewTree(ranges ...Range) *interval.Tree[Range] {
+	return interval.NewTree(Compare, ranges...)
+}
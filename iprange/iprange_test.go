@@ -0,0 +1,77 @@
+package iprange_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gaissmai/interval/iprange"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"10.0.0.5-10.0.1.77", "10.0.0.5-10.0.1.77"},
+		{" 10.0.0.5 - 10.0.1.77 ", "10.0.0.5-10.0.1.77"},
+		{"10.0.0.17", "10.0.0.17"},
+		{"10.0.1.0/23", "10.0.0.0-10.0.1.255"},
+		{"2001:db8::-2001:db8::ff", "2001:db8::-2001:db8::ff"},
+	}
+
+	for _, tt := range tests {
+		r, err := iprange.Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q), unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got := r.String(); got != tt.want {
+			t.Errorf("Parse(%q), got %s, want %s", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"10.0.1.77-10.0.0.5", "10.0.0.1-::1", "10.0.0.1-", "foo", "10.0.0.0/33"} {
+		if _, err := iprange.Parse(in); !errors.Is(err, iprange.ErrInvalidRange) {
+			t.Errorf("Parse(%q), got err %v, want %v", in, err, iprange.ErrInvalidRange)
+		}
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	t.Parallel()
+
+	if pfx, ok := iprange.MustParse("10.0.0.0-10.0.1.255").Prefix(); !ok || pfx.String() != "10.0.0.0/23" {
+		t.Errorf("Prefix(), got %s %v, want 10.0.0.0/23 true", pfx, ok)
+	}
+
+	if _, ok := iprange.MustParse("10.0.0.5-10.0.1.77").Prefix(); ok {
+		t.Errorf("Prefix() of non-CIDR range, got true, want false")
+	}
+}
+
+func TestTree(t *testing.T) {
+	t.Parallel()
+
+	tree := iprange.NewTree(
+		iprange.MustParse("10.0.0.0/8"),
+		iprange.MustParse("10.0.0.5-10.0.1.77"),
+		iprange.MustParse("10.0.2.0-10.0.2.9"),
+		iprange.MustParse("::/0"),
+	)
+
+	got := tree.Covers(iprange.MustParse("10.0.0.17"))
+	if len(got) != 2 || got[0].String() != "10.0.0.0-10.255.255.255" || got[1].String() != "10.0.0.5-10.0.1.77" {
+		t.Errorf("Covers(10.0.0.17), got %v", got)
+	}
+
+	lcp, ok := tree.CoverLCP(iprange.MustParse("10.0.2.3"))
+	if !ok || lcp.String() != "10.0.2.0-10.0.2.9" {
+		t.Errorf("CoverLCP(10.0.2.3), got %s %v", lcp, ok)
+	}
+
+	if tree.Intersects(iprange.MustParse("11.0.0.0-11.0.0.255")) {
+		t.Errorf("Intersects(11.0.0.0-11.0.0.255), got true, want false")
+	}
+}
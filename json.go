@@ -0,0 +1,103 @@
+package interval
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// WithJSONCodec, the items are encoded by [Tree.MarshalJSON] with encode and decoded by
+// [Tree.UnmarshalJSON] with decode. The encoded items must be valid JSON values.
+//
+// Without a JSON codec the items are encoded with [encoding/json].
+// The type parameter must match the item type of the tree, otherwise the (un)marshal methods return an error.
+func WithJSONCodec[T any](encode func(T) ([]byte, error), decode func([]byte) (T, error)) Option {
+	return func(o *options) {
+		o.jsonCodec = codec[T]{encode, decode}
+	}
+}
+
+// jsonItemCodec, the configured JSON codec, ok is false for the default encoding/json.
+func (t *Tree[T]) jsonItemCodec() (c codec[T], ok bool, err error) {
+	if t.opts == nil || t.opts.jsonCodec == nil {
+		return c, false, nil
+	}
+
+	if c, ok = t.opts.jsonCodec.(codec[T]); !ok {
+		return c, false, fmt.Errorf("interval: JSON codec item type %T does not match the tree", t.opts.jsonCodec)
+	}
+
+	return c, true, nil
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+//
+// The tree is encoded as an array of the items in sorted order, the same items result in the same document,
+// well suited for storing and diffing. The shape of the tree is not encoded.
+func (t Tree[T]) MarshalJSON() ([]byte, error) {
+	c, hasCodec, err := t.jsonItemCodec()
+	if err != nil {
+		return nil, err
+	}
+
+	items := t.items()
+	if !hasCodec {
+		if items == nil {
+			items = []T{}
+		}
+		return json.Marshal(items)
+	}
+
+	raw := make([]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		data, err := c.encode(item)
+		if err != nil {
+			return nil, fmt.Errorf("interval: encoding item %v: %w", item, err)
+		}
+		raw = append(raw, data)
+	}
+
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+//
+// The tree must be initialized with the compare function and the same JSON codec as the
+// marshaled tree, e.g. with [New], the zero value can't be used. All items in t are replaced,
+// the shape of the tree is rebuilt.
+func (t *Tree[T]) UnmarshalJSON(data []byte) error {
+	if t.cmp == nil {
+		return fmt.Errorf("%w: UnmarshalJSON", ErrNoCompareFunc)
+	}
+
+	c, hasCodec, err := t.jsonItemCodec()
+	if err != nil {
+		return err
+	}
+
+	var items []T
+
+	if !hasCodec {
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+	} else {
+		var raw []json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+
+		items = make([]T, 0, len(raw))
+		for _, r := range raw {
+			item, err := c.decode(r)
+			if err != nil {
+				return fmt.Errorf("interval: decoding item %s: %w", r, err)
+			}
+			items = append(items, item)
+		}
+	}
+
+	t.root = nil
+	t.Insert(items...)
+
+	return nil
+}
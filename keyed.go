@@ -0,0 +1,132 @@
+package interval
+
+import (
+	"cmp"
+	"slices"
+)
+
+// KeyedItem is an item in the namespace Key, e.g. a chromosome and a (start, end) interval.
+type KeyedItem[K cmp.Ordered, T any] struct {
+	Key  K
+	Item T
+}
+
+// Keyed maps keys, e.g. chromosomes or other namespaces, to separate trees.
+// The items of different keys never meet, the queries address a key explicitly.
+// See [Forest] for shard keys derived from the items with queries over all shards.
+//
+// A Keyed is not safe for concurrent use, like [Tree].
+type Keyed[K cmp.Ordered, T any] struct {
+	cmp   func(a, b T) (ll, rr, lr, rl int)
+	opts  []Option
+	trees map[K]*Tree[T]
+}
+
+// NewKeyed returns an empty keyed forest, the trees are created with the compare function
+// and the options, see [New].
+func NewKeyed[K cmp.Ordered, T any](cmp func(a, b T) (ll, rr, lr, rl int), opts ...Option) *Keyed[K, T] {
+	return &Keyed[K, T]{cmp: cmp, opts: opts, trees: make(map[K]*Tree[T])}
+}
+
+// Insert inserts the items in place into the tree of key, the tree is created as needed.
+func (k *Keyed[K, T]) Insert(key K, items ...T) {
+	t := k.trees[key]
+	if t == nil {
+		t = New(k.cmp, k.opts...)
+		k.trees[key] = t
+	}
+	t.Insert(items...)
+}
+
+// InsertKeyed inserts the keyed items, grouped by key, one bulk insert per key.
+func (k *Keyed[K, T]) InsertKeyed(items ...KeyedItem[K, T]) {
+	groups := make(map[K][]T)
+	for _, ki := range items {
+		groups[ki.Key] = append(groups[ki.Key], ki.Item)
+	}
+
+	for key, group := range groups {
+		k.Insert(key, group...)
+	}
+}
+
+// Delete removes the item from the tree of key, returns true if it exists, false otherwise.
+// Empty trees are dropped.
+func (k *Keyed[K, T]) Delete(key K, item T) bool {
+	t := k.trees[key]
+	if t == nil {
+		return false
+	}
+
+	ok := t.Delete(item)
+	if t.root == nil {
+		delete(k.trees, key)
+	}
+	return ok
+}
+
+// Tree returns the tree of key or nil. Changes of the returned tree are changes of k.
+func (k *Keyed[K, T]) Tree(key K) *Tree[T] {
+	return k.trees[key]
+}
+
+// Keys returns the keys in ascending order.
+func (k *Keyed[K, T]) Keys() []K {
+	keys := make([]K, 0, len(k.trees))
+	for key := range k.trees {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Intersections returns all items of key that intersect with item, in sorted order.
+func (k *Keyed[K, T]) Intersections(key K, item T) []T {
+	if t := k.trees[key]; t != nil {
+		return t.Intersections(item)
+	}
+	return nil
+}
+
+// Covers returns all items of key that cover the item, in sorted order.
+func (k *Keyed[K, T]) Covers(key K, item T) []T {
+	if t := k.trees[key]; t != nil {
+		return t.Covers(item)
+	}
+	return nil
+}
+
+// CoveredBy returns all items of key that are covered by the item, in sorted order.
+func (k *Keyed[K, T]) CoveredBy(key K, item T) []T {
+	if t := k.trees[key]; t != nil {
+		return t.CoveredBy(item)
+	}
+	return nil
+}
+
+// Bulk runs the query for each probe against the tree of its key, the results are in the order of the probes.
+// The query is any method expression with the signature of the list queries, e.g.:
+//
+//	hits := k.Bulk(probes, (*interval.Tree[T]).Intersections)
+func (k *Keyed[K, T]) Bulk(probes []KeyedItem[K, T], query func(t *Tree[T], item T) []T) [][]T {
+	results := make([][]T, len(probes))
+	for i, p := range probes {
+		if t := k.trees[p.Key]; t != nil {
+			results[i] = query(t, p.Item)
+		}
+	}
+	return results
+}
+
+// All runs the query with the item against the trees of all keys, keys without results are omitted.
+//
+//	hits := k.All(item, (*interval.Tree[T]).Covers)
+func (k *Keyed[K, T]) All(item T, query func(t *Tree[T], item T) []T) map[K][]T {
+	results := make(map[K][]T)
+	for key, t := range k.trees {
+		if r := query(t, item); len(r) > 0 {
+			results[key] = r
+		}
+	}
+	return results
+}
@@ -0,0 +1,53 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestKeyed(t *testing.T) {
+	t.Parallel()
+
+	k := interval.NewKeyed[string](cmpUintInterval)
+	k.InsertKeyed(
+		interval.KeyedItem[string, uintInterval]{Key: "chr1", Item: uintInterval{100, 200}},
+		interval.KeyedItem[string, uintInterval]{Key: "chr1", Item: uintInterval{150, 300}},
+		interval.KeyedItem[string, uintInterval]{Key: "chr2", Item: uintInterval{100, 200}},
+	)
+	k.Insert("chrX", uintInterval{0, 1000})
+
+	if got := k.Keys(); !slices.Equal(got, []string{"chr1", "chr2", "chrX"}) {
+		t.Fatalf("Keys(), got %v", got)
+	}
+
+	if got := k.Intersections("chr1", uintInterval{180, 190}); len(got) != 2 {
+		t.Fatalf("Intersections(chr1), got %v, want 2 items", got)
+	}
+	if got := k.Intersections("chr3", uintInterval{180, 190}); got != nil {
+		t.Fatalf("Intersections(chr3), got %v, want nil", got)
+	}
+
+	probes := []interval.KeyedItem[string, uintInterval]{
+		{Key: "chr2", Item: uintInterval{250, 260}},
+		{Key: "chr1", Item: uintInterval{250, 260}},
+		{Key: "chrY", Item: uintInterval{250, 260}},
+	}
+	bulk := k.Bulk(probes, (*interval.Tree[uintInterval]).Intersections)
+	if len(bulk[0]) != 0 || len(bulk[1]) != 1 || len(bulk[2]) != 0 {
+		t.Fatalf("Bulk, got %v", bulk)
+	}
+
+	all := k.All(uintInterval{120, 130}, (*interval.Tree[uintInterval]).Covers)
+	if len(all) != 3 || len(all["chr1"]) != 1 || len(all["chrX"]) != 1 {
+		t.Fatalf("All, got %v", all)
+	}
+
+	if !k.Delete("chrX", uintInterval{0, 1000}) {
+		t.Fatal("Delete(chrX), got false, want true")
+	}
+	if k.Tree("chrX") != nil {
+		t.Fatal("empty tree not dropped after Delete")
+	}
+}
@@ -0,0 +1,206 @@
+package interval
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/gaissmai/interval/internal/query"
+)
+
+// fixed-width format for memory mapping, all numbers little endian:
+//
+//	magic "IVM", version byte
+//	item size uint32, number of nodes uint32
+//	nodes in preorder, each: left, right, minUpper, maxUpper int32, item [item size]byte
+//
+// The node records are the slab nodes, see [Slab], the child links are indices.
+const (
+	mappedMagic   = "IVM"
+	mappedVersion = 1
+
+	mappedHeaderSize = len(mappedMagic) + 1 + 4 + 4
+	mappedLinksSize  = 4 * 4
+)
+
+// StoreFixed writes the slab in the fixed-width format to w, to be opened zero-copy with [OpenMapped].
+//
+// Each item is encoded by encode into exactly itemSize bytes, e.g. an IPv6 range in 32 bytes.
+func (s *Slab[T]) StoreFixed(w io.Writer, itemSize int, encode func(dst []byte, item T)) error {
+	if itemSize <= 0 || itemSize > math.MaxInt32-mappedLinksSize {
+		return fmt.Errorf("interval: invalid item size %d", itemSize)
+	}
+
+	bw := bufio.NewWriter(w)
+
+	var buf [4]byte
+	bw.WriteString(mappedMagic)
+	bw.WriteByte(mappedVersion)
+	bw.Write(binary.LittleEndian.AppendUint32(buf[:0], uint32(itemSize)))
+	bw.Write(binary.LittleEndian.AppendUint32(buf[:0], uint32(len(s.nodes))))
+
+	rec := make([]byte, mappedLinksSize+itemSize)
+	for _, sn := range s.nodes {
+		binary.LittleEndian.PutUint32(rec[0:], uint32(sn.left))
+		binary.LittleEndian.PutUint32(rec[4:], uint32(sn.right))
+		binary.LittleEndian.PutUint32(rec[8:], uint32(sn.minUpper))
+		binary.LittleEndian.PutUint32(rec[12:], uint32(sn.maxUpper))
+
+		clear(rec[mappedLinksSize:])
+		encode(rec[mappedLinksSize:], sn.item)
+
+		bw.Write(rec)
+	}
+
+	return bw.Flush()
+}
+
+// Mapped is a read-only tree queried directly on the fixed-width records written by [Slab.StoreFixed],
+// without unmarshaling. The data is typically memory-mapped from a file with [MapFile],
+// the startup is zero-copy and the pages are loaded on demand by the OS.
+//
+// The items are decoded from the records on each access, keep the decode function cheap.
+type Mapped[T any] struct {
+	data     []byte // the node records, without header
+	count    int32
+	recSize  int
+	itemSize int
+	decode   func([]byte) T
+	tree     Tree[T] // for the compare functions, without nodes
+	query    query.Query[int32, T]
+}
+
+// OpenMapped returns a read-only tree on data in the fixed-width format, see [Slab.StoreFixed].
+// The compare function and the options must match the stored tree, decode is the
+// inverse of the encode function used for storing.
+//
+// The records are validated once, data must not be changed while the tree is in use.
+func OpenMapped[T any](data []byte, cmp func(a, b T) (ll, rr, lr, rl int), decode func([]byte) T, opts ...Option) (*Mapped[T], error) {
+	if len(data) < mappedHeaderSize || string(data[:len(mappedMagic)]) != mappedMagic {
+		return nil, ErrInvalidData
+	}
+
+	if version := data[len(mappedMagic)]; version != mappedVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidData, version)
+	}
+
+	if cmp == nil || decode == nil {
+		return nil, errors.New("interval: OpenMapped needs a compare and a decode function")
+	}
+
+	itemSize := binary.LittleEndian.Uint32(data[len(mappedMagic)+1:])
+	count := binary.LittleEndian.Uint32(data[len(mappedMagic)+5:])
+	data = data[mappedHeaderSize:]
+
+	if itemSize == 0 || itemSize > math.MaxInt32-mappedLinksSize || count > math.MaxInt32 {
+		return nil, ErrInvalidData
+	}
+
+	recSize := mappedLinksSize + int(itemSize)
+	if uint64(len(data)) != uint64(count)*uint64(recSize) {
+		return nil, fmt.Errorf("%w: size mismatch", ErrInvalidData)
+	}
+
+	m := &Mapped[T]{
+		data:     data,
+		count:    int32(count),
+		recSize:  recSize,
+		itemSize: int(itemSize),
+		decode:   decode,
+		tree:     *New(cmp, opts...),
+	}
+
+	// links must be valid indices, preorder: children follow the parent
+	for i := int32(0); i < m.count; i++ {
+		for f := 0; f < 4; f++ {
+			j := m.link(i, f)
+			if j < nilIdx || j >= m.count || f < 2 && j != nilIdx && j <= i || f >= 2 && j < i {
+				return nil, fmt.Errorf("%w: corrupt link in node %d", ErrInvalidData, i)
+			}
+		}
+	}
+
+	m.query = query.Query[int32, T]{Nodes: mappedNodes[T]{m}, Cmp: m.tree.cmp, Order: m.tree.compare}
+	return m, nil
+}
+
+// link fields in the node record
+const (
+	linkLeft = iota
+	linkRight
+	linkMinUpper
+	linkMaxUpper
+)
+
+// link, returns the index in field f of node i.
+func (m *Mapped[T]) link(i int32, f int) int32 {
+	off := int(i)*m.recSize + 4*f
+	return int32(binary.LittleEndian.Uint32(m.data[off:]))
+}
+
+// item, decodes the item of node i.
+func (m *Mapped[T]) item(i int32) T {
+	off := int(i)*m.recSize + mappedLinksSize
+	return m.decode(m.data[off : off+m.itemSize : off+m.itemSize])
+}
+
+// root index, nilIdx for an empty tree.
+func (m *Mapped[T]) root() int32 {
+	if m.count == 0 {
+		return nilIdx
+	}
+	return 0
+}
+
+// Len returns the number of items.
+func (m *Mapped[T]) Len() int {
+	return int(m.count)
+}
+
+// mappedNodes, the read access to the node records for the query algorithms.
+type mappedNodes[T any] struct{ m *Mapped[T] }
+
+func (ns mappedNodes[T]) IsNil(i int32) bool  { return i == nilIdx }
+func (ns mappedNodes[T]) Left(i int32) int32  { return ns.m.link(i, linkLeft) }
+func (ns mappedNodes[T]) Right(i int32) int32 { return ns.m.link(i, linkRight) }
+func (ns mappedNodes[T]) Item(i int32) T      { return ns.m.item(i) }
+func (ns mappedNodes[T]) MinUpper(i int32) T  { return ns.m.item(ns.m.link(i, linkMinUpper)) }
+func (ns mappedNodes[T]) MaxUpper(i int32) T  { return ns.m.item(ns.m.link(i, linkMaxUpper)) }
+
+// Find, see [Tree.Find].
+func (m *Mapped[T]) Find(item T) (result T, ok bool) {
+	return m.query.Find(m.root(), item)
+}
+
+// CoverLCP, see [Tree.CoverLCP].
+func (m *Mapped[T]) CoverLCP(item T) (result T, ok bool) {
+	return m.query.CoverLCP(m.root(), item)
+}
+
+// CoverSCP, see [Tree.CoverSCP].
+func (m *Mapped[T]) CoverSCP(item T) (result T, ok bool) {
+	return m.query.CoverSCP(m.root(), item)
+}
+
+// Covers, see [Tree.Covers].
+func (m *Mapped[T]) Covers(item T) []T {
+	return m.query.Covers(m.root(), item, nil)
+}
+
+// CoveredBy, see [Tree.CoveredBy].
+func (m *Mapped[T]) CoveredBy(item T) []T {
+	return m.query.CoveredBy(m.root(), item, nil)
+}
+
+// Intersects, see [Tree.Intersects].
+func (m *Mapped[T]) Intersects(item T) bool {
+	return m.query.Intersects(m.root(), item)
+}
+
+// Intersections, see [Tree.Intersections].
+func (m *Mapped[T]) Intersections(item T) []T {
+	return m.query.Intersections(m.root(), item, nil)
+}
@@ -0,0 +1,104 @@
+package interval_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func putUintIval(dst []byte, p uintInterval) {
+	binary.LittleEndian.PutUint64(dst[0:], uint64(p[0]))
+	binary.LittleEndian.PutUint64(dst[8:], uint64(p[1]))
+}
+
+func getUintIval(src []byte) uintInterval {
+	return uintInterval{uint(binary.LittleEndian.Uint64(src[0:])), uint(binary.LittleEndian.Uint64(src[8:]))}
+}
+
+func TestMapped(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(1_000)...)
+
+	var buf bytes.Buffer
+	if err := tree.Slab().StoreFixed(&buf, 16, putUintIval); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "tree.ivm")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, unmap, err := interval.MapFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := unmap(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	m, err := interval.OpenMapped(data, cmpUintInterval, getUintIval)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if size, _, _, _ := tree.Statistics(); m.Len() != size {
+		t.Fatalf("Len(), got: %d, want: %d", m.Len(), size)
+	}
+
+	for _, probe := range append(genUintIvals(200), ps...) {
+		got, gotOK := m.CoverLCP(probe)
+		want, wantOK := tree.CoverLCP(probe)
+		if got != want || gotOK != wantOK {
+			t.Fatalf("CoverLCP(%v), got: (%v, %v), want: (%v, %v)", probe, got, gotOK, want, wantOK)
+		}
+
+		got, gotOK = m.CoverSCP(probe)
+		want, wantOK = tree.CoverSCP(probe)
+		if got != want || gotOK != wantOK {
+			t.Fatalf("CoverSCP(%v), got: (%v, %v), want: (%v, %v)", probe, got, gotOK, want, wantOK)
+		}
+
+		got, gotOK = m.Find(probe)
+		want, wantOK = tree.Find(probe)
+		if got != want || gotOK != wantOK {
+			t.Fatalf("Find(%v), got: (%v, %v), want: (%v, %v)", probe, got, gotOK, want, wantOK)
+		}
+
+		if m.Intersects(probe) != tree.Intersects(probe) {
+			t.Fatalf("Intersects(%v) differs", probe)
+		}
+
+		if got, want := m.Covers(probe), tree.Covers(probe); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Covers(%v), got: %v, want: %v", probe, got, want)
+		}
+
+		if got, want := m.CoveredBy(probe), tree.CoveredBy(probe); !reflect.DeepEqual(got, want) {
+			t.Fatalf("CoveredBy(%v), got: %v, want: %v", probe, got, want)
+		}
+
+		if got, want := m.Intersections(probe), tree.Intersections(probe); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Intersections(%v), got: %v, want: %v", probe, got, want)
+		}
+	}
+
+	// corrupt data
+	if _, err := interval.OpenMapped(data[:len(data)-1], cmpUintInterval, getUintIval); !errors.Is(err, interval.ErrInvalidData) {
+		t.Fatalf("OpenMapped(truncated), got: %v, want: ErrInvalidData", err)
+	}
+
+	corrupt := bytes.Clone(data)
+	binary.LittleEndian.PutUint32(corrupt[12:], 0) // left child of root points to the root
+	if _, err := interval.OpenMapped(corrupt, cmpUintInterval, getUintIval); !errors.Is(err, interval.ErrInvalidData) {
+		t.Fatalf("OpenMapped(corrupt link), got: %v, want: ErrInvalidData", err)
+	}
+}
@@ -0,0 +1,173 @@
+package interval
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidData is returned when decoding malformed or unsupported serialized trees.
+var ErrInvalidData = errors.New("interval: invalid serialized data")
+
+// binary format, all numbers as uvarint:
+//
+//	magic "IVT", version byte
+//	encoding byte, 0: items as gob encoded []T, 1: length prefixed items from the codec
+//	gob:   gob stream
+//	codec: number of items, then for each item: length, bytes
+const (
+	binaryMagic   = "IVT"
+	binaryVersion = 1
+
+	encodingGob   = 0
+	encodingCodec = 1
+)
+
+// codec, the item encoding for the binary serialization, see [WithCodec].
+type codec[T any] struct {
+	encode func(T) ([]byte, error)
+	decode func([]byte) (T, error)
+}
+
+// WithCodec, the items are serialized by [Tree.MarshalBinary] with encode and restored by
+// [Tree.UnmarshalBinary] with decode.
+//
+// Without a codec the items are serialized with [encoding/gob], the items must be gob encodable then.
+// The type parameter must match the item type of the tree, otherwise the (un)marshal methods return an error.
+func WithCodec[T any](encode func(T) ([]byte, error), decode func([]byte) (T, error)) Option {
+	return func(o *options) {
+		o.codec = codec[T]{encode, decode}
+	}
+}
+
+// itemCodec, the configured codec, ok is false for the default gob encoding.
+func (t *Tree[T]) itemCodec() (c codec[T], ok bool, err error) {
+	if t.opts == nil || t.opts.codec == nil {
+		return c, false, nil
+	}
+
+	if c, ok = t.opts.codec.(codec[T]); !ok {
+		return c, false, fmt.Errorf("interval: codec item type %T does not match the tree", t.opts.codec)
+	}
+
+	return c, true, nil
+}
+
+// items returns all items in sorted order.
+func (t *Tree[T]) items() []T {
+	var items []T
+	t.traverse(t.root, inorder, 0, func(n *node[T], _ int) bool {
+		items = append(items, n.item)
+		return true
+	})
+	return items
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface, so the tree is also gob encodable.
+//
+// The items are encoded in sorted order, with the codec from [WithCodec] if given, with gob otherwise.
+// The shape of the tree is not serialized, it's rebuilt on unmarshaling.
+func (t Tree[T]) MarshalBinary() ([]byte, error) {
+	c, hasCodec, err := t.itemCodec()
+	if err != nil {
+		return nil, err
+	}
+
+	items := t.items()
+
+	buf := bytes.NewBufferString(binaryMagic)
+	buf.WriteByte(binaryVersion)
+
+	if !hasCodec {
+		buf.WriteByte(encodingGob)
+		if err := gob.NewEncoder(buf).Encode(items); err != nil {
+			return nil, fmt.Errorf("interval: gob encoding items: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	buf.WriteByte(encodingCodec)
+	buf.Write(binary.AppendUvarint(nil, uint64(len(items))))
+
+	for _, item := range items {
+		data, err := c.encode(item)
+		if err != nil {
+			return nil, fmt.Errorf("interval: encoding item %v: %w", item, err)
+		}
+		buf.Write(binary.AppendUvarint(nil, uint64(len(data))))
+		buf.Write(data)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+//
+// The tree must be initialized with the compare function and the same codec as the
+// marshaled tree, e.g. with [New], the zero value can't be used. All items in t are replaced.
+func (t *Tree[T]) UnmarshalBinary(data []byte) error {
+	if t.cmp == nil {
+		return fmt.Errorf("%w: UnmarshalBinary", ErrNoCompareFunc)
+	}
+
+	c, hasCodec, err := t.itemCodec()
+	if err != nil {
+		return err
+	}
+
+	if len(data) < len(binaryMagic)+2 || string(data[:len(binaryMagic)]) != binaryMagic {
+		return ErrInvalidData
+	}
+	data = data[len(binaryMagic):]
+
+	if data[0] != binaryVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidData, data[0])
+	}
+
+	var items []T
+
+	switch data[1] {
+	case encodingGob:
+		if err := gob.NewDecoder(bytes.NewReader(data[2:])).Decode(&items); err != nil {
+			return fmt.Errorf("%w: gob decoding items: %w", ErrInvalidData, err)
+		}
+
+	case encodingCodec:
+		if !hasCodec {
+			return errors.New("interval: data is encoded with a codec, initialize the tree WithCodec")
+		}
+
+		data = data[2:]
+		count, k := binary.Uvarint(data)
+		if k <= 0 || count > uint64(len(data)) {
+			return ErrInvalidData
+		}
+		data = data[k:]
+
+		items = make([]T, 0, count)
+		for i := uint64(0); i < count; i++ {
+			size, k := binary.Uvarint(data)
+			if k <= 0 || size > uint64(len(data)-k) {
+				return ErrInvalidData
+			}
+			data = data[k:]
+
+			item, err := c.decode(data[:size])
+			if err != nil {
+				return fmt.Errorf("%w: decoding item: %w", ErrInvalidData, err)
+			}
+			items = append(items, item)
+			data = data[size:]
+		}
+
+	default:
+		return fmt.Errorf("%w: unknown encoding %d", ErrInvalidData, data[1])
+	}
+
+	t.root = nil
+	t.Insert(items...)
+
+	return nil
+}
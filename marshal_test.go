@@ -0,0 +1,167 @@
+package interval_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func encodeUintIval(p uintInterval) ([]byte, error) {
+	return binary.AppendUvarint(binary.AppendUvarint(nil, uint64(p[0])), uint64(p[1])), nil
+}
+
+func decodeUintIval(data []byte) (p uintInterval, err error) {
+	a, k := binary.Uvarint(data)
+	b, _ := binary.Uvarint(data[k:])
+	return uintInterval{uint(a), uint(b)}, nil
+}
+
+func TestMarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(1_000)...)
+
+	// default gob encoding
+	data, err := tree.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := interval.New(cmpUintInterval)
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if !equalsSizeAndOrder(tree, got) {
+		t.Fatal("UnmarshalBinary(MarshalBinary()) differs")
+	}
+
+	// with codec
+	withCodec := interval.WithCodec(encodeUintIval, decodeUintIval)
+
+	tree2 := interval.New(cmpUintInterval, withCodec)
+	tree2.Insert(genUintIvals(1_000)...)
+
+	if data, err = tree2.MarshalBinary(); err != nil {
+		t.Fatal(err)
+	}
+
+	// replaces all items
+	got = interval.New(cmpUintInterval, withCodec)
+	got.Insert(ps...)
+
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if !equalsSizeAndOrder(tree2, got) {
+		t.Fatal("UnmarshalBinary(MarshalBinary()) with codec differs")
+	}
+
+	// codec data without codec
+	if err := interval.New(cmpUintInterval).UnmarshalBinary(data); err == nil {
+		t.Fatal("UnmarshalBinary() without codec, got: nil, want: error")
+	}
+
+	if err := got.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, interval.ErrInvalidData) {
+		t.Fatalf("UnmarshalBinary(truncated), got: %v, want: ErrInvalidData", err)
+	}
+
+	// codec with wrong item type
+	tree3 := interval.New(cmpUintInterval, interval.WithCodec(
+		func(string) ([]byte, error) { return nil, nil },
+		func([]byte) (string, error) { return "", nil }))
+
+	if _, err := tree3.MarshalBinary(); err == nil {
+		t.Fatal("MarshalBinary() with mismatched codec, got: nil, want: error")
+	}
+}
+
+func TestGobTree(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, ps...)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(tree); err != nil {
+		t.Fatal(err)
+	}
+
+	got := interval.New(cmpUintInterval)
+	if err := gob.NewDecoder(&buf).Decode(got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !equalsSizeAndOrder(tree, got) {
+		t.Fatal("gob roundtrip differs")
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, ps...)
+
+	data, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `[[0,6],[0,5],[1,8],[1,7],[1,5],[1,4],[2,8],[2,7],[4,8],[6,7],[7,9]]`
+	if string(data) != want {
+		t.Fatalf("MarshalJSON(), got: %s, want: %s", data, want)
+	}
+
+	got := interval.New(cmpUintInterval)
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !equalsSizeAndOrder(tree, got) {
+		t.Fatal("UnmarshalJSON(MarshalJSON()) differs")
+	}
+
+	// empty tree is an empty array, not null
+	if data, _ := json.Marshal(interval.New(cmpUintInterval)); string(data) != "[]" {
+		t.Fatalf("MarshalJSON() empty tree, got: %s, want: []", data)
+	}
+
+	// with codec, items as "a-b" strings
+	withCodec := interval.WithJSONCodec(
+		func(p uintInterval) ([]byte, error) { return json.Marshal(fmt.Sprintf("%d-%d", p[0], p[1])) },
+		func(data []byte) (p uintInterval, err error) {
+			var s string
+			if err = json.Unmarshal(data, &s); err != nil {
+				return
+			}
+			_, err = fmt.Sscanf(s, "%d-%d", &p[0], &p[1])
+			return
+		})
+
+	tree2 := interval.New(cmpUintInterval, withCodec)
+	tree2.Insert(ps[:3]...)
+
+	if data, err = json.Marshal(tree2); err != nil {
+		t.Fatal(err)
+	}
+
+	want = `["0-6","0-5","1-8"]`
+	if string(data) != want {
+		t.Fatalf("MarshalJSON() with codec, got: %s, want: %s", data, want)
+	}
+
+	got = interval.New(cmpUintInterval, withCodec)
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !equalsSizeAndOrder(tree2, got) {
+		t.Fatal("UnmarshalJSON(MarshalJSON()) with codec differs")
+	}
+}
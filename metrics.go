@@ -0,0 +1,143 @@
+package interval
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Metrics receives the instrumentation of a tree configured with [WithMetrics].
+//
+// The methods are called synchronously in the hot path, possibly from many goroutines
+// concurrently, implementations must be fast and safe for concurrent use.
+// See [Counters] for a ready-made implementation, adapters for Prometheus
+// or similar are easily written.
+type Metrics interface {
+	// Inserted is called with the number of items passed to an insert, duplicates included.
+	Inserted(n int)
+
+	// Deleted is called with the number of items removed by a delete.
+	Deleted(n int)
+
+	// Lookup is called after every query, op is the method name, e.g. "CoverLCP".
+	// Visited is the number of compare function calls, a measure for the nodes visited,
+	// results is the number of items returned.
+	Lookup(op string, visited, results int)
+}
+
+// StructureMetrics is an optional extension of [Metrics]. If the configured Metrics implements it,
+// the tree also reports the structural operations, to distinguish algorithmic degradation
+// from workload growth in performance investigations.
+//
+// The treap is balanced by splits and joins only, there are no rotations.
+type StructureMetrics interface {
+	// Restructured is called for every split and join of a treap and for every copy
+	// of a shared node (copy-on-write), op is "split", "join" or "copy".
+	Restructured(op string)
+}
+
+// WithMetrics, the tree reports the inserts, deletes and lookups to m and the structural
+// operations if m implements [StructureMetrics].
+//
+// All trees derived from this tree report to the same m. Without this option
+// the instrumentation costs just a nil check.
+func WithMetrics(m Metrics) Option {
+	return func(o *options) {
+		o.metrics = m
+		o.structure, _ = m.(StructureMetrics)
+	}
+}
+
+// metrics, the configured Metrics or nil.
+func (t *Tree[T]) metrics() Metrics {
+	if t.opts == nil {
+		return nil
+	}
+	return t.opts.metrics
+}
+
+// restructured, reports the structural operation if configured.
+func (t *Tree[T]) restructured(op string) {
+	if t.opts != nil && t.opts.structure != nil {
+		t.opts.structure.Restructured(op)
+	}
+}
+
+// observe instruments a query, t must be the private copy of the value receiver.
+// The compare function of t is wrapped with a counter, the returned func reports the lookup.
+func (t *Tree[T]) observe(op string) (report func(results int)) {
+	var visited int
+
+	cmp := t.cmp
+	t.cmp = func(a, b T) (ll, rr, lr, rl int) {
+		visited++
+		return cmp(a, b)
+	}
+
+	m := t.opts.metrics
+	return func(results int) {
+		m.Lookup(op, visited, results)
+	}
+}
+
+// Counters is a [Metrics] and [StructureMetrics] implementation with atomic counters.
+//
+// It implements the expvar.Var interface and can be published directly:
+//
+//	c := new(interval.Counters)
+//	expvar.Publish("routes", c)
+//	tree := interval.New(cmp, interval.WithMetrics(c))
+type Counters struct {
+	Inserts atomic.Uint64
+	Deletes atomic.Uint64
+	Lookups atomic.Uint64
+	Visited atomic.Uint64
+	Results atomic.Uint64
+
+	Splits atomic.Uint64
+	Joins  atomic.Uint64
+	Copies atomic.Uint64
+}
+
+// Inserted implements [Metrics].
+func (c *Counters) Inserted(n int) {
+	c.Inserts.Add(uint64(n))
+}
+
+// Deleted implements [Metrics].
+func (c *Counters) Deleted(n int) {
+	c.Deletes.Add(uint64(n))
+}
+
+// Lookup implements [Metrics].
+func (c *Counters) Lookup(_ string, visited, results int) {
+	c.Lookups.Add(1)
+	c.Visited.Add(uint64(visited))
+	c.Results.Add(uint64(results))
+}
+
+// Restructured implements [StructureMetrics].
+func (c *Counters) Restructured(op string) {
+	switch op {
+	case "split":
+		c.Splits.Add(1)
+	case "join":
+		c.Joins.Add(1)
+	case "copy":
+		c.Copies.Add(1)
+	}
+}
+
+// String returns the counters as JSON object, see expvar.Var.
+func (c *Counters) String() string {
+	return fmt.Sprintf(`{"inserts": %d, "deletes": %d, "lookups": %d, "visited": %d, "results": %d, "splits": %d, "joins": %d, "copies": %d}`,
+		c.Inserts.Load(), c.Deletes.Load(), c.Lookups.Load(), c.Visited.Load(), c.Results.Load(),
+		c.Splits.Load(), c.Joins.Load(), c.Copies.Load())
+}
+
+// count, 1 for a hit, 0 otherwise.
+func count(ok bool) int {
+	if ok {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,115 @@
+package interval_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+type opRecorder struct {
+	interval.Counters
+	ops []string
+}
+
+func (r *opRecorder) Lookup(op string, visited, results int) {
+	r.ops = append(r.ops, op)
+	r.Counters.Lookup(op, visited, results)
+}
+
+func TestWithMetrics(t *testing.T) {
+	t.Parallel()
+
+	m := new(opRecorder)
+	tree := interval.New(cmpUintInterval, interval.WithMetrics(m))
+
+	ivals := []uintInterval{{0, 100}, {10, 20}, {30, 40}, {50, 60}}
+	tree.Insert(ivals...)
+	tree = tree.InsertImmutable(uintInterval{70, 80})
+
+	if got := m.Inserts.Load(); got != 5 {
+		t.Fatalf("Inserts, got %d, want 5", got)
+	}
+
+	tree.Delete(uintInterval{70, 80})
+	tree.Delete(uintInterval{70, 80})
+	if got := m.Deletes.Load(); got != 1 {
+		t.Fatalf("Deletes, got %d, want 1", got)
+	}
+
+	tree.Find(uintInterval{10, 20})
+	tree.CoverLCP(uintInterval{12, 13})
+	tree.Intersections(uintInterval{15, 35})
+
+	wantOps := []string{"Find", "CoverLCP", "Intersections"}
+	if len(m.ops) != len(wantOps) {
+		t.Fatalf("Lookup ops, got %v, want %v", m.ops, wantOps)
+	}
+	for i := range wantOps {
+		if m.ops[i] != wantOps[i] {
+			t.Fatalf("Lookup ops, got %v, want %v", m.ops, wantOps)
+		}
+	}
+
+	// Find: 1, CoverLCP: 1, Intersections: {0,100}, {10,20}, {30,40}
+	if got := m.Results.Load(); got != 5 {
+		t.Fatalf("Results, got %d, want 5", got)
+	}
+	if m.Visited.Load() == 0 {
+		t.Fatal("Visited, got 0, want > 0")
+	}
+
+	if !json.Valid([]byte(m.String())) {
+		t.Fatalf("String() is not valid JSON: %s", m.String())
+	}
+}
+
+// lookupsOnly implements Metrics, but not StructureMetrics
+type lookupsOnly struct{ lookups int }
+
+func (*lookupsOnly) Inserted(int)              {}
+func (*lookupsOnly) Deleted(int)               {}
+func (m *lookupsOnly) Lookup(string, int, int) { m.lookups++ }
+
+func TestStructureMetrics(t *testing.T) {
+	t.Parallel()
+
+	c := new(interval.Counters)
+	mt := interval.NewMutTree(cmpUintInterval, interval.WithMetrics(c))
+
+	// in place, the mutable tree owns all nodes, no copies
+	for _, item := range genUintIvals(1_000) {
+		mt.Insert(item)
+	}
+
+	if c.Splits.Load() == 0 || c.Copies.Load() != 0 {
+		t.Fatalf("Insert in place, splits: %d, copies: %d, want splits > 0 and no copies", c.Splits.Load(), c.Copies.Load())
+	}
+	tree := mt.Freeze()
+
+	// immutable, the path is copied
+	joins := c.Joins.Load()
+	tree2, _ := tree.DeleteImmutable(tree.Min())
+
+	if c.Copies.Load() == 0 || c.Joins.Load() == joins {
+		t.Fatalf("DeleteImmutable, copies: %d, joins: %d, want > 0", c.Copies.Load(), c.Joins.Load()-joins)
+	}
+
+	// the derived tree reports to the same counters
+	copies := c.Copies.Load()
+	tree2.InsertImmutable(uintInterval{1, 2})
+
+	if c.Copies.Load() == copies {
+		t.Fatal("InsertImmutable on derived tree, no copies reported")
+	}
+
+	// Metrics without StructureMetrics
+	m := new(lookupsOnly)
+	tree3 := interval.New(cmpUintInterval, interval.WithMetrics(m))
+	tree3.Insert(ps...)
+	tree3.InsertImmutable(uintInterval{1, 2}).Covers(uintInterval{3, 4})
+
+	if m.lookups != 1 {
+		t.Fatalf("Lookup, got %d calls, want 1", m.lookups)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package interval
+
+import "os"
+
+// MapFile reads the file into memory, e.g. for [OpenMapped].
+// Memory mapping is not supported on this platform, unmap is a no-op.
+func MapFile(path string) (data []byte, unmap func() error, err error) {
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
@@ -0,0 +1,35 @@
+//go:build unix
+
+package interval
+
+import (
+	"os"
+	"syscall"
+)
+
+// MapFile maps the file read-only into memory, e.g. for [OpenMapped].
+// The data must not be used after calling unmap.
+func MapFile(path string) (data []byte, unmap func() error, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// mmap of zero length fails
+	if fi.Size() == 0 {
+		return nil, func() error { return nil }, nil
+	}
+
+	data, err = syscall.Mmap(int(f.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, &os.PathError{Op: "mmap", Path: path, Err: err}
+	}
+
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
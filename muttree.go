@@ -0,0 +1,146 @@
+package interval
+
+import "io"
+
+// MutTree is the mutable counterpart of the persistent [Tree], all changes are made in place.
+// Build and maintain large trees with a MutTree, hand out the persistent snapshots with [MutTree.Freeze].
+//
+// A MutTree and the trees converted from or to it never share mutable nodes,
+// conversions are O(1) in both directions, see [Tree.Mutable] and [MutTree.Freeze].
+// The nodes are copied on the first change (copy-on-write), no aliasing between the persistent
+// and the mutable world is possible.
+//
+// A MutTree is not safe for concurrent use.
+type MutTree[T any] struct {
+	tree Tree[T]
+}
+
+// NewMutTree initializes an empty mutable tree with the compare function and the options, see [New].
+func NewMutTree[T any](cmp func(a, b T) (ll, rr, lr, rl int), opts ...Option) *MutTree[T] {
+	return &MutTree[T]{tree: *New(cmp, opts...)}
+}
+
+// Mutable returns a mutable tree with the items of t in O(1), t remains unchanged.
+// Mutable only reads t, it is safe for concurrent use, e.g. on a version published in a [Store].
+func (t *Tree[T]) Mutable() *MutTree[T] {
+	m := &MutTree[T]{tree: *t}
+
+	// owns no nodes, the shared nodes are copied on the first change
+	m.tree.owner = 0
+	return m
+}
+
+// Freeze returns the persistent tree with the current items in O(1).
+// The mutable tree can be used further, the returned tree remains unchanged.
+func (m *MutTree[T]) Freeze() *Tree[T] {
+	return m.tree.Clone()
+}
+
+// Insert inserts the items in place, duplicates replace the previous elements.
+//
+// Large bulk inserts into an empty tree are sorted and built bottom-up in O(n), large bulk inserts
+// into a non-empty tree are done concurrently if the tree is configured with [WithParallelism].
+// Otherwise for batches large compared to the tree, the augmented values are recalculated once
+// after the batch and not along each insert path.
+func (m *MutTree[T]) Insert(items ...T) {
+	m.tree.insertInPlace(items...)
+}
+
+// InsertWithPriority inserts the item in place with the given priority instead of a random one,
+// see [Tree.InsertWithPriority] for the use of priorities.
+func (m *MutTree[T]) InsertWithPriority(item T, prio uint32) {
+	m.tree.insertWithPriorityInPlace(item, prio)
+}
+
+// InsertWithPriorities inserts the items in place with the given priorities,
+// see [NewTreeWithPriorities] and [Tree.ItemsWithPriority].
+func (m *MutTree[T]) InsertWithPriorities(items ...Prioritized[T]) {
+	m.tree.insertWithPrioritiesInPlace(items...)
+}
+
+// Delete removes the item in place, returns true if it exists, false otherwise.
+func (m *MutTree[T]) Delete(item T) bool {
+	return m.tree.deleteInPlace(item)
+}
+
+// Union combines the other tree in place into m. In case of duplicate items, the overwrite flag
+// controls whether the union keeps the original or the item of the other tree.
+// The other tree remains unchanged, its nodes are copied as needed.
+func (m *MutTree[T]) Union(other *Tree[T], overwrite bool) {
+	m.tree.unionInPlace(other, overwrite)
+}
+
+// UnionConcurrent, same as [MutTree.Union] with up to jobs goroutines, see [Tree.UnionImmutableConcurrent].
+func (m *MutTree[T]) UnionConcurrent(jobs int, other *Tree[T], overwrite bool) {
+	m.tree.unionConcurrentInPlace(jobs, other, overwrite)
+}
+
+// Find, see [Tree.Find].
+func (m *MutTree[T]) Find(item T) (result T, ok bool) {
+	return m.tree.Find(item)
+}
+
+// CoverLCP, see [Tree.CoverLCP].
+func (m *MutTree[T]) CoverLCP(item T) (result T, ok bool) {
+	return m.tree.CoverLCP(item)
+}
+
+// CoverSCP, see [Tree.CoverSCP].
+func (m *MutTree[T]) CoverSCP(item T) (result T, ok bool) {
+	return m.tree.CoverSCP(item)
+}
+
+// Intersects, see [Tree.Intersects].
+func (m *MutTree[T]) Intersects(item T) bool {
+	return m.tree.Intersects(item)
+}
+
+// Covers, see [Tree.Covers].
+func (m *MutTree[T]) Covers(item T) []T {
+	return m.tree.Covers(item)
+}
+
+// CoveredBy, see [Tree.CoveredBy].
+func (m *MutTree[T]) CoveredBy(item T) []T {
+	return m.tree.CoveredBy(item)
+}
+
+// Intersections, see [Tree.Intersections].
+func (m *MutTree[T]) Intersections(item T) []T {
+	return m.tree.Intersections(item)
+}
+
+// Precedes, see [Tree.Precedes].
+func (m *MutTree[T]) Precedes(item T) []T {
+	return m.tree.Precedes(item)
+}
+
+// PrecededBy, see [Tree.PrecededBy].
+func (m *MutTree[T]) PrecededBy(item T) []T {
+	return m.tree.PrecededBy(item)
+}
+
+// Visit, see [Tree.Visit]. The visitFn must not change m.
+func (m *MutTree[T]) Visit(start, stop T, visitFn func(item T) bool) {
+	m.tree.Visit(start, stop, visitFn)
+}
+
+// Min, see [Tree.Min].
+func (m *MutTree[T]) Min() T {
+	return m.tree.Min()
+}
+
+// Max, see [Tree.Max].
+func (m *MutTree[T]) Max() T {
+	return m.tree.Max()
+}
+
+// Fprint, see [Tree.Fprint].
+func (m *MutTree[T]) Fprint(w io.Writer, opts ...PrintOption) error {
+	return m.tree.Fprint(w, opts...)
+}
+
+// String, see [Tree.String].
+func (m *MutTree[T]) String() string {
+	return m.tree.String()
+}
@@ -0,0 +1,124 @@
+package interval_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestMutTree(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(1_000)
+
+	m := interval.NewMutTree(cmpUintInterval)
+	m.Insert(ivals...)
+
+	frozen := m.Freeze()
+	want := frozen.String()
+
+	// changes of the mutable tree are not visible in the frozen tree
+	for _, item := range ivals[:500] {
+		m.Delete(item)
+	}
+	m.Insert(uintInterval{1, 2})
+
+	if got := frozen.String(); got != want {
+		t.Fatal("MutTree, frozen tree changed by later in place changes")
+	}
+	if err := frozen.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	// and back, changes of the mutable copy are not visible in the origin
+	m2 := frozen.Mutable()
+	m2.Insert(uintInterval{3, 4})
+	m2.Delete(frozen.Min())
+
+	if got := frozen.String(); got != want {
+		t.Fatal("Mutable, origin changed by changes of the mutable copy")
+	}
+	if _, ok := m2.Find(uintInterval{3, 4}); !ok {
+		t.Fatal("Mutable, inserted item not found")
+	}
+
+	// the origin itself may still be changed in place, without effect on the mutable copy
+	frozen.Insert(uintInterval{5, 6})
+	if _, ok := m2.Find(uintInterval{5, 6}); ok {
+		t.Fatal("Mutable, copy changed by in place changes of the origin")
+	}
+}
+
+func TestMutableConcurrent(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(1_000)
+	want := interval.NewTree(cmpUintInterval, ivals...).String()
+
+	store := interval.NewStore(interval.NewTree(cmpUintInterval, ivals...))
+	owning := interval.NewTree(cmpUintInterval, ivals...)
+
+	for _, shared := range []*interval.Tree[uintInterval]{store.Load(), owning} {
+		var wg sync.WaitGroup
+		for i := range 16 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				// Mutable must not write the shared tree
+				m := shared.Mutable()
+				m.Insert(genUintIvals(10)...)
+				m.Delete(ivals[i])
+
+				if err := m.Freeze().CheckInvariants(); err != nil {
+					t.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if got := shared.String(); got != want {
+			t.Fatal("Mutable, shared tree changed by concurrent mutable copies")
+		}
+	}
+}
+
+func TestMutTreeFreeze(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(1_000)
+
+	m := interval.NewMutTree(cmpUintInterval)
+	m.Insert(ivals...)
+	m.Insert(ps...)
+
+	for _, item := range ps {
+		if !m.Delete(item) {
+			t.Fatalf("Delete(%v), got: false, want: true", item)
+		}
+	}
+
+	tree1 := m.Freeze()
+	want := interval.NewTree(cmpUintInterval, ivals...)
+
+	if !equalsSizeAndOrder(tree1, want) {
+		t.Fatal("Freeze() differs with NewTree()")
+	}
+
+	// go on mutating, the frozen tree must not change
+	m.Insert(ps...)
+	m.Delete(ivals[0])
+
+	if !equalsSizeAndOrder(tree1, want) {
+		t.Fatal("MutTree changed the frozen tree")
+	}
+
+	// change the frozen tree, the mutable tree must not change
+	tree2 := m.Freeze()
+	tree1.Insert(genUintIvals(10)...)
+
+	if !equalsSizeAndOrder(tree2, m.Freeze()) {
+		t.Fatal("frozen tree changed the MutTree")
+	}
+}
@@ -0,0 +1,286 @@
+package interval
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+)
+
+// Option configures a tree created with [New].
+//
+// The generic options, e.g. [WithTiebreak] or [WithHash], take functions of the items.
+// Their type parameter must match the item type of the tree, otherwise [New] panics
+// and [NewTreeE] returns an error wrapping [ErrInvalidOption].
+type Option func(*options)
+
+// options, the collected configuration of all options.
+type options struct {
+	arena bool
+	jobs  int         // parallelism for bulk operations
+	rng   *lockedRand // deterministic source for node priorities
+
+	halfOpen  bool // intervals are [a,b), not [a,b]
+	closedCmp any  // func(a, b T) (ll, rr, lr, rl int), the compare function without WithHalfOpen
+
+	codec     any // codec[T] for the binary serialization, see [WithCodec]
+	jsonCodec any // codec[T] for the JSON serialization, see [WithJSONCodec]
+	hash      any // func(T) uint64, see [WithHash]
+	onInsert  any // func(T), see [WithOnInsert]
+	onDelete  any // func(T), see [WithOnDelete]
+	tiebreak  any // func(a, b T) int, see [WithTiebreak]
+	tags      any // func(T) []string, see [WithTags]
+	stringer  any // func(T) string, see [WithStringer]
+
+	metrics   Metrics          // see [WithMetrics]
+	structure StructureMetrics // metrics, if it implements StructureMetrics
+}
+
+// ErrInvalidOption is returned by [NewTreeE], or the panic value of [New], if an option
+// doesn't match the tree item type or conflicts with another option.
+var ErrInvalidOption = errors.New("interval: invalid option")
+
+// New initializes an empty interval tree with the compare function and the options.
+// Items are added with [Tree.Insert] or the immutable variants.
+//
+// See [NewTree] for the contract of the compare function.
+// New panics if an option doesn't match the tree item type, see [NewTreeE] for a fail-fast variant.
+func New[T any](cmp func(a, b T) (ll, rr, lr, rl int), opts ...Option) *Tree[T] {
+	t, err := newTree(cmp, opts)
+	if err != nil {
+		panic(err.Error())
+	}
+	return t
+}
+
+// NewTreeE is like [New], but returns an error instead of a tree that fails on first use,
+// e.g. for trees created from a configuration.
+//
+// The error wraps [ErrNoCompareFunc] if cmp is nil and [ErrInvalidOption] if an option is nil,
+// doesn't match the tree item type, including the codecs, or if options conflict:
+// [WithSeed] and [WithParallelism] with more than one job, the tree shape isn't reproducible.
+func NewTreeE[T any](cmp func(a, b T) (ll, rr, lr, rl int), opts ...Option) (*Tree[T], error) {
+	if cmp == nil {
+		return nil, fmt.Errorf("%w: NewTreeE", ErrNoCompareFunc)
+	}
+
+	for i, opt := range opts {
+		if opt == nil {
+			return nil, fmt.Errorf("%w: option %d is nil", ErrInvalidOption, i)
+		}
+	}
+
+	t, err := newTree(cmp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err := t.itemCodec(); err != nil {
+		return nil, fmt.Errorf("%w: WithCodec, %w", ErrInvalidOption, err)
+	}
+
+	if _, _, err := t.jsonItemCodec(); err != nil {
+		return nil, fmt.Errorf("%w: WithJSONCodec, %w", ErrInvalidOption, err)
+	}
+
+	if t.opts.rng != nil && t.parallelism() > 1 {
+		return nil, fmt.Errorf("%w: WithSeed conflicts with WithParallelism(%d)", ErrInvalidOption, t.opts.jobs)
+	}
+
+	return t, nil
+}
+
+// newTree, see New and NewTreeE.
+func newTree[T any](cmp func(a, b T) (ll, rr, lr, rl int), opts []Option) (*Tree[T], error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.halfOpen {
+		o.closedCmp = cmp
+		cmp = halfOpenCmp(cmp)
+	}
+
+	t := &Tree[T]{cmp: checkedCmp(cmp), opts: &o}
+
+	if o.arena {
+		t.arena = new(arena[T])
+	}
+
+	if o.hash != nil {
+		if !hashSupported {
+			return nil, fmt.Errorf("%w: WithHash, build with the tag interval_hash", ErrInvalidOption)
+		}
+		var ok bool
+		if t.hashFn, ok = o.hash.(func(T) uint64); !ok {
+			return nil, fmt.Errorf("%w: WithHash, hash function %T does not match the tree item type", ErrInvalidOption, o.hash)
+		}
+	}
+
+	if o.onInsert != nil {
+		var ok bool
+		if t.onInsert, ok = o.onInsert.(func(T)); !ok {
+			return nil, fmt.Errorf("%w: WithOnInsert, hook %T does not match the tree item type", ErrInvalidOption, o.onInsert)
+		}
+	}
+
+	if o.onDelete != nil {
+		var ok bool
+		if t.onDelete, ok = o.onDelete.(func(T)); !ok {
+			return nil, fmt.Errorf("%w: WithOnDelete, hook %T does not match the tree item type", ErrInvalidOption, o.onDelete)
+		}
+	}
+
+	if o.tiebreak != nil {
+		var ok bool
+		if t.tiebreak, ok = o.tiebreak.(func(a, b T) int); !ok {
+			return nil, fmt.Errorf("%w: WithTiebreak, tiebreak %T does not match the tree item type", ErrInvalidOption, o.tiebreak)
+		}
+	}
+
+	if o.tags != nil {
+		var ok bool
+		if t.tags, ok = o.tags.(func(T) []string); !ok {
+			return nil, fmt.Errorf("%w: WithTags, tag function %T does not match the tree item type", ErrInvalidOption, o.tags)
+		}
+	}
+
+	if o.stringer != nil {
+		var ok bool
+		if t.stringer, ok = o.stringer.(func(T) string); !ok {
+			return nil, fmt.Errorf("%w: WithStringer, stringer %T does not match the tree item type", ErrInvalidOption, o.stringer)
+		}
+	}
+
+	return t, nil
+}
+
+// WithArena, nodes are allocated in chunks from an arena instead of one by one.
+//
+// This drastically reduces the number of allocations and the GC pressure for huge
+// trees and for heavy immutable churn. As a drawback, a chunk is not garbage collected
+// as long as any node in it is still alive.
+//
+// All trees derived from this tree by immutable operations share the same arena.
+func WithArena() Option {
+	return func(o *options) {
+		o.arena = true
+	}
+}
+
+// WithParallelism, large bulk inserts (> 100_000 items) with [Tree.Insert] are split into chunks,
+// the partial trees are created concurrently with up to jobs goroutines and combined afterwards.
+//
+// If jobs <= 0 the number of logical CPUs usable by the current process is taken.
+// Small inputs are always inserted sequentially, the fan-out is not worth the overhead.
+func WithParallelism(jobs int) Option {
+	return func(o *options) {
+		if jobs <= 0 {
+			jobs = runtime.NumCPU()
+		}
+		o.jobs = jobs
+	}
+}
+
+// parallelism, the configured number of jobs for bulk operations, 1 if not configured.
+func (t *Tree[T]) parallelism() int {
+	if t.opts == nil || t.opts.jobs < 1 {
+		return 1
+	}
+	return t.opts.jobs
+}
+
+// WithSeed, the node priorities are taken from a pseudo-random source with the given seed.
+//
+// The same sequence of operations then yields identical tree shapes across runs,
+// useful for tests, golden files and reproducible builds. All trees derived from this tree
+// share the source, it is safe for concurrent use but concurrent callers get
+// the numbers in no definite order. For the same reason the shape is not reproducible
+// in combination with [WithParallelism].
+func WithSeed(seed uint64) Option {
+	return func(o *options) {
+		o.rng = &lockedRand{r: rand.New(rand.NewPCG(seed, seed))}
+	}
+}
+
+// WithHalfOpen, the intervals are treated as half-open [a,b) instead of closed [a,b].
+//
+// The compare function is still written for the endpoints as they are, but intervals that just meet,
+// the right point of one equals the left point of the other, no longer intersect, they precede each other.
+// This affects Intersects, Intersections, Precedes and PrecededBy. Adjacent time ranges like
+// [09:00,10:00) and [10:00,11:00) are then disjoint as expected.
+//
+// Degenerate intervals [a,a) are empty and considered malformed by [Tree.InsertStrict].
+func WithHalfOpen() Option {
+	return func(o *options) {
+		o.halfOpen = true
+	}
+}
+
+// halfOpenCmp, wraps the compare function for half-open intervals, the right points are exclusive.
+//
+//	a.left == b.right => lr: +1
+//	a.right == b.left => rl: -1
+func halfOpenCmp[T any](cmp func(a, b T) (ll, rr, lr, rl int)) func(a, b T) (ll, rr, lr, rl int) {
+	if cmp == nil {
+		return nil
+	}
+
+	return func(a, b T) (ll, rr, lr, rl int) {
+		ll, rr, lr, rl = cmp(a, b)
+		if lr == 0 {
+			lr = 1
+		}
+		if rl == 0 {
+			rl = -1
+		}
+		return
+	}
+}
+
+// WithTiebreak, items with equal intervals, both endpoints equal, are distinct items ordered by fn
+// instead of duplicates replacing each other. Useful for intervals with a payload,
+// e.g. rules ordered by priority or insertion time, for Visit, Covers and printing.
+//
+// fn returns a negative number if a sorts before b, a positive number if a sorts after b
+// and 0 if a and b are duplicates. The order of intervals with equal left points but different
+// right points, supersets to the left, is not affected, the query algorithms rely on it.
+// For the type parameter see [Option].
+func WithTiebreak[T any](fn func(a, b T) int) Option {
+	return func(o *options) {
+		o.tiebreak = fn
+	}
+}
+
+// WithStringer, the items are printed with fn by [Tree.String], [Tree.Fprint] and [Tree.FprintBST]
+// instead of the fmt.Stringer or the default format %v, e.g. for items without a suitable
+// String method or with payload secrets that must not leak into logs.
+//
+// The print option [WithFormatter] still takes precedence for a single call of Fprint.
+func WithStringer[T any](fn func(T) string) Option {
+	return func(o *options) {
+		o.stringer = fn
+	}
+}
+
+// lockedRand, a rand source safe for concurrent use.
+type lockedRand struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+func (l *lockedRand) Uint32() uint32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Uint32()
+}
+
+// randPrio, the priority for a new node, from the seeded source if configured.
+func (t *Tree[T]) randPrio() uint32 {
+	if t.opts != nil && t.opts.rng != nil {
+		return t.opts.rng.Uint32()
+	}
+	return rand.Uint32()
+}
@@ -0,0 +1,347 @@
+package interval_test
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestNewWithArena(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(10_000)
+
+	tree1 := interval.NewTree(cmpUintInterval, ivals...)
+	tree2 := interval.New(cmpUintInterval, interval.WithArena())
+	tree2.Insert(ivals...)
+
+	if !equalsSizeAndOrder(tree1, tree2) {
+		t.Fatal("New(WithArena()) differs with NewTree()")
+	}
+
+	// immutable churn with nodes from the arena
+	tree3 := tree2.InsertImmutable(ps...)
+	for _, item := range ps {
+		var ok bool
+		if tree3, ok = tree3.DeleteImmutable(item); !ok {
+			t.Fatalf("DeleteImmutable(%v), got: false, want: true", item)
+		}
+	}
+
+	if !equalsSizeAndOrder(tree1, tree3) {
+		t.Fatal("immutable insert/delete with arena changed the tree")
+	}
+
+	if !equalsSizeAndOrder(tree2, tree2.Clone()) {
+		t.Fatal("Clone() with arena differs")
+	}
+}
+
+func TestRecycle(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(10_000)
+
+	plain := interval.NewTree(cmpUintInterval, ivals...)
+	if got := plain.InsertImmutable(ps...).Recycle(plain); got != 0 {
+		t.Fatalf("Recycle without arena, got %d, want 0", got)
+	}
+
+	want := interval.NewTree(cmpUintInterval, ivals...)
+
+	tree := interval.New(cmpUintInterval, interval.WithArena())
+	tree.Insert(ivals...)
+
+	// versions by immutable churn, each predecessor recycled before the next version
+	recycled := 0
+	for i, item := range genUintIvals(1_000) {
+		next := tree.InsertImmutable(item)
+		want.Insert(item)
+
+		if i%2 == 1 {
+			var ok bool
+			next, ok = next.DeleteImmutable(item)
+			if !ok {
+				t.Fatalf("DeleteImmutable(%v), got: false, want: true", item)
+			}
+			want.Delete(item)
+		}
+
+		n := next.Recycle(tree)
+		if n == 0 {
+			t.Fatalf("Recycle, got 0 recycled nodes after insert of %v", item)
+		}
+		recycled += n
+		tree = next
+	}
+
+	if err := tree.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !equalsSizeAndOrder(want, tree) {
+		t.Fatal("immutable churn with recycled nodes changed the tree")
+	}
+
+	if got := tree.Recycle(tree); got != 0 {
+		t.Fatalf("Recycle of itself, got %d, want 0", got)
+	}
+
+	t.Logf("recycled %d nodes", recycled)
+}
+
+func TestWithParallelism(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(200_000)
+
+	want := interval.NewTree(cmpUintInterval, ivals...)
+
+	// auto-tuned number of jobs
+	tree1 := interval.New(cmpUintInterval, interval.WithParallelism(0))
+	tree1.Insert(ivals[:100_000]...)
+	tree1.Insert(ivals[100_000:]...)
+
+	if !equalsSizeAndOrder(want, tree1) {
+		t.Fatal("New(WithParallelism()) differs with NewTree()")
+	}
+
+	tree2 := interval.New(cmpUintInterval, interval.WithParallelism(3), interval.WithArena())
+	tree2.Insert(ivals...)
+
+	if !equalsSizeAndOrder(want, tree2) {
+		t.Fatal("New(WithParallelism(), WithArena()) differs with NewTree()")
+	}
+}
+
+func TestWithSeed(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(1_000)
+
+	// FprintBST without the pointers
+	shape := func(tree *interval.Tree[uintInterval]) string {
+		w := new(strings.Builder)
+		_ = tree.FprintBST(w)
+		return regexp.MustCompile(`\[0x.*\]`).ReplaceAllString(w.String(), "")
+	}
+
+	tree1 := interval.New(cmpUintInterval, interval.WithSeed(42))
+	tree1.Insert(ivals...)
+
+	tree2 := interval.New(cmpUintInterval, interval.WithSeed(42))
+	tree2.Insert(ivals...)
+
+	if shape(tree1) != shape(tree2) {
+		t.Fatal("WithSeed(), same seed, but tree shapes differ")
+	}
+
+	tree3 := interval.New(cmpUintInterval, interval.WithSeed(43))
+	tree3.Insert(ivals...)
+
+	if shape(tree1) == shape(tree3) {
+		t.Fatal("WithSeed(), different seeds, but tree shapes are identical")
+	}
+}
+
+func TestWithHalfOpen(t *testing.T) {
+	t.Parallel()
+
+	ivals := []uintInterval{{1, 5}, {5, 10}, {10, 15}}
+
+	closed := interval.NewTree(cmpUintInterval, ivals...)
+	halfOpen := interval.New(cmpUintInterval, interval.WithHalfOpen())
+	halfOpen.Insert(ivals...)
+
+	probe := uintInterval{5, 10}
+
+	if got := closed.Intersections(probe); len(got) != 3 {
+		t.Fatalf("closed, Intersections(%v), got: %v, want: 3 items", probe, got)
+	}
+
+	if got := halfOpen.Intersections(probe); len(got) != 1 || got[0] != probe {
+		t.Fatalf("half-open, Intersections(%v), got: %v, want: [%v]", probe, got, probe)
+	}
+
+	if got := halfOpen.Precedes(probe); len(got) != 1 || got[0] != ivals[0] {
+		t.Fatalf("half-open, Precedes(%v), got: %v, want: [%v]", probe, got, ivals[0])
+	}
+
+	if got := halfOpen.PrecededBy(probe); len(got) != 1 || got[0] != ivals[2] {
+		t.Fatalf("half-open, PrecededBy(%v), got: %v, want: [%v]", probe, got, ivals[2])
+	}
+
+	if halfOpen.Intersects(uintInterval{15, 20}) {
+		t.Fatal("half-open, Intersects({15 20}), got: true, want: false")
+	}
+
+	if _, err := halfOpen.InsertStrict(uintInterval{7, 7}); err == nil {
+		t.Fatal("half-open, InsertStrict({7 7}), got: nil, want: error")
+	}
+
+	if err := halfOpen.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type prioInterval struct {
+	ival uintInterval
+	prio int
+}
+
+func cmpPrioInterval(a, b prioInterval) (ll, rr, lr, rl int) {
+	return cmpUintInterval(a.ival, b.ival)
+}
+
+func TestWithTiebreak(t *testing.T) {
+	t.Parallel()
+
+	byPrio := func(a, b prioInterval) int { return b.prio - a.prio }
+	tree := interval.New(cmpPrioInterval, interval.WithTiebreak(byPrio))
+
+	tree.Insert(
+		prioInterval{uintInterval{0, 100}, 1},
+		prioInterval{uintInterval{10, 20}, 1},
+		prioInterval{uintInterval{10, 20}, 3},
+		prioInterval{uintInterval{10, 20}, 2},
+		prioInterval{uintInterval{10, 30}, 0},
+		prioInterval{uintInterval{10, 20}, 2}, // duplicate
+	)
+
+	if err := tree.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []int
+	tree.Visit(tree.Min(), tree.Max(), func(item prioInterval) bool {
+		got = append(got, item.prio)
+		return true
+	})
+
+	// supersets to the left, equal intervals by descending prio
+	if want := []int{1, 0, 3, 2, 1}; !slices.Equal(got, want) {
+		t.Fatalf("Visit with tiebreak, got prios %v, want %v", got, want)
+	}
+
+	// the probe payload must not matter for the queries
+	probe := prioInterval{uintInterval{10, 20}, 99}
+
+	if got := tree.Covers(probe); len(got) != 5 {
+		t.Fatalf("Covers with tiebreak, got %v, want 5 items", got)
+	}
+	if got := tree.CoveredBy(probe); len(got) != 3 {
+		t.Fatalf("CoveredBy with tiebreak, got %v, want 3 items", got)
+	}
+	if got, ok := tree.CoverLCP(probe); !ok || got.ival != probe.ival {
+		t.Fatalf("CoverLCP with tiebreak, got %v %v, want interval %v", got, ok, probe.ival)
+	}
+	if got, ok := tree.CoverSCP(probe); !ok || got.ival != (uintInterval{0, 100}) {
+		t.Fatalf("CoverSCP with tiebreak, got %v %v", got, ok)
+	}
+
+	if _, ok := tree.Find(probe); ok {
+		t.Fatal("Find with tiebreak, the payload is part of the identity, got true, want false")
+	}
+	if !tree.Delete(prioInterval{uintInterval{10, 20}, 3}) {
+		t.Fatal("Delete with tiebreak, got false, want true")
+	}
+	if got := tree.Covers(probe); len(got) != 4 {
+		t.Fatalf("Covers after Delete, got %v, want 4 items", got)
+	}
+}
+
+func TestWithTiebreakParallelism(t *testing.T) {
+	t.Parallel()
+
+	byPrio := func(a, b prioInterval) int { return b.prio - a.prio }
+
+	var items []prioInterval
+	for _, ival := range genUintIvals(30_000) {
+		items = append(items, prioInterval{ival, 1}, prioInterval{ival, 2})
+	}
+
+	want := interval.New(cmpPrioInterval, interval.WithTiebreak(byPrio))
+	want.Insert(prioInterval{uintInterval{0, 0}, 0})
+	want.Insert(items...)
+
+	// bulk insert into a non-empty tree, fan out
+	tree := interval.New(cmpPrioInterval, interval.WithTiebreak(byPrio), interval.WithParallelism(4))
+	tree.Insert(prioInterval{uintInterval{0, 0}, 0})
+	tree.Insert(items...)
+
+	if err := tree.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	wantSize, _, _, _ := want.Statistics()
+	gotSize, _, _, _ := tree.Statistics()
+	if gotSize != wantSize {
+		t.Fatalf("WithTiebreak and WithParallelism, got %d items, want %d", gotSize, wantSize)
+	}
+
+	var got, all []prioInterval
+	collect := func(dst *[]prioInterval) func(prioInterval) bool {
+		return func(item prioInterval) bool {
+			*dst = append(*dst, item)
+			return true
+		}
+	}
+	tree.Visit(tree.Min(), tree.Max(), collect(&got))
+	want.Visit(want.Min(), want.Max(), collect(&all))
+
+	if !slices.Equal(got, all) {
+		t.Fatal("WithTiebreak and WithParallelism differs with the sequential insert")
+	}
+}
+
+func TestNewTreeE(t *testing.T) {
+	t.Parallel()
+
+	tree, err := interval.NewTreeE(cmpUintInterval, interval.WithArena(), interval.WithSeed(42))
+	if err != nil {
+		t.Fatalf("NewTreeE(), unexpected error: %v", err)
+	}
+
+	tree.Insert(ps...)
+	if err := tree.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := interval.NewTreeE[uintInterval](nil); !errors.Is(err, interval.ErrNoCompareFunc) {
+		t.Errorf("NewTreeE(nil), got: %v, want: %v", err, interval.ErrNoCompareFunc)
+	}
+
+	invalid := []struct {
+		name string
+		opts []interval.Option
+	}{
+		{"nil option", []interval.Option{nil}},
+		{"WithHash", []interval.Option{interval.WithHash(func(string) uint64 { return 0 })}},
+		{"WithOnDelete", []interval.Option{interval.WithOnDelete(func(int) {})}},
+		{"WithTiebreak", []interval.Option{interval.WithTiebreak(func(a, b int) int { return 0 })}},
+		{"WithCodec", []interval.Option{interval.WithCodec(
+			func(string) ([]byte, error) { return nil, nil },
+			func([]byte) (string, error) { return "", nil })}},
+		{"WithSeed and WithParallelism", []interval.Option{interval.WithSeed(1), interval.WithParallelism(4)}},
+	}
+
+	for _, tt := range invalid {
+		if _, err := interval.NewTreeE(cmpUintInterval, tt.opts...); !errors.Is(err, interval.ErrInvalidOption) {
+			t.Errorf("NewTreeE(%s), got: %v, want: %v", tt.name, err, interval.ErrInvalidOption)
+		}
+	}
+
+	// New panics with the same error
+	defer func() {
+		r := recover()
+		if msg, ok := r.(string); !ok || !strings.Contains(msg, "WithHash") {
+			t.Errorf("New with mismatched hash function, got panic: %v", r)
+		}
+	}()
+
+	interval.New(cmpUintInterval, interval.WithHash(func(string) uint64 { return 0 }))
+}
@@ -0,0 +1,445 @@
+// Package ordered is a specialized interval tree for intervals with endpoints of ordered types.
+//
+// The intervals are of type [2]P with P in [cmp.Ordered], the left point at index 0
+// and the right point at index 1. Since the endpoints are ordered, the comparisons are
+// inlined by the compiler instead of being called indirectly by a compare function
+// as in the generic [interval.Tree]. For simple numeric intervals this is considerably faster.
+//
+// The algorithms are the same as in the parent package, see there for the details.
+// Unlike the other read-only structures, the package keeps its own copies of the query
+// descents instead of the shared ones: behind the node accessors the comparisons are
+// indirect calls again, CoverLCP is about ten times slower then.
+package ordered
+
+import (
+	"cmp"
+	"math/rand/v2"
+)
+
+// node is the basic recursive data structure.
+type node[P cmp.Ordered] struct {
+	// augment the treap for interval lookups, the upper bounds are stored inline
+	minUpper P // min upper value in subtree
+	maxUpper P // max upper value in subtree
+	//
+	// base treap fields
+	left  *node[P]
+	right *node[P]
+	prio  uint32 // random key for binary heap, balances the tree
+	item  [2]P
+}
+
+// Tree is the public handle, the zero value is an empty tree ready to use.
+type Tree[P cmp.Ordered] struct {
+	root *node[P]
+	size int
+}
+
+// NewTree initializes the interval tree with items.
+func NewTree[P cmp.Ordered](items ...[2]P) *Tree[P] {
+	t := new(Tree[P])
+	t.Insert(items...)
+	return t
+}
+
+// Point returns the degenerate interval {p, p}.
+func Point[P cmp.Ordered](p P) [2]P {
+	return [2]P{p, p}
+}
+
+// compare is for sorting keys into the BST, the sort key is the left point of the intervals.
+// If the left point is equal, sort the supersets to the left (definite order).
+func compare[P cmp.Ordered](a, b [2]P) int {
+	if a[0] == b[0] {
+		return cmp.Compare(b[1], a[1])
+	}
+	return cmp.Compare(a[0], b[0])
+}
+
+// covers, returns true if a covers b.
+func covers[P cmp.Ordered](a, b [2]P) bool {
+	return a[0] <= b[0] && a[1] >= b[1]
+}
+
+// intersects, returns true if a and b intersect.
+func intersects[P cmp.Ordered](a, b [2]P) bool {
+	return a[0] <= b[1] && a[1] >= b[0]
+}
+
+// recalc the augmented fields in treap node after each creation/modification with values in descendants.
+func (n *node[P]) recalc() {
+	n.minUpper = n.item[1]
+	n.maxUpper = n.item[1]
+
+	for _, c := range [2]*node[P]{n.left, n.right} {
+		if c == nil {
+			continue
+		}
+		n.minUpper = min(n.minUpper, c.minUpper)
+		n.maxUpper = max(n.maxUpper, c.maxUpper)
+	}
+}
+
+// Len returns the number of items in the tree.
+func (t *Tree[P]) Len() int {
+	return t.size
+}
+
+// Insert items into the tree, duplicates are replaced.
+func (t *Tree[P]) Insert(items ...[2]P) {
+	for _, item := range items {
+		m := &node[P]{item: item, prio: rand.Uint32()}
+		m.recalc()
+
+		var dupe bool
+		t.root, dupe = t.insert(t.root, m)
+		if !dupe {
+			t.size++
+		}
+	}
+}
+
+// insert rec-descent, returns the new subtree and true if m replaced a duplicate.
+func (t *Tree[P]) insert(n, m *node[P]) (*node[P], bool) {
+	if n == nil {
+		return m, false
+	}
+
+	// m is the new root of this subtree
+	if m.prio >= n.prio {
+		l, dupe, r := t.split(n, m.item)
+		m.left, m.right = l, r
+		m.recalc()
+		return m, dupe != nil
+	}
+
+	var dupe bool
+	switch c := compare(m.item, n.item); {
+	case c == 0:
+		// replace the item, keep the prio
+		n.item = m.item
+		dupe = true
+	case c < 0:
+		n.left, dupe = t.insert(n.left, m)
+	default:
+		n.right, dupe = t.insert(n.right, m)
+	}
+
+	n.recalc()
+	return n, dupe
+}
+
+// Delete removes an item from tree, returns true if it exists, false otherwise.
+func (t *Tree[P]) Delete(item [2]P) bool {
+	l, m, r := t.split(t.root, item)
+	t.root = t.join(l, r)
+
+	if m == nil {
+		return false
+	}
+
+	t.size--
+	return true
+}
+
+// split the treap into all nodes that compare less-than, equal
+// and greater-than the provided item (BST key).
+func (t *Tree[P]) split(n *node[P], key [2]P) (left, mid, right *node[P]) {
+	if n == nil {
+		return nil, nil, nil
+	}
+
+	switch c := compare(n.item, key); {
+	case c < 0:
+		l, m, r := t.split(n.right, key)
+		n.right = l
+		n.recalc()
+		return n, m, r
+	case c > 0:
+		l, m, r := t.split(n.left, key)
+		n.left = r
+		n.recalc()
+		return l, m, n
+	default:
+		l, r := n.left, n.right
+		n.left, n.right = nil, nil
+		n.recalc()
+		return l, n, r
+	}
+}
+
+// join combines two disjunct treaps. All nodes in treap n have keys <= that of treap m.
+func (t *Tree[P]) join(n, m *node[P]) *node[P] {
+	if n == nil {
+		return m
+	}
+	if m == nil {
+		return n
+	}
+
+	if n.prio > m.prio {
+		n.right = t.join(n.right, m)
+		n.recalc()
+		return n
+	}
+
+	m.left = t.join(n, m.left)
+	m.recalc()
+	return m
+}
+
+// Find, searches for the exact interval in the tree and returns it as well as true,
+// otherwise the zero value for item is returned and false.
+func (t *Tree[P]) Find(item [2]P) (result [2]P, ok bool) {
+	n := t.root
+	for n != nil {
+		switch c := compare(item, n.item); {
+		case c == 0:
+			return n.item, true
+		case c < 0:
+			n = n.left
+		default:
+			n = n.right
+		}
+	}
+	return
+}
+
+// Min returns the min item in tree.
+func (t *Tree[P]) Min() (min [2]P) {
+	n := t.root
+	if n == nil {
+		return
+	}
+
+	for n.left != nil {
+		n = n.left
+	}
+	return n.item
+}
+
+// Max returns the max item in tree.
+func (t *Tree[P]) Max() (max [2]P) {
+	n := t.root
+	if n == nil {
+		return
+	}
+
+	for n.right != nil {
+		n = n.right
+	}
+	return n.item
+}
+
+// CoverLCP returns the interval with the longest-common-prefix that covers the item.
+// If the item isn't covered by any interval, the zero value and false is returned.
+func (t *Tree[P]) CoverLCP(item [2]P) (result [2]P, ok bool) {
+	return lcp(t.root, item)
+}
+
+// lcp rec-descent
+func lcp[P cmp.Ordered](n *node[P], item [2]P) (result [2]P, ok bool) {
+	for {
+		if n == nil {
+			return
+		}
+
+		// fast exit, node has too small max upper value
+		if item[1] > n.maxUpper {
+			return
+		}
+
+		c := compare(n.item, item)
+		if c == 0 {
+			// equality is always the shortest containing hull
+			return n.item, true
+		}
+
+		if c < 0 {
+			break
+		}
+
+		// item too big, go left
+		n = n.left
+	}
+
+	// LCP => right backtracking
+	if result, ok = lcp(n.right, item); ok {
+		return result, ok
+	}
+
+	// not found in right subtree, try this node
+	if covers(n.item, item) {
+		return n.item, true
+	}
+
+	// left rec-descent
+	return lcp(n.left, item)
+}
+
+// CoverSCP returns the interval with the shortest-common-prefix that covers the item.
+// If the item isn't covered by any interval, the zero value and false is returned.
+func (t *Tree[P]) CoverSCP(item [2]P) (result [2]P, ok bool) {
+	return scp(t.root, item)
+}
+
+// scp rec-descent
+func scp[P cmp.Ordered](n *node[P], item [2]P) (result [2]P, ok bool) {
+	for {
+		if n == nil {
+			return
+		}
+
+		// fast exit, node has too small max upper value
+		if item[1] > n.maxUpper {
+			return
+		}
+
+		// n and the right subtree sort behind the item, go left
+		if compare(n.item, item) > 0 {
+			n = n.left
+			continue
+		}
+
+		// SCP => left backtracking
+		if result, ok = scp(n.left, item); ok {
+			return result, ok
+		}
+
+		if covers(n.item, item) {
+			return n.item, true
+		}
+
+		n = n.right
+	}
+}
+
+// Covers returns all intervals that cover the item.
+// The returned intervals are in sorted order.
+func (t *Tree[P]) Covers(item [2]P) [][2]P {
+	return coversRec(t.root, item, nil)
+}
+
+// Stab returns all intervals containing the point p, the same as Covers(Point(p)).
+// The returned intervals are in sorted order.
+func (t *Tree[P]) Stab(p P) [][2]P {
+	return coversRec(t.root, Point(p), nil)
+}
+
+// coversRec rec-descent, appends to result.
+func coversRec[P cmp.Ordered](n *node[P], item [2]P, result [][2]P) [][2]P {
+	if n == nil {
+		return result
+	}
+
+	// nope, subtree has too small upper value
+	if item[1] > n.maxUpper {
+		return result
+	}
+
+	result = coversRec(n.left, item, result)
+
+	// n and the right subtree sort behind the item
+	if compare(n.item, item) > 0 {
+		return result
+	}
+
+	if covers(n.item, item) {
+		result = append(result, n.item)
+	}
+
+	return coversRec(n.right, item, result)
+}
+
+// CoveredBy returns all intervals that are covered by item.
+// The returned intervals are in sorted order.
+func (t *Tree[P]) CoveredBy(item [2]P) [][2]P {
+	return coveredByRec(t.root, item, nil)
+}
+
+// coveredByRec rec-descent, appends to result.
+func coveredByRec[P cmp.Ordered](n *node[P], item [2]P, result [][2]P) [][2]P {
+	if n == nil {
+		return result
+	}
+
+	// nope, subtree has too big upper value
+	if item[1] < n.minUpper {
+		return result
+	}
+
+	// n and the left subtree sort before the item
+	if compare(n.item, item) < 0 {
+		return coveredByRec(n.right, item, result)
+	}
+
+	result = coveredByRec(n.left, item, result)
+
+	if covers(item, n.item) {
+		result = append(result, n.item)
+	}
+
+	return coveredByRec(n.right, item, result)
+}
+
+// Intersects returns true if any interval intersects item.
+func (t *Tree[P]) Intersects(item [2]P) bool {
+	return intersectsRec(t.root, item)
+}
+
+// intersectsRec rec-descent
+func intersectsRec[P cmp.Ordered](n *node[P], item [2]P) bool {
+	for n != nil {
+		if intersects(n.item, item) {
+			return true
+		}
+
+		// subtree has too small upper value for intersection
+		if item[0] > n.maxUpper {
+			return false
+		}
+
+		if intersectsRec(n.left, item) {
+			return true
+		}
+
+		// right subtree has too big left value for intersection
+		if item[1] < n.item[0] {
+			return false
+		}
+
+		n = n.right
+	}
+	return false
+}
+
+// Intersections returns all intervals that intersect with item.
+// The returned intervals are in sorted order.
+func (t *Tree[P]) Intersections(item [2]P) [][2]P {
+	return intersectionsRec(t.root, item, nil)
+}
+
+// intersectionsRec rec-descent, appends to result.
+func intersectionsRec[P cmp.Ordered](n *node[P], item [2]P, result [][2]P) [][2]P {
+	if n == nil {
+		return result
+	}
+
+	// subtree has too small upper value for intersection
+	if item[0] > n.maxUpper {
+		return result
+	}
+
+	result = intersectionsRec(n.left, item, result)
+
+	if intersects(n.item, item) {
+		result = append(result, n.item)
+	}
+
+	// right subtree has too big left value for intersection
+	if item[1] < n.item[0] {
+		return result
+	}
+
+	return intersectionsRec(n.right, item, result)
+}
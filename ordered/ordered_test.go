@@ -0,0 +1,140 @@
+package ordered_test
+
+import (
+	"cmp"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gaissmai/interval"
+	"github.com/gaissmai/interval/ordered"
+)
+
+func cmpIval(p, q [2]uint64) (ll, rr, lr, rl int) {
+	return cmp.Compare(p[0], q[0]),
+		cmp.Compare(p[1], q[1]),
+		cmp.Compare(p[0], q[1]),
+		cmp.Compare(p[1], q[0])
+}
+
+func genIvals(n int) [][2]uint64 {
+	is := make([][2]uint64, n)
+	for i := range is {
+		a, b := rand.Uint64()%10_000, rand.Uint64()%10_000
+		if a > b {
+			a, b = b, a
+		}
+		is[i] = [2]uint64{a, b}
+	}
+	return is
+}
+
+func TestTreeEmpty(t *testing.T) {
+	t.Parallel()
+
+	var tree ordered.Tree[uint64]
+	var zero [2]uint64
+
+	if tree.Len() != 0 {
+		t.Errorf("Len(), got: %d, want: 0", tree.Len())
+	}
+
+	if _, ok := tree.CoverLCP(zero); ok {
+		t.Errorf("CoverLCP(), got: %v, want: false", ok)
+	}
+
+	if tree.Delete(zero) {
+		t.Errorf("Delete(), got: true, want: false")
+	}
+
+	if s := tree.Intersections(zero); s != nil {
+		t.Errorf("Intersections(), got: %v, want: nil", s)
+	}
+}
+
+func TestTreeMatchesGeneric(t *testing.T) {
+	t.Parallel()
+
+	ivals := genIvals(2_000)
+
+	want := interval.NewTree(cmpIval, ivals...)
+	tree := ordered.NewTree(ivals...)
+
+	// with dupes
+	tree.Insert(ivals[:100]...)
+
+	for _, item := range ivals[:500] {
+		if tree.Delete(item) != want.Delete(item) {
+			t.Fatalf("Delete(%v) differs", item)
+		}
+	}
+
+	if size, _, _, _ := want.Statistics(); tree.Len() != size {
+		t.Fatalf("Len(), got: %d, want: %d", tree.Len(), size)
+	}
+
+	if tree.Min() != want.Min() || tree.Max() != want.Max() {
+		t.Fatalf("Min(), Max() differs")
+	}
+
+	for _, probe := range genIvals(1_000) {
+		got, gotOK := tree.CoverLCP(probe)
+		w, wOK := want.CoverLCP(probe)
+		if got != w || gotOK != wOK {
+			t.Fatalf("CoverLCP(%v), got: (%v, %v), want: (%v, %v)", probe, got, gotOK, w, wOK)
+		}
+
+		got, gotOK = tree.CoverSCP(probe)
+		w, wOK = want.CoverSCP(probe)
+		if got != w || gotOK != wOK {
+			t.Fatalf("CoverSCP(%v), got: (%v, %v), want: (%v, %v)", probe, got, gotOK, w, wOK)
+		}
+
+		got, gotOK = tree.Find(probe)
+		w, wOK = want.Find(probe)
+		if got != w || gotOK != wOK {
+			t.Fatalf("Find(%v), got: (%v, %v), want: (%v, %v)", probe, got, gotOK, w, wOK)
+		}
+
+		if tree.Intersects(probe) != want.Intersects(probe) {
+			t.Fatalf("Intersects(%v) differs", probe)
+		}
+
+		if got, w := tree.Covers(probe), want.Covers(probe); !reflect.DeepEqual(got, w) {
+			t.Fatalf("Covers(%v), got: %v, want: %v", probe, got, w)
+		}
+
+		if got, w := tree.CoveredBy(probe), want.CoveredBy(probe); !reflect.DeepEqual(got, w) {
+			t.Fatalf("CoveredBy(%v), got: %v, want: %v", probe, got, w)
+		}
+
+		if got, w := tree.Intersections(probe), want.Intersections(probe); !reflect.DeepEqual(got, w) {
+			t.Fatalf("Intersections(%v), got: %v, want: %v", probe, got, w)
+		}
+
+		point := interval.Point(probe[0])
+		if got, w := tree.Stab(probe[0]), want.Covers(point); !reflect.DeepEqual(got, w) {
+			t.Fatalf("Stab(%v), got: %v, want: %v", probe[0], got, w)
+		}
+	}
+}
+
+func BenchmarkCoverLCP(b *testing.B) {
+	ivals := genIvals(100_000)
+	probe := genIvals(1)[0]
+
+	generic := interval.NewTree(cmpIval, ivals...)
+	tree := ordered.NewTree(ivals...)
+
+	b.Run("Generic", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			_, _ = generic.CoverLCP(probe)
+		}
+	})
+
+	b.Run("Ordered", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			_, _ = tree.CoverLCP(probe)
+		}
+	})
+}
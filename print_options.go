@@ -0,0 +1,65 @@
+package interval
+
+import "fmt"
+
+// PrintOption configures the tree diagram of [Tree.Fprint].
+type PrintOption func(*printOptions)
+
+// printOptions, the collected configuration of all print options.
+type printOptions struct {
+	format      any // func(T) string, see [WithFormatter]
+	ascii       bool
+	maxDepth    int
+	maxChildren int
+}
+
+// WithFormatter, the items are formatted with fn instead of the fmt.Stringer or the default format %v.
+//
+// The type parameter must match the item type of the tree, otherwise Fprint returns an error.
+func WithFormatter[T any](fn func(T) string) PrintOption {
+	return func(o *printOptions) {
+		o.format = fn
+	}
+}
+
+// WithASCII, the tree diagram is drawn with ASCII glyphs only, for terminals without unicode.
+func WithASCII() PrintOption {
+	return func(o *printOptions) {
+		o.ascii = true
+	}
+}
+
+// WithMaxDepth, only the top levels of the hierarchy down to depth are printed, the deeper items are cut off.
+// Depth 1 prints just the top level items, useful for summarizing huge trees, depth <= 0 means unlimited.
+func WithMaxDepth(depth int) PrintOption {
+	return func(o *printOptions) {
+		o.maxDepth = depth
+	}
+}
+
+// WithMaxChildren, only the first n children of each parent are printed, the remaining
+// children and their subtrees are elided with a marker line "… (k more)".
+// Together with [WithMaxDepth] even huge trees are printed as a usable summary, n <= 0 means unlimited.
+func WithMaxChildren(n int) PrintOption {
+	return func(o *printOptions) {
+		o.maxChildren = n
+	}
+}
+
+// printFormatter, the item formatter from the options, the stringer of the tree or the default format %v.
+func printFormatter[T any](o printOptions, stringer func(T) string) (func(T) string, error) {
+	if o.format == nil && stringer != nil {
+		return stringer, nil
+	}
+
+	if o.format == nil {
+		return func(item T) string { return fmt.Sprintf("%v", item) }, nil
+	}
+
+	format, ok := o.format.(func(T) string)
+	if !ok {
+		return nil, fmt.Errorf("interval: formatter %T does not match the tree item type", o.format)
+	}
+
+	return format, nil
+}
@@ -0,0 +1,84 @@
+package interval
+
+// Prioritized is an item with the priority of its node, see [Tree.ItemsWithPriority].
+type Prioritized[T any] struct {
+	Item T
+	Prio uint32
+}
+
+// ItemsWithPriority returns all items in sorted order together with the priorities of
+// their nodes. The sorted items and the priorities determine the shape of the treap,
+// rebuilt with [NewTreeWithPriorities] or [MutTree.InsertWithPriorities] the tree has
+// the same shape, e.g. to reproduce shape-dependent performance or bugs.
+func (t Tree[T]) ItemsWithPriority() []Prioritized[T] {
+	if t.root == nil {
+		return nil
+	}
+
+	result := make([]Prioritized[T], 0, t.root.size)
+	t.traverse(t.root, inorder, 0, func(n *node[T], _ int) bool {
+		result = append(result, Prioritized[T]{Item: n.item, Prio: n.prio})
+		return true
+	})
+
+	return result
+}
+
+// NewTreeWithPriorities, initializes the interval tree with the compare function and the items
+// with the given priorities, see [NewTree] and [MutTree.InsertWithPriorities].
+func NewTreeWithPriorities[T any](cmp func(a, b T) (ll, rr, lr, rl int), items ...Prioritized[T]) *Tree[T] {
+	var t Tree[T]
+	t.cmp = checkedCmp(cmp)
+
+	t.insertWithPrioritiesInPlace(items...)
+
+	return &t
+}
+
+// InsertWithPriorities inserts the items with the given priorities, changing the original tree,
+// see [Tree.InsertWithPriority]. Duplicate items replace the previous elements.
+//
+// Into an empty tree, items in sorted order as returned by [Tree.ItemsWithPriority]
+// are inserted in O(n), otherwise in O(n log n).
+//
+// Deprecated: Tree is persistent, use [NewTreeWithPriorities] or [MutTree.InsertWithPriorities]
+// for repeated in place changes. For compatibility the receiver is still changed, the nodes shared
+// with other trees, e.g. with a [Tree.Mutable] copy, are copied first.
+func (t *Tree[T]) InsertWithPriorities(items ...Prioritized[T]) {
+	t.detach()
+	t.insertWithPrioritiesInPlace(items...)
+}
+
+// insertWithPrioritiesInPlace, see InsertWithPriorities, the nodes owned by t are changed in place.
+func (t *Tree[T]) insertWithPrioritiesInPlace(items ...Prioritized[T]) {
+	t.mustCmp(len(items))
+
+	if len(items) > 0 && t.root == nil && t.isSorted(items) {
+		sorted := make([]T, len(items))
+		prios := make([]uint32, len(items))
+		for i, p := range items {
+			sorted[i], prios[i] = p.Item, p.Prio
+		}
+
+		t.acquire()
+		t.root = t.buildSorted(sorted, prios)
+		t.changed()
+		t.inserted(sorted...)
+
+		return
+	}
+
+	for _, p := range items {
+		t.insertWithPriorityInPlace(p.Item, p.Prio)
+	}
+}
+
+// isSorted, true if the items are in strictly ascending order, without duplicates.
+func (t *Tree[T]) isSorted(items []Prioritized[T]) bool {
+	for i := 1; i < len(items); i++ {
+		if t.compare(items[i-1].Item, items[i].Item) >= 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package interval_test
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestItemsWithPriority(t *testing.T) {
+	t.Parallel()
+
+	var zero interval.Tree[uintInterval]
+	if got := zero.ItemsWithPriority(); len(got) != 0 {
+		t.Fatalf("ItemsWithPriority of empty tree, got %v", got)
+	}
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(1_000)...)
+	pairs := tree.ItemsWithPriority()
+
+	// sorted, O(n)
+	tree2 := interval.NewTreeWithPriorities(cmpUintInterval, pairs...)
+	if err := tree2.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tree.DumpBST(), tree2.DumpBST()) {
+		t.Fatal("NewTreeWithPriorities, sorted pairs, the shape differs")
+	}
+
+	// shuffled, same shape with distinct priorities
+	shuffled := append([]interval.Prioritized[uintInterval]{}, pairs...)
+	rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+	seen := make(map[uint32]bool)
+	for _, p := range pairs {
+		seen[p.Prio] = true
+	}
+
+	tree3 := interval.NewTreeWithPriorities(cmpUintInterval, shuffled...)
+	if err := tree3.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) == len(pairs) && !reflect.DeepEqual(tree.DumpBST(), tree3.DumpBST()) {
+		t.Fatal("NewTreeWithPriorities, shuffled pairs, the shape differs")
+	}
+
+	// into a non-empty tree
+	tree4 := interval.NewTree(cmpUintInterval, pairs[0].Item)
+	tree4.InsertWithPriorities(pairs...)
+	if err := tree4.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sortedItems(tree4), sortedItems(tree); !reflect.DeepEqual(got, want) {
+		t.Fatal("InsertWithPriorities into non-empty tree, items differ")
+	}
+}
@@ -0,0 +1,197 @@
+package interval
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Protocol Buffers wire format of the Snapshot message, see snapshot.proto:
+//
+//	uint32 version = 1;
+//	repeated bytes items = 2;
+//	repeated uint32 priorities = 3; // packed
+//
+// The message is encoded and decoded by hand, no dependency on the protobuf runtime is needed.
+const (
+	protoVersion = 1
+
+	protoFieldVersion    = 1
+	protoFieldItems      = 2
+	protoFieldPriorities = 3
+
+	protoWireVarint  = 0
+	protoWireFixed64 = 1
+	protoWireBytes   = 2
+	protoWireFixed32 = 5
+)
+
+// MarshalProto encodes the tree as Protocol Buffers message Snapshot, see snapshot.proto
+// in the repository, the items in sorted order with their priorities.
+// The items are encoded with the codec from [WithCodec], it is required.
+//
+// The result is a valid serialized Snapshot message, e.g. for a bytes field or an embedded
+// Snapshot field in gRPC messages. Restore the tree with [Tree.UnmarshalProto].
+func (t Tree[T]) MarshalProto() ([]byte, error) {
+	c, hasCodec, err := t.itemCodec()
+	if err != nil {
+		return nil, err
+	}
+	if !hasCodec {
+		return nil, errors.New("interval: MarshalProto, initialize the tree WithCodec")
+	}
+
+	var nodes []*node[T]
+	t.traverse(t.root, inorder, 0, func(n *node[T], _ int) bool {
+		nodes = append(nodes, n)
+		return true
+	})
+
+	buf := protoAppendTag(nil, protoFieldVersion, protoWireVarint)
+	buf = binary.AppendUvarint(buf, protoVersion)
+
+	for _, n := range nodes {
+		data, err := c.encode(n.item)
+		if err != nil {
+			return nil, fmt.Errorf("interval: encoding item %v: %w", n.item, err)
+		}
+		buf = protoAppendTag(buf, protoFieldItems, protoWireBytes)
+		buf = binary.AppendUvarint(buf, uint64(len(data)))
+		buf = append(buf, data...)
+	}
+
+	if len(nodes) > 0 {
+		var packed []byte
+		for _, n := range nodes {
+			packed = binary.AppendUvarint(packed, uint64(n.prio))
+		}
+		buf = protoAppendTag(buf, protoFieldPriorities, protoWireBytes)
+		buf = binary.AppendUvarint(buf, uint64(len(packed)))
+		buf = append(buf, packed...)
+	}
+
+	return buf, nil
+}
+
+// UnmarshalProto replaces all items in t with the Protocol Buffers message Snapshot,
+// written by [Tree.MarshalProto] or by any other protobuf implementation.
+// Unknown fields are skipped, the priorities may be packed or not.
+//
+// If the message has priorities, the tree is rebuilt in O(n) with the same shape,
+// otherwise with fresh random priorities. The tree must be initialized with the
+// compare function and the codec, e.g. with [New].
+func (t *Tree[T]) UnmarshalProto(data []byte) error {
+	if t.cmp == nil {
+		return fmt.Errorf("%w: UnmarshalProto", ErrNoCompareFunc)
+	}
+
+	c, hasCodec, err := t.itemCodec()
+	if err != nil {
+		return err
+	}
+	if !hasCodec {
+		return errors.New("interval: UnmarshalProto, initialize the tree WithCodec")
+	}
+
+	var (
+		version uint64
+		items   []T
+		prios   []uint32
+	)
+
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return fmt.Errorf("%w: malformed tag", ErrInvalidData)
+		}
+		data = data[n:]
+
+		field, wire := tag>>3, tag&7
+
+		var value uint64
+		var payload []byte
+
+		// read the value of the field, varint or length delimited, skip fixed sizes
+		switch wire {
+		case protoWireVarint:
+			if value, n = binary.Uvarint(data); n <= 0 {
+				return fmt.Errorf("%w: malformed varint", ErrInvalidData)
+			}
+			data = data[n:]
+		case protoWireBytes:
+			size, n := binary.Uvarint(data)
+			if n <= 0 || size > uint64(len(data)-n) {
+				return fmt.Errorf("%w: malformed length", ErrInvalidData)
+			}
+			payload, data = data[n:n+int(size)], data[n+int(size):]
+		case protoWireFixed64, protoWireFixed32:
+			size := 8
+			if wire == protoWireFixed32 {
+				size = 4
+			}
+			if len(data) < size {
+				return fmt.Errorf("%w: truncated field %d", ErrInvalidData, field)
+			}
+			data = data[size:]
+		default:
+			return fmt.Errorf("%w: unsupported wire type %d", ErrInvalidData, wire)
+		}
+
+		switch {
+		case field == protoFieldVersion && wire == protoWireVarint:
+			version = value
+
+		case field == protoFieldItems && wire == protoWireBytes:
+			item, err := c.decode(payload)
+			if err != nil {
+				return fmt.Errorf("%w: decoding item: %w", ErrInvalidData, err)
+			}
+			if len(items) > 0 && t.compare(items[len(items)-1], item) >= 0 {
+				return fmt.Errorf("%w: items not in sorted order", ErrInvalidData)
+			}
+			items = append(items, item)
+
+		case field == protoFieldPriorities && wire == protoWireVarint:
+			prios = append(prios, uint32(value))
+
+		case field == protoFieldPriorities && wire == protoWireBytes:
+			for len(payload) > 0 {
+				prio, n := binary.Uvarint(payload)
+				if n <= 0 {
+					return fmt.Errorf("%w: malformed priorities", ErrInvalidData)
+				}
+				prios = append(prios, uint32(prio))
+				payload = payload[n:]
+			}
+
+		case field == protoFieldVersion || field == protoFieldItems || field == protoFieldPriorities:
+			return fmt.Errorf("%w: wrong wire type %d for field %d", ErrInvalidData, wire, field)
+		}
+	}
+
+	if version != protoVersion {
+		return fmt.Errorf("%w: unsupported snapshot version %d", ErrInvalidData, version)
+	}
+
+	if len(prios) == 0 {
+		prios = make([]uint32, len(items))
+		for i := range prios {
+			prios[i] = t.randPrio()
+		}
+	}
+
+	if len(prios) != len(items) {
+		return fmt.Errorf("%w: %d priorities for %d items", ErrInvalidData, len(prios), len(items))
+	}
+
+	t.acquire()
+	t.root = t.buildSorted(items, prios)
+	t.changed()
+
+	return nil
+}
+
+// protoAppendTag, appends the field number and the wire type.
+func protoAppendTag(buf []byte, field, wire uint64) []byte {
+	return binary.AppendUvarint(buf, field<<3|wire)
+}
@@ -0,0 +1,137 @@
+package interval_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestMarshalProto(t *testing.T) {
+	t.Parallel()
+
+	// FprintBST without the pointers
+	shape := func(tree *interval.Tree[uintInterval]) string {
+		w := new(strings.Builder)
+		_ = tree.FprintBST(w)
+		return regexp.MustCompile(`\[0x.*\]`).ReplaceAllString(w.String(), "")
+	}
+
+	withCodec := interval.WithCodec(encodeUintIval, decodeUintIval)
+
+	tree := interval.New(cmpUintInterval, withCodec)
+	tree.Insert(genUintIvals(1_000)...)
+
+	data, err := tree.MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := interval.New(cmpUintInterval, withCodec)
+	got.Insert(ps...)
+
+	if err := got.UnmarshalProto(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if shape(tree) != shape(got) {
+		t.Fatal("UnmarshalProto(MarshalProto()), tree shapes differ")
+	}
+
+	if err := got.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := got.UnmarshalProto(data[:len(data)-1]); !errors.Is(err, interval.ErrInvalidData) {
+		t.Fatalf("UnmarshalProto(truncated), got: %v, want: ErrInvalidData", err)
+	}
+
+	// without codec
+	if _, err := interval.NewTree(cmpUintInterval, ps...).MarshalProto(); err == nil {
+		t.Fatal("MarshalProto() without codec, expected error")
+	}
+
+	// empty tree
+	if data, err = interval.New(cmpUintInterval, withCodec).MarshalProto(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := got.UnmarshalProto(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.String() != "" {
+		t.Fatalf("UnmarshalProto(empty), got: %s, want: empty tree", got)
+	}
+}
+
+func TestUnmarshalProtoForeign(t *testing.T) {
+	t.Parallel()
+
+	appendField := func(buf []byte, field, wire uint64, value []byte) []byte {
+		buf = binary.AppendUvarint(buf, field<<3|wire)
+		if wire == 2 {
+			buf = binary.AppendUvarint(buf, uint64(len(value)))
+		}
+		return append(buf, value...)
+	}
+
+	uvarint := func(v uint64) []byte { return binary.AppendUvarint(nil, v) }
+
+	items := []uintInterval{{0, 100}, {3, 13}, {7, 9}}
+
+	// priorities not packed, unknown fields and the version at the end
+	var msg []byte
+	for i, item := range items {
+		data, _ := encodeUintIval(item)
+		msg = appendField(msg, 2, 2, data)
+		msg = appendField(msg, 3, 0, uvarint(uint64(100-i)))
+	}
+	msg = appendField(msg, 9, 0, uvarint(42))
+	msg = appendField(msg, 10, 5, []byte{1, 2, 3, 4})
+	msg = appendField(msg, 11, 2, []byte("unknown"))
+	msg = appendField(msg, 1, 0, uvarint(1))
+
+	tree := interval.New(cmpUintInterval, interval.WithCodec(encodeUintIval, decodeUintIval))
+
+	if err := tree.UnmarshalProto(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tree.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := tree.CoveredBy(uintInterval{0, 100}); !slices.Equal(got, items) {
+		t.Fatalf("UnmarshalProto(), got: %v, want: %v", got, items)
+	}
+
+	// no priorities, fresh random priorities
+	msg = appendField(nil, 1, 0, uvarint(1))
+	for _, item := range items {
+		data, _ := encodeUintIval(item)
+		msg = appendField(msg, 2, 2, data)
+	}
+
+	if err := tree.UnmarshalProto(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := tree.CoveredBy(uintInterval{0, 100}); !slices.Equal(got, items) {
+		t.Fatalf("UnmarshalProto() without priorities, got: %v, want: %v", got, items)
+	}
+
+	// unsupported version, too few priorities
+	for _, bad := range [][]byte{
+		appendField(slices.Clone(msg), 1, 0, uvarint(2)),
+		appendField(slices.Clone(msg), 3, 0, uvarint(7)),
+	} {
+		if err := tree.UnmarshalProto(bad); !errors.Is(err, interval.ErrInvalidData) {
+			t.Errorf("UnmarshalProto(bad), got: %v, want: ErrInvalidData", err)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package interval
+
+import (
+	"cmp"
+	"slices"
+)
+
+// CoversK returns the k innermost intervals that cover the item, the most specific first,
+// e.g. the two most specific matching rules. The traversal stops as soon as k intervals are found.
+//
+// The result is the tail of [Tree.Covers] in reverse order, CoversK(item, 1) is [Tree.CoverLCP].
+func (t Tree[T]) CoversK(item T, k int) (result []T) {
+	if t.metrics() != nil {
+		report := t.observe("CoversK")
+		defer func() { report(len(result)) }()
+	}
+
+	if k <= 0 {
+		return nil
+	}
+
+	return t.coversK(t.root, item, k, make([]T, 0, min(k, 8)))
+}
+
+// coversK rec-descent, reverse in-order with early exit.
+func (t *Tree[T]) coversK(n *node[T], item T, k int, result []T) []T {
+	if n == nil || len(result) == k {
+		return result
+	}
+
+	// nope, subtree has too small upper interval value
+	if t.cmpRR(item, n.maxUpperItem()) > 0 {
+		return result
+	}
+
+	// n and the right subtree sort behind the item, can't cover it, go left
+	if t.compareIval(n.item, item) > 0 {
+		return t.coversK(n.left, item, k, result)
+	}
+
+	// innermost first, right subtree
+	result = t.coversK(n.right, item, k, result)
+
+	// this n.item
+	if len(result) < k && t.cmpCovers(n.item, item) {
+		result = append(result, n.item)
+	}
+
+	return t.coversK(n.left, item, k, result)
+}
+
+// IntersectionsByOverlap returns all intervals that intersect with item, sorted by the size
+// of the overlap with item, the largest overlap first. Equal overlaps remain in sorted order.
+//
+// The width callback measures the distance from the left point of left to the right point of right,
+// as for [CoveredWidth], e.g. for intervals of integers:
+//
+//	width := func(left, right ival) uint64 { return right.hi - left.lo }
+func (t Tree[T]) IntersectionsByOverlap(item T, width func(left, right T) uint64) []T {
+	type ranked struct {
+		item    T
+		overlap uint64
+	}
+
+	hits := t.Intersections(item)
+
+	rs := make([]ranked, len(hits))
+	for i, hit := range hits {
+		rs[i] = ranked{hit, t.overlap(hit, item, width)}
+	}
+
+	slices.SortStableFunc(rs, func(a, b ranked) int {
+		return cmp.Compare(b.overlap, a.overlap)
+	})
+
+	for i := range rs {
+		hits[i] = rs[i].item
+	}
+	return hits
+}
+
+// Overlap returns the size of the intersection of a and b, 0 if they don't intersect.
+// The overlapping sub-range is found with the compare function of the tree, width measures it:
+// the distance from the left point of lo to the right point of hi, see [Tree.IntersectionsByOverlap].
+// Neither a nor b must be in the tree.
+func (t Tree[T]) Overlap(a, b T, width func(lo, hi T) uint64) uint64 {
+	if t.cmp == nil {
+		return 0
+	}
+	return t.overlap(a, b, width)
+}
+
+// overlap, see Overlap.
+func (t *Tree[T]) overlap(a, b T, width func(left, right T) uint64) uint64 {
+	if !t.cmpIntersects(a, b) {
+		return 0
+	}
+
+	ll, rr, _, _ := t.cmp(a, b)
+
+	// the intersection spans from the greater left point to the smaller right point
+	left, right := a, a
+	if ll < 0 {
+		left = b
+	}
+	if rr > 0 {
+		right = b
+	}
+	return width(left, right)
+}
+
+// FirstIntersection returns the leftmost interval in sorted order that intersects with item,
+// the first element of [Tree.Intersections] in O(log n), without the whole result slice.
+func (t Tree[T]) FirstIntersection(item T) (result T, ok bool) {
+	if t.metrics() != nil {
+		report := t.observe("FirstIntersection")
+		defer func() { report(count(ok)) }()
+	}
+
+	return t.firstIntersection(t.root, item)
+}
+
+// firstIntersection rec-descent
+func (t *Tree[T]) firstIntersection(n *node[T], item T) (result T, ok bool) {
+	for n != nil {
+		// subtree has too small upper value for intersection
+		if t.cmpLR(item, n.maxUpperItem()) > 0 {
+			return
+		}
+
+		// leftmost first
+		if result, ok = t.firstIntersection(n.left, item); ok {
+			return
+		}
+
+		if t.cmpIntersects(n.item, item) {
+			return n.item, true
+		}
+
+		// n and the right subtree have too big left points for intersection
+		if t.cmpRL(item, n.item) < 0 {
+			return
+		}
+
+		n = n.right
+	}
+	return
+}
+
+// LastIntersection returns the rightmost interval in sorted order that intersects with item,
+// the last element of [Tree.Intersections], without the whole result slice.
+func (t Tree[T]) LastIntersection(item T) (result T, ok bool) {
+	if t.metrics() != nil {
+		report := t.observe("LastIntersection")
+		defer func() { report(count(ok)) }()
+	}
+
+	return t.lastIntersection(t.root, item)
+}
+
+// lastIntersection rec-descent
+func (t *Tree[T]) lastIntersection(n *node[T], item T) (result T, ok bool) {
+	for n != nil {
+		// subtree has too small upper value for intersection
+		if t.cmpLR(item, n.maxUpperItem()) > 0 {
+			return
+		}
+
+		// n and the right subtree have too big left points for intersection, go left
+		if t.cmpRL(item, n.item) < 0 {
+			n = n.left
+			continue
+		}
+
+		// rightmost first
+		if result, ok = t.lastIntersection(n.right, item); ok {
+			return
+		}
+
+		if t.cmpIntersects(n.item, item) {
+			return n.item, true
+		}
+
+		n = n.left
+	}
+	return
+}
+
+// IsCovered returns true if any interval covers the item, like [Tree.CoverSCP] but without
+// identifying the interval, the traversal stops at the first covering interval.
+func (t Tree[T]) IsCovered(item T) (ok bool) {
+	if t.metrics() != nil {
+		report := t.observe("IsCovered")
+		defer func() { report(count(ok)) }()
+	}
+
+	return t.isCovered(t.root, item)
+}
+
+// isCovered rec-descent
+func (t *Tree[T]) isCovered(n *node[T], item T) bool {
+	for n != nil {
+		// subtree has too small upper interval value
+		if t.cmpRR(item, n.maxUpperItem()) > 0 {
+			return false
+		}
+
+		// n and the right subtree sort behind the item, can't cover it, go left
+		if t.compareIval(n.item, item) > 0 {
+			n = n.left
+			continue
+		}
+
+		// fast exit
+		if t.cmpCovers(n.item, item) || t.isCovered(n.left, item) {
+			return true
+		}
+
+		n = n.right
+	}
+	return false
+}
+
+// HasSubset returns true if any interval is covered by the item, like [Tree.CoveredBy] but without
+// the result slice, the traversal stops at the first covered interval.
+func (t Tree[T]) HasSubset(item T) (ok bool) {
+	if t.metrics() != nil {
+		report := t.observe("HasSubset")
+		defer func() { report(count(ok)) }()
+	}
+
+	return t.hasSubset(t.root, item)
+}
+
+// hasSubset rec-descent
+func (t *Tree[T]) hasSubset(n *node[T], item T) bool {
+	for n != nil {
+		// subtree has too big upper interval value
+		if augmentMinUpper && t.cmpRR(item, n.minUpperItem()) < 0 {
+			return false
+		}
+
+		// n and the left subtree sort before the item, can't be covered, go right
+		if t.compareIval(n.item, item) < 0 {
+			n = n.right
+			continue
+		}
+
+		// fast exit
+		if t.cmpCovers(item, n.item) || t.hasSubset(n.left, item) {
+			return true
+		}
+
+		n = n.right
+	}
+	return false
+}
+
+// NearestPrecedes returns the interval that ends closest before the item, the preceding interval
+// with the greatest right point, e.g. the gap to the previous allocation. If many intervals
+// end at the same point, one of them is returned.
+//
+// Subtrees are pruned with the augmented upper values, a subtree ending completely before
+// the item is answered by its max upper value in O(1).
+func (t Tree[T]) NearestPrecedes(item T) (result T, ok bool) {
+	if t.metrics() != nil {
+		report := t.observe("NearestPrecedes")
+		defer func() { report(count(ok)) }()
+	}
+
+	return t.nearestPrecedes(t.root, item, result, false)
+}
+
+// nearestPrecedes rec-descent, branch and bound with the best candidate so far.
+func (t *Tree[T]) nearestPrecedes(n *node[T], item T, best T, ok bool) (T, bool) {
+	if n == nil {
+		return best, ok
+	}
+
+	// nope, all intervals in this subtree end too late
+	if augmentMinUpper && t.cmpLR(item, n.minUpperItem()) <= 0 {
+		return best, ok
+	}
+
+	maxUpper := n.maxUpperItem()
+
+	// no better candidate in this subtree
+	if ok && t.cmpRR(maxUpper, best) <= 0 {
+		return best, ok
+	}
+
+	// all intervals in this subtree precede the item, the max upper value is the best
+	if t.cmpLR(item, maxUpper) > 0 {
+		return maxUpper, true
+	}
+
+	// this n.item
+	if t.cmpLR(item, n.item) > 0 && (!ok || t.cmpRR(n.item, best) > 0) {
+		best, ok = n.item, true
+	}
+
+	// the right subtree has the greater left points, try it first for a good bound
+	best, ok = t.nearestPrecedes(n.right, item, best, ok)
+	return t.nearestPrecedes(n.left, item, best, ok)
+}
+
+// NearestPrecededBy returns the interval that starts soonest after the item, the first
+// interval of [Tree.PrecededBy], in O(log n).
+func (t Tree[T]) NearestPrecededBy(item T) (result T, ok bool) {
+	if t.metrics() != nil {
+		report := t.observe("NearestPrecededBy")
+		defer func() { report(count(ok)) }()
+	}
+
+	for n := t.root; n != nil; {
+		// n and the left subtree intersect or precede the item, only the right subtree is left
+		if t.cmpRL(item, n.item) >= 0 {
+			n = n.right
+			continue
+		}
+
+		// n is preceded by the item, look for a smaller one in the left subtree
+		result, ok = n.item, true
+		n = n.left
+	}
+
+	return result, ok
+}
+
+// CountBetween returns the number of items with the left point in the range [start, stop],
+// only the left points of start and stop are considered. The count is calculated in O(log n)
+// with the subtree sizes, e.g. for histograms of large trees by key range.
+func (t Tree[T]) CountBetween(start, stop T) (n int) {
+	if t.metrics() != nil {
+		report := t.observe("CountBetween")
+		defer func() { report(n) }()
+	}
+
+	n = t.countLess(stop, true) - t.countLess(start, false)
+	return max(n, 0)
+}
+
+// countLess, the number of items with the left point less than (or equal to) the left point of item.
+func (t *Tree[T]) countLess(item T, orEqual bool) (count int) {
+	for n := t.root; n != nil; {
+		ll := t.cmpLL(n.item, item)
+		if ll < 0 || orEqual && ll == 0 {
+			// n and the left subtree
+			count++
+			if n.left != nil {
+				count += n.left.size
+			}
+			n = n.right
+			continue
+		}
+		n = n.left
+	}
+	return count
+}
@@ -0,0 +1,217 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestCoversK(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+
+	for _, probe := range genUintIvals(100) {
+		covers := tree.Covers(probe)
+		slices.Reverse(covers)
+
+		for _, k := range []int{0, 1, 2, 5, len(covers) + 1} {
+			want := covers[:min(k, len(covers))]
+			got := tree.CoversK(probe, k)
+			if !slices.Equal(got, want) {
+				t.Fatalf("CoversK(%v, %d), got %v, want %v", probe, k, got, want)
+			}
+		}
+
+		lcp, ok := tree.CoverLCP(probe)
+		if got := tree.CoversK(probe, 1); ok != (len(got) == 1) || ok && got[0] != lcp {
+			t.Fatalf("CoversK(%v, 1), got %v, want CoverLCP %v", probe, got, lcp)
+		}
+	}
+}
+
+func TestIntersectionsByOverlap(t *testing.T) {
+	t.Parallel()
+
+	width := func(left, right uintInterval) uint64 {
+		return uint64(right[1] - left[0])
+	}
+
+	tree := interval.NewTree(cmpUintInterval,
+		uintInterval{0, 12},  // overlap 2
+		uintInterval{8, 30},  // overlap 10
+		uintInterval{12, 15}, // overlap 3
+		uintInterval{14, 17}, // overlap 3
+		uintInterval{19, 40}, // overlap 1
+		uintInterval{21, 40}, // no intersection
+	)
+
+	got := tree.IntersectionsByOverlap(uintInterval{10, 20}, width)
+	want := []uintInterval{{8, 30}, {12, 15}, {14, 17}, {0, 12}, {19, 40}}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("IntersectionsByOverlap, got %v, want %v", got, want)
+	}
+}
+
+func TestOverlap(t *testing.T) {
+	t.Parallel()
+
+	width := func(lo, hi uintInterval) uint64 {
+		return uint64(hi[1] - lo[0])
+	}
+
+	tree := interval.New(cmpUintInterval)
+
+	tests := []struct {
+		a, b uintInterval
+		want uint64
+	}{
+		{uintInterval{0, 10}, uintInterval{5, 20}, 5},
+		{uintInterval{5, 20}, uintInterval{0, 10}, 5},
+		{uintInterval{0, 100}, uintInterval{40, 60}, 20},
+		{uintInterval{40, 60}, uintInterval{0, 100}, 20},
+		{uintInterval{0, 10}, uintInterval{0, 10}, 10},
+		{uintInterval{0, 10}, uintInterval{11, 20}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := tree.Overlap(tt.a, tt.b, width); got != tt.want {
+			t.Errorf("Overlap(%v, %v), got %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFirstLastIntersection(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+
+	for _, probe := range genUintIvals(200) {
+		all := tree.Intersections(probe)
+
+		first, ok := tree.FirstIntersection(probe)
+		if ok != (len(all) > 0) || ok && first != all[0] {
+			t.Fatalf("FirstIntersection(%v), got %v %v", probe, first, ok)
+		}
+
+		last, ok := tree.LastIntersection(probe)
+		if ok != (len(all) > 0) || ok && last != all[len(all)-1] {
+			t.Fatalf("LastIntersection(%v), got %v %v", probe, last, ok)
+		}
+	}
+
+	if _, ok := interval.New(cmpUintInterval).FirstIntersection(uintInterval{1, 2}); ok {
+		t.Fatal("FirstIntersection on empty tree, got true, want false")
+	}
+}
+
+func TestIsCoveredHasSubset(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+
+	for _, probe := range genUintIvals(200) {
+		if got, want := tree.IsCovered(probe), len(tree.Covers(probe)) > 0; got != want {
+			t.Fatalf("IsCovered(%v), got %v, want %v", probe, got, want)
+		}
+		if got, want := tree.HasSubset(probe), len(tree.CoveredBy(probe)) > 0; got != want {
+			t.Fatalf("HasSubset(%v), got %v, want %v", probe, got, want)
+		}
+	}
+
+	tree = interval.NewTree(cmpUintInterval, ps...)
+	for _, probe := range ps {
+		if !tree.IsCovered(probe) || !tree.HasSubset(probe) {
+			t.Fatalf("IsCovered/HasSubset(%v) of tree item, got false, want true", probe)
+		}
+	}
+}
+
+func TestNearestPrecedes(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+
+	for _, probe := range genUintIvals(200) {
+		precedes := tree.Precedes(probe)
+
+		got, ok := tree.NearestPrecedes(probe)
+		if ok != (len(precedes) > 0) {
+			t.Fatalf("NearestPrecedes(%v), got ok %v, want %v", probe, ok, !ok)
+		}
+		if !ok {
+			continue
+		}
+
+		// the max right point of all preceding intervals
+		want := precedes[0]
+		for _, p := range precedes[1:] {
+			if p[1] > want[1] {
+				want = p
+			}
+		}
+
+		if got[1] != want[1] || !slices.Contains(precedes, got) {
+			t.Fatalf("NearestPrecedes(%v), got %v, want %v", probe, got, want)
+		}
+	}
+}
+
+func TestNearestPrecededBy(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+
+	for _, probe := range genUintIvals(200) {
+		precededBy := tree.PrecededBy(probe)
+
+		got, ok := tree.NearestPrecededBy(probe)
+		if ok != (len(precededBy) > 0) {
+			t.Fatalf("NearestPrecededBy(%v), got ok %v, want %v", probe, ok, !ok)
+		}
+		if ok && got != precededBy[0] {
+			t.Fatalf("NearestPrecededBy(%v), got %v, want %v", probe, got, precededBy[0])
+		}
+	}
+}
+
+func TestCountBetween(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+	for _, item := range genUintIvals(1_000) {
+		tree.Delete(item)
+	}
+
+	if err := tree.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	var all []uintInterval
+	tree.Visit(tree.Min(), tree.Max(), func(item uintInterval) bool {
+		all = append(all, item)
+		return true
+	})
+
+	probes := genUintIvals(100)
+	for i := 0; i < len(probes)-1; i++ {
+		start, stop := probes[i], probes[i+1]
+
+		want := 0
+		for _, item := range all {
+			if item[0] >= start[0] && item[0] <= stop[0] {
+				want++
+			}
+		}
+
+		if got := tree.CountBetween(start, stop); got != want {
+			t.Fatalf("CountBetween(%v, %v), got %d, want %d", start, stop, got, want)
+		}
+	}
+
+	if got := tree.CountBetween(tree.Min(), tree.Max()); got != len(all) {
+		t.Fatalf("CountBetween(Min, Max), got %d, want %d", got, len(all))
+	}
+}
@@ -0,0 +1,144 @@
+// Package rcu packages the read-copy-update pattern of router and firewall tables
+// into safe primitives: lock-free readers, serialized writers publishing new versions
+// of the tree and a grace period before the nodes of old versions are recycled.
+//
+// Readers pin the current version for the duration of a lookup, writers derive the
+// next version from a copy-on-write clone of the current one:
+//
+//	table := rcu.New(interval.New(cmpRoute, interval.WithArena()))
+//
+//	// reader, e.g. per packet
+//	snap := table.Reader()
+//	route, ok := snap.Tree().CoverLCP(dst)
+//	snap.Release()
+//
+//	// writer, e.g. per routing update
+//	table.Publish(func(t interval.Tree[Route]) interval.Tree[Route] {
+//		return *t.InsertImmutable(route)
+//	})
+//
+// A retired version is recycled after its grace period, when all readers pinning it
+// have released their snapshots. For trees with [interval.WithArena] the nodes only
+// used by the retired version are returned to the arena, see [interval.Tree.Recycle],
+// and reused by the following writes. Without an arena they are left to the garbage collector.
+package rcu
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gaissmai/interval"
+)
+
+// Table holds the current version of a tree for concurrent readers and writers.
+type Table[T any] struct {
+	cur atomic.Pointer[version[T]]
+
+	mu      sync.Mutex    // serializes the writers
+	retired []*version[T] // predecessors of the current version, oldest first
+}
+
+// version is a published tree and the number of readers pinning it.
+type version[T any] struct {
+	tree    *interval.Tree[T]
+	readers atomic.Int64
+}
+
+// Snapshot is a version of the tree pinned by a reader, see [Table.Reader].
+type Snapshot[T any] struct {
+	v *version[T]
+}
+
+// New returns a table with t as the current version. t must not be nil
+// and must not be modified anymore, the table takes it over.
+func New[T any](t *interval.Tree[T]) *Table[T] {
+	tb := new(Table[T])
+	tb.cur.Store(&version[T]{tree: t.Clone()})
+	return tb
+}
+
+// Reader pins the current version of the tree, lock-free. The snapshot must be
+// released with [Snapshot.Release] exactly once, as soon as the lookups are done,
+// a pinned version delays the recycling of all later retired versions.
+func (tb *Table[T]) Reader() Snapshot[T] {
+	for {
+		v := tb.cur.Load()
+		v.readers.Add(1)
+
+		// still current, the writer sees the reader before the grace period ends
+		if tb.cur.Load() == v {
+			return Snapshot[T]{v}
+		}
+		v.readers.Add(-1)
+	}
+}
+
+// Tree returns the pinned tree, treat it as read-only. Neither the tree nor any
+// item pointer into it may be used after [Snapshot.Release].
+func (s Snapshot[T]) Tree() *interval.Tree[T] {
+	return s.v.tree
+}
+
+// Release unpins the version, the snapshot must not be used anymore.
+func (s Snapshot[T]) Release() {
+	s.v.readers.Add(-1)
+}
+
+// Publish publishes the tree returned by f as the new version. Writers are serialized,
+// f is called exactly once with a copy-on-write clone of the current version, it may
+// be modified in place. The new version must be derived from this clone, not from
+// other trees sharing nodes with it.
+//
+// The previous version is retired, retired versions past their grace period are recycled.
+func (tb *Table[T]) Publish(f func(interval.Tree[T]) interval.Tree[T]) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	old := tb.cur.Load()
+	next := f(*old.tree.Clone())
+
+	// the published tree owns no nodes, Clone doesn't change it
+	tb.cur.Store(&version[T]{tree: next.Clone()})
+	tb.retired = append(tb.retired, old)
+
+	tb.reclaim()
+}
+
+// Synchronize waits for the grace period of all retired versions, until all readers
+// pinning them have released their snapshots, and recycles them. Writers are blocked meanwhile.
+func (tb *Table[T]) Synchronize() {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	for backoff := time.Microsecond; ; backoff = min(2*backoff, time.Millisecond) {
+		if tb.reclaim(); len(tb.retired) == 0 {
+			return
+		}
+		time.Sleep(backoff)
+	}
+}
+
+// Pending returns the number of retired versions still waiting for their grace period.
+func (tb *Table[T]) Pending() int {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	return len(tb.retired)
+}
+
+// reclaim, recycles the retired versions without readers, oldest first. A node of a retired
+// version may be shared with all older versions, a version is recycled only after its predecessors.
+func (tb *Table[T]) reclaim() {
+	i := 0
+	for ; i < len(tb.retired) && tb.retired[i].readers.Load() == 0; i++ {
+		successor := tb.cur.Load()
+		if i+1 < len(tb.retired) {
+			successor = tb.retired[i+1]
+		}
+		successor.tree.Recycle(tb.retired[i].tree)
+	}
+
+	clear(tb.retired[:i])
+	tb.retired = tb.retired[i:]
+}
@@ -0,0 +1,127 @@
+package rcu_test
+
+import (
+	"cmp"
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/gaissmai/interval"
+	"github.com/gaissmai/interval/rcu"
+)
+
+func cmpIval(p, q [2]uint64) (ll, rr, lr, rl int) {
+	return cmp.Compare(p[0], q[0]),
+		cmp.Compare(p[1], q[1]),
+		cmp.Compare(p[0], q[1]),
+		cmp.Compare(p[1], q[0])
+}
+
+func genIvals(n int) [][2]uint64 {
+	is := make([][2]uint64, n)
+	for i := range is {
+		a, b := rand.Uint64()%10_000, rand.Uint64()%10_000
+		is[i] = [2]uint64{min(a, b), max(a, b)}
+	}
+	return is
+}
+
+func items(t *interval.Tree[[2]uint64]) (result [][2]uint64) {
+	t.Visit(t.Min(), t.Max(), func(item [2]uint64) bool {
+		result = append(result, item)
+		return true
+	})
+	return
+}
+
+func TestGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.New(cmpIval, interval.WithArena())
+	tree.Insert(genIvals(1_000)...)
+	table := rcu.New(tree)
+
+	snap := table.Reader()
+	want := items(snap.Tree())
+
+	for _, item := range genIvals(100) {
+		table.Publish(func(t interval.Tree[[2]uint64]) interval.Tree[[2]uint64] {
+			t.Insert(item)
+			t.Delete(t.Min())
+			return t
+		})
+	}
+
+	// the pinned snapshot blocks the recycling of all versions
+	if got := table.Pending(); got != 100 {
+		t.Fatalf("Pending, got %d, want 100", got)
+	}
+
+	if err := snap.Tree().CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+	if got := items(snap.Tree()); !reflect.DeepEqual(got, want) {
+		t.Fatal("pinned snapshot changed by the writers")
+	}
+
+	snap.Release()
+	table.Synchronize()
+
+	if got := table.Pending(); got != 0 {
+		t.Fatalf("Pending after Synchronize, got %d, want 0", got)
+	}
+
+	snap = table.Reader()
+	defer snap.Release()
+
+	if err := snap.Tree().CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.New(cmpIval, interval.WithArena())
+	tree.Insert(genIvals(1_000)...)
+	table := rcu.New(tree)
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				snap := table.Reader()
+				if err := snap.Tree().CheckInvariants(); err != nil {
+					t.Error(err)
+				}
+				snap.Release()
+			}
+		}()
+	}
+
+	for _, item := range genIvals(500) {
+		table.Publish(func(t interval.Tree[[2]uint64]) interval.Tree[[2]uint64] {
+			t.Insert(item)
+			return t
+		})
+	}
+
+	close(done)
+	wg.Wait()
+
+	table.Synchronize()
+	if got := table.Pending(); got != 0 {
+		t.Fatalf("Pending after Synchronize, got %d, want 0", got)
+	}
+}
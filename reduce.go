@@ -0,0 +1,89 @@
+package interval
+
+import "slices"
+
+// Maximals returns a new tree with the intervals not covered by any other interval in the tree,
+// e.g. to collapse redundant ACL entries or to summarize a dataset. Equal intervals, see [WithTiebreak],
+// are reduced to the first one. The new tree has the same configuration, the tree is unchanged.
+func (t Tree[T]) Maximals() *Tree[T] {
+	var result []T
+
+	// in sorted order the supersets come first, an interval is covered by a predecessor
+	// if any predecessor reaches as far to the right
+	var maxUpper T
+	t.traverse(t.root, inorder, 0, func(n *node[T], _ int) bool {
+		if len(result) == 0 || t.cmpRR(n.item, maxUpper) > 0 {
+			result = append(result, n.item)
+			maxUpper = n.item
+		}
+		return true
+	})
+
+	return t.derive(result)
+}
+
+// Minimals returns a new tree with the intervals that cover no other interval in the tree, the most
+// specific intervals, the dual of [Tree.Maximals]. Equal intervals, see [WithTiebreak], are reduced
+// to the last one. The new tree has the same configuration, the tree is unchanged.
+func (t Tree[T]) Minimals() *Tree[T] {
+	var result []T
+
+	// in reverse sorted order the subsets come first, an interval covers a successor
+	// if any successor ends not after it
+	var minUpper T
+	t.traverse(t.root, reverse, 0, func(n *node[T], _ int) bool {
+		if len(result) == 0 || t.cmpRR(n.item, minUpper) < 0 {
+			result = append(result, n.item)
+			minUpper = n.item
+		}
+		return true
+	})
+
+	slices.Reverse(result)
+	return t.derive(result)
+}
+
+// Compact returns a new tree with the same items and configuration, rebuilt in O(n) with new
+// priorities from the sorted items into one contiguous allocation. The tree is unchanged.
+//
+// Long-lived trees with heavy copy-on-write churn are scattered over the heap and may keep
+// nodes alive through the structural sharing with discarded versions, the compacted tree shares
+// nothing. As with [WithArena], the allocation is not garbage collected as long as any node
+// in it is still alive.
+func (t Tree[T]) Compact() *Tree[T] {
+	var items []T
+	if t.root != nil {
+		items = make([]T, 0, t.root.size)
+	}
+
+	t.traverse(t.root, inorder, 0, func(n *node[T], _ int) bool {
+		items = append(items, n.item)
+		return true
+	})
+
+	// all nodes from one chunk, later inserts allocate as configured
+	shared := t.arena
+	t.arena = &arena[T]{chunk: make([]node[T], len(items))}
+
+	c := t.derive(items)
+	c.arena = shared
+
+	return c
+}
+
+// derive, a new tree with the configuration of t from the items in sorted order, in O(n).
+func (t Tree[T]) derive(items []T) *Tree[T] {
+	// owns no nodes, the new nodes get a new owner
+	t.owner = 0
+	t.acquire()
+
+	prios := make([]uint32, len(items))
+	for i := range prios {
+		prios[i] = t.randPrio()
+	}
+
+	t.root = t.buildSorted(items, prios)
+	t.changed()
+
+	return &t
+}
@@ -0,0 +1,114 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+// sortedItems, all items of the tree in sorted order.
+func sortedItems(tree *interval.Tree[uintInterval]) (items []uintInterval) {
+	tree.Visit(tree.Min(), tree.Max(), func(item uintInterval) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+// bruteReduce, the items a without any other item b for which drop(a, b) is true.
+func bruteReduce(all []uintInterval, drop func(a, b uintInterval) bool) (result []uintInterval) {
+	for i, a := range all {
+		dropped := false
+		for j, b := range all {
+			if i != j && drop(a, b) {
+				dropped = true
+				break
+			}
+		}
+		if !dropped {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+func TestMaximals(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(1_000)...)
+	maximals := tree.Maximals()
+
+	if err := maximals.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	all := sortedItems(tree)
+
+	// a is covered by b
+	want := bruteReduce(all, func(a, b uintInterval) bool { return b[0] <= a[0] && b[1] >= a[1] })
+
+	if got := sortedItems(maximals); !slices.Equal(got, want) {
+		t.Fatalf("Maximals, got %d items, want %d", len(got), len(want))
+	}
+
+	// the tree is unchanged
+	if got := sortedItems(tree); !slices.Equal(got, all) {
+		t.Fatalf("Maximals changed the tree")
+	}
+}
+
+func TestMinimals(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(1_000)...)
+	minimals := tree.Minimals()
+
+	if err := minimals.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	all := sortedItems(tree)
+
+	// a covers b
+	want := bruteReduce(all, func(a, b uintInterval) bool { return a[0] <= b[0] && a[1] >= b[1] })
+
+	if got := sortedItems(minimals); !slices.Equal(got, want) {
+		t.Fatalf("Minimals, got %d items, want %d", len(got), len(want))
+	}
+
+	// the tree is unchanged
+	if got := sortedItems(tree); !slices.Equal(got, all) {
+		t.Fatalf("Minimals changed the tree")
+	}
+}
+
+func TestCompact(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+	for _, item := range genUintIvals(1_000) {
+		tree = tree.InsertImmutable(item)
+	}
+	all := sortedItems(tree)
+
+	compact := tree.Compact()
+	if err := compact.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := sortedItems(compact); !slices.Equal(got, all) {
+		t.Fatalf("Compact, got %d items, want %d", len(got), len(all))
+	}
+
+	// the trees share no nodes
+	compact.Insert(genUintIvals(100)...)
+	if got := sortedItems(tree); !slices.Equal(got, all) {
+		t.Fatalf("Compact, changes of the compacted tree changed the tree")
+	}
+
+	var zero interval.Tree[uintInterval]
+	if got := zero.Compact(); got.Min() != (uintInterval{}) {
+		t.Fatalf("Compact of zero tree, got %v", got)
+	}
+}
@@ -0,0 +1,87 @@
+package interval
+
+// NewTreeOfPointers initializes the interval tree with pointer items, for large structs with
+// a payload. The nodes and the query results then hold just the pointers instead of copies
+// of the full items and the compare function is derived from accessor functions reading
+// the endpoints through the pointers, see [NewTreeFromLowerUpper]:
+//
+//	type route struct {
+//		from, to netip.Addr
+//		attrs    [64]byte
+//		...
+//	}
+//
+//	tree := interval.NewTreeOfPointers(
+//		func(r *route) netip.Addr { return r.from },
+//		func(r *route) netip.Addr { return r.to },
+//		netip.Addr.Compare,
+//		routes...)
+//
+// The items must not be changed through the pointers while they are in the tree,
+// at least not the endpoints, the tree would be corrupted.
+func NewTreeOfPointers[P, T any](lower, upper func(item *T) P, cmpPoint func(a, b P) int, items ...*T) *Tree[*T] {
+	return NewTree[*T](cmpFromLowerUpper(lower, upper, cmpPoint), items...)
+}
+
+// ItemsRef returns pointers to all items in the nodes in sorted order, the items are not copied.
+// For trees with large value items, see also [NewTreeOfPointers].
+//
+// The items must not be changed through the pointers, the nodes may be shared with clones
+// and immutable versions. After changes of the tree in place the pointers may refer to
+// stale copies.
+func (t Tree[T]) ItemsRef() []*T {
+	var size int
+	if t.root != nil {
+		size = t.root.size
+	}
+
+	result := make([]*T, 0, size)
+	t.traverse(t.root, inorder, 0, func(n *node[T], _ int) bool {
+		result = append(result, &n.item)
+		return true
+	})
+	return result
+}
+
+// IntersectionsRef is like [Tree.Intersections], but returns pointers to the items in the nodes,
+// see [Tree.ItemsRef].
+func (t Tree[T]) IntersectionsRef(item T) (result []*T) {
+	if t.metrics() != nil {
+		report := t.observe("IntersectionsRef")
+		defer func() { report(len(result)) }()
+	}
+
+	t.intersectionsFn(t.root, item, func(hit *T) bool {
+		result = append(result, hit)
+		return true
+	})
+	return result
+}
+
+// CoversRef is like [Tree.Covers], but returns pointers to the items in the nodes,
+// see [Tree.ItemsRef].
+func (t Tree[T]) CoversRef(item T) (result []*T) {
+	if t.metrics() != nil {
+		report := t.observe("CoversRef")
+		defer func() { report(len(result)) }()
+	}
+
+	t.covers(t.root, item, func(hit *T) {
+		result = append(result, hit)
+	})
+	return result
+}
+
+// CoveredByRef is like [Tree.CoveredBy], but returns pointers to the items in the nodes,
+// see [Tree.ItemsRef].
+func (t Tree[T]) CoveredByRef(item T) (result []*T) {
+	if t.metrics() != nil {
+		report := t.observe("CoveredByRef")
+		defer func() { report(len(result)) }()
+	}
+
+	t.coveredBy(t.root, item, func(hit *T) {
+		result = append(result, hit)
+	})
+	return result
+}
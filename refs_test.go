@@ -0,0 +1,87 @@
+package interval_test
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+type bigItem struct {
+	lo, hi  uint
+	payload [256]byte
+}
+
+func TestNewTreeOfPointers(t *testing.T) {
+	t.Parallel()
+
+	items := make([]*bigItem, len(ps))
+	for i, p := range ps {
+		items[i] = &bigItem{lo: p[0], hi: p[1]}
+		items[i].payload[0] = byte(i)
+	}
+
+	tree := interval.NewTreeOfPointers(
+		func(b *bigItem) uint { return b.lo },
+		func(b *bigItem) uint { return b.hi },
+		cmp.Compare[uint],
+		items...)
+
+	if err := tree.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := interval.NewTree(cmpUintInterval, ps...)
+	probe := uintInterval{3, 5}
+
+	var got []uintInterval
+	for _, b := range tree.Covers(&bigItem{lo: probe[0], hi: probe[1]}) {
+		got = append(got, uintInterval{b.lo, b.hi})
+	}
+
+	if !slices.Equal(got, want.Covers(probe)) {
+		t.Fatalf("Covers(), got: %v, want: %v", got, want.Covers(probe))
+	}
+}
+
+func TestItemsRef(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, ps...)
+	probe := uintInterval{3, 5}
+
+	deref := func(refs []*uintInterval) (items []uintInterval) {
+		for _, r := range refs {
+			items = append(items, *r)
+		}
+		return items
+	}
+
+	tests := []struct {
+		name string
+		got  []*uintInterval
+		want []uintInterval
+	}{
+		{"ItemsRef", tree.ItemsRef(), sortedItems(tree)},
+		{"IntersectionsRef", tree.IntersectionsRef(probe), tree.Intersections(probe)},
+		{"CoversRef", tree.CoversRef(probe), tree.Covers(probe)},
+		{"CoveredByRef", tree.CoveredByRef(uintInterval{1, 8}), tree.CoveredBy(uintInterval{1, 8})},
+	}
+
+	for _, tt := range tests {
+		if got := deref(tt.got); !slices.Equal(got, tt.want) {
+			t.Errorf("%s(), got: %v, want: %v", tt.name, got, tt.want)
+		}
+	}
+
+	// the pointers refer to the items in the nodes, not to copies
+	if a, b := tree.CoversRef(probe), tree.ItemsRef(); a[0] != b[0] {
+		t.Errorf("CoversRef() and ItemsRef(), pointers differ: %p, %p", a[0], b[0])
+	}
+
+	var empty interval.Tree[uintInterval]
+	if refs := empty.ItemsRef(); len(refs) != 0 {
+		t.Errorf("ItemsRef() of empty tree, got: %v", refs)
+	}
+}
@@ -0,0 +1,155 @@
+package interval
+
+// CoveredWidth returns the width of the union of all intervals in the tree, clipped to the window.
+//
+// Overlapping intervals are coalesced first, every point is measured at most once.
+// The width callback measures the distance from the left point of left to the right point of right,
+// left and right are intervals from the tree or the window itself, e.g. for time intervals:
+//
+//	width := func(left, right period) float64 { return float64(right.end.Sub(left.start)) }
+func CoveredWidth[T any](t *Tree[T], window T, width func(left, right T) float64) float64 {
+	hits := t.Intersections(window)
+	if len(hits) == 0 {
+		return 0
+	}
+
+	var covered float64
+
+	// measure the coalesced run from the left point of first to the right point of last
+	measure := func(first, last T) {
+		if ll, _, _, _ := t.cmp(first, window); ll < 0 {
+			first = window
+		}
+		if rr := t.cmpRR(last, window); rr > 0 {
+			last = window
+		}
+		covered += width(first, last)
+	}
+
+	// hits are sorted by the left point
+	first, last := hits[0], hits[0]
+	for _, item := range hits[1:] {
+		// item starts behind the run, close the run
+		if t.cmpLR(item, last) > 0 {
+			measure(first, last)
+			first, last = item, item
+			continue
+		}
+
+		// extend the run
+		if t.cmpRR(item, last) > 0 {
+			last = item
+		}
+	}
+	measure(first, last)
+
+	return covered
+}
+
+// Uptime returns the percentage of the window not covered by the downtime intervals in the tree,
+// the SLA availability for the reporting window. See [CoveredWidth] for the width callback.
+// A window with zero width has 100% uptime.
+func Uptime[T any](downtimes *Tree[T], window T, width func(left, right T) float64) float64 {
+	total := width(window, window)
+	if total <= 0 {
+		return 100
+	}
+
+	down := CoveredWidth(downtimes, window, width)
+	return 100 * (total - down) / total
+}
+
+// IsFullyCovered reports whether the item is completely covered by the union of the intervals in the tree,
+// not necessarily by a single interval, e.g. "is every address in this block assigned?".
+//
+// Intervals that don't overlap but abut without a gap, e.g. [1,4] and [5,8] for integers, are joined if
+// adjacent(a, b) reports true, a precedes b. A nil adjacent joins only overlapping intervals.
+func (t Tree[T]) IsFullyCovered(item T, adjacent func(a, b T) bool) bool {
+	hits := t.Intersections(item)
+	if len(hits) == 0 {
+		return false
+	}
+
+	// hits are sorted by the left point, the first must start at or before the item
+	if t.cmpLL(hits[0], item) > 0 {
+		return false
+	}
+
+	last := hits[0]
+	for _, next := range hits[1:] {
+		if t.cmpRR(last, item) >= 0 {
+			return true
+		}
+
+		// gap between the run and next
+		if !t.contiguous(last, next) && (adjacent == nil || !adjacent(last, next)) {
+			return false
+		}
+
+		// extend the run
+		if t.cmpRR(next, last) > 0 {
+			last = next
+		}
+	}
+
+	return t.cmpRR(last, item) >= 0
+}
+
+// UncoveredWithin returns the sub-ranges of the item not covered by any interval in the tree,
+// sorted and disjoint, the gap analysis scoped to one region, e.g. the free ranges of an address block.
+//
+// The gaps are built by mk, lo and hi are the bounds of the gap. A bound that is the item itself is
+// inclusive, the gap starts at the left point or ends at the right point of the item. A bound that is
+// an interval from the tree is exclusive, the gap starts after its right point or ends before its left point.
+// If the item is equal to an interval from the tree there are no gaps, mk can simply distinguish the bounds:
+//
+//	mk := func(lo, hi ival) ival {
+//		from, to := lo[1]+1, hi[0]-1
+//		if lo == probe {
+//			from = lo[0]
+//		}
+//		if hi == probe {
+//			to = hi[1]
+//		}
+//		return ival{from, to}
+//	}
+//
+// Gaps built with the left point greater than the right point, e.g. between adjacent integer
+// intervals, are dropped.
+func (t Tree[T]) UncoveredWithin(item T, mk func(lo, hi T) T) []T {
+	hits := t.Intersections(item)
+	if len(hits) == 0 {
+		return []T{mk(item, item)}
+	}
+
+	var result []T
+	gap := func(lo, hi T) {
+		if g := mk(lo, hi); t.cmpValid(g) {
+			result = append(result, g)
+		}
+	}
+
+	// leading gap
+	if t.cmpLL(hits[0], item) > 0 {
+		gap(item, hits[0])
+	}
+
+	last := hits[0]
+	for _, next := range hits[1:] {
+		if t.cmpLR(next, last) > 0 {
+			gap(last, next)
+		}
+
+		// extend the run
+		if t.cmpRR(next, last) > 0 {
+			last = next
+		}
+	}
+
+	// trailing gap
+	if t.cmpRR(last, item) < 0 {
+		gap(last, item)
+	}
+
+	return result
+}
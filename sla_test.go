@@ -0,0 +1,148 @@
+package interval_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestUptime(t *testing.T) {
+	t.Parallel()
+
+	width := func(left, right uintInterval) float64 {
+		return float64(right[1]) - float64(left[0])
+	}
+
+	downtimes := interval.New(cmpUintInterval, interval.WithHalfOpen())
+	downtimes.Insert(
+		uintInterval{0, 20},    // clipped to 10...20
+		uintInterval{30, 40},   // coalesced with the next two
+		uintInterval{35, 45},   //
+		uintInterval{36, 38},   //
+		uintInterval{50, 55},   //
+		uintInterval{55, 60},   // meets the previous, half-open
+		uintInterval{100, 120}, // clipped to 100...110
+		uintInterval{200, 300}, // outside
+	)
+
+	window := uintInterval{10, 110}
+
+	// 10 + 15 + 10 + 10
+	if got := interval.CoveredWidth(downtimes, window, width); got != 45 {
+		t.Fatalf("CoveredWidth, got %v, want 45", got)
+	}
+
+	if got := interval.Uptime(downtimes, window, width); math.Abs(got-55) > 1e-9 {
+		t.Fatalf("Uptime, got %v, want 55", got)
+	}
+
+	if got := interval.Uptime(downtimes, uintInterval{60, 100}, width); got != 100 {
+		t.Fatalf("Uptime in clean window, got %v, want 100", got)
+	}
+}
+
+func TestIsFullyCovered(t *testing.T) {
+	t.Parallel()
+
+	adjacent := func(a, b uintInterval) bool { return a[1]+1 == b[0] }
+
+	tree := interval.NewTree(cmpUintInterval,
+		uintInterval{0, 10},
+		uintInterval{5, 20},
+		uintInterval{21, 30},
+		uintInterval{40, 50},
+	)
+
+	tests := []struct {
+		item     uintInterval
+		adjacent func(a, b uintInterval) bool
+		want     bool
+	}{
+		{uintInterval{2, 18}, nil, true},       // overlapping intervals
+		{uintInterval{2, 25}, nil, false},      // 20 and 21 abut, not joined
+		{uintInterval{2, 25}, adjacent, true},  // joined
+		{uintInterval{0, 30}, adjacent, true},  // exact
+		{uintInterval{0, 31}, adjacent, false}, // 31 not covered
+		{uintInterval{25, 45}, adjacent, false},
+		{uintInterval{42, 42}, nil, true},
+		{uintInterval{60, 70}, adjacent, false},
+	}
+
+	for _, tt := range tests {
+		if got := tree.IsFullyCovered(tt.item, tt.adjacent); got != tt.want {
+			t.Errorf("IsFullyCovered(%v), got %v, want %v", tt.item, got, tt.want)
+		}
+	}
+
+	var zero interval.Tree[uintInterval]
+	if zero.IsFullyCovered(uintInterval{1, 2}, nil) {
+		t.Errorf("IsFullyCovered on zero tree, got true")
+	}
+
+	// half-open intervals that meet are contiguous
+	halfOpen := interval.New(cmpUintInterval, interval.WithHalfOpen())
+	halfOpen.Insert(uintInterval{0, 5}, uintInterval{5, 10}, uintInterval{11, 20})
+
+	for _, tt := range []struct {
+		item uintInterval
+		want bool
+	}{
+		{uintInterval{0, 10}, true},
+		{uintInterval{2, 8}, true},
+		{uintInterval{0, 12}, false}, // [10,11) not covered
+		{uintInterval{12, 20}, true},
+	} {
+		if got := halfOpen.IsFullyCovered(tt.item, nil); got != tt.want {
+			t.Errorf("half-open, IsFullyCovered(%v), got %v, want %v", tt.item, got, tt.want)
+		}
+
+		mk := func(lo, hi uintInterval) uintInterval { return uintInterval{lo[1], hi[0]} }
+		if gaps := halfOpen.UncoveredWithin(tt.item, mk); (len(gaps) == 0) != tt.want {
+			t.Errorf("half-open, UncoveredWithin(%v), got %v, inconsistent with IsFullyCovered", tt.item, gaps)
+		}
+	}
+}
+
+func TestUncoveredWithin(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval,
+		uintInterval{0, 10},
+		uintInterval{5, 20},
+		uintInterval{21, 30}, // adjacent, no gap
+		uintInterval{40, 50},
+		uintInterval{42, 45},
+		uintInterval{60, 70},
+	)
+
+	tests := []struct {
+		item uintInterval
+		want []uintInterval
+	}{
+		{uintInterval{0, 30}, nil},
+		{uintInterval{0, 100}, []uintInterval{{31, 39}, {51, 59}, {71, 100}}},
+		{uintInterval{25, 65}, []uintInterval{{31, 39}, {51, 59}}},
+		{uintInterval{35, 55}, []uintInterval{{35, 39}, {51, 55}}},
+		{uintInterval{80, 90}, []uintInterval{{80, 90}}},
+		{uintInterval{40, 50}, nil},
+	}
+
+	for _, tt := range tests {
+		mk := func(lo, hi uintInterval) uintInterval {
+			from, to := lo[1]+1, hi[0]-1
+			if lo == tt.item {
+				from = lo[0]
+			}
+			if hi == tt.item {
+				to = hi[1]
+			}
+			return uintInterval{from, to}
+		}
+
+		if got := tree.UncoveredWithin(tt.item, mk); !slices.Equal(got, tt.want) {
+			t.Errorf("UncoveredWithin(%v), got %v, want %v", tt.item, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package interval
+
+import "github.com/gaissmai/interval/internal/query"
+
+// nilIdx, the index of a missing child in the slab.
+const nilIdx int32 = -1
+
+// slabNode, the node in the slab, children are indices instead of pointers.
+type slabNode[T any] struct {
+	left     int32 // index of left child or nilIdx
+	right    int32 // index of right child or nilIdx
+	minUpper int32 // index of node in subtree with min upper value
+	maxUpper int32 // index of node in subtree with max upper value
+	item     T
+}
+
+// Slab is a read-only copy of the treap with all nodes in one contiguous slice
+// and int32 indices as child links instead of pointers.
+//
+// The nodes are stored in preorder, a parent is always followed by its left child.
+// This improves the cache locality for read-heavy workloads and shrinks the per-node overhead
+// on 64-bit platforms. Mutations are done on the [Tree], create a new Slab afterwards.
+type Slab[T any] struct {
+	nodes []slabNode[T]
+	tree  Tree[T] // for the compare functions, without nodes
+	query query.Query[int32, T]
+}
+
+// Slab returns a read-only, index-based copy of the tree, the tree shape is preserved.
+func (t Tree[T]) Slab() *Slab[T] {
+	s := &Slab[T]{tree: t}
+	s.tree.root = nil
+
+	if t.root != nil {
+		s.nodes = make([]slabNode[T], 0, t.root.size)
+		s.build(t.root)
+	}
+
+	s.query = query.Query[int32, T]{Nodes: slabNodes[T](s.nodes), Cmp: s.tree.cmp, Order: s.tree.compare}
+	return s
+}
+
+// build rec-descent, append the nodes in preorder, returns the index of n.
+func (s *Slab[T]) build(n *node[T]) int32 {
+	if n == nil {
+		return nilIdx
+	}
+
+	i := int32(len(s.nodes))
+	s.nodes = append(s.nodes, slabNode[T]{item: n.item})
+
+	l := s.build(n.left)
+	r := s.build(n.right)
+
+	sn := &s.nodes[i]
+	sn.left, sn.right = l, r
+	s.recalc(i)
+
+	return i
+}
+
+// recalc the augmented indices in slab node i with values in descendants.
+func (s *Slab[T]) recalc(i int32) {
+	sn := &s.nodes[i]
+
+	// start with upper min/max pointing to self
+	sn.minUpper = i
+	sn.maxUpper = i
+
+	for _, c := range [2]int32{sn.right, sn.left} {
+		if c == nilIdx {
+			continue
+		}
+
+		if s.tree.cmpRR(s.nodes[sn.minUpper].item, s.nodes[s.nodes[c].minUpper].item) > 0 {
+			sn.minUpper = s.nodes[c].minUpper
+		}
+
+		if s.tree.cmpRR(s.nodes[sn.maxUpper].item, s.nodes[s.nodes[c].maxUpper].item) < 0 {
+			sn.maxUpper = s.nodes[c].maxUpper
+		}
+	}
+}
+
+// root index, nilIdx for an empty slab.
+func (s *Slab[T]) root() int32 {
+	if len(s.nodes) == 0 {
+		return nilIdx
+	}
+	return 0
+}
+
+// slabNodes, the read access to the slab nodes for the query algorithms.
+type slabNodes[T any] []slabNode[T]
+
+func (ns slabNodes[T]) IsNil(i int32) bool  { return i == nilIdx }
+func (ns slabNodes[T]) Left(i int32) int32  { return ns[i].left }
+func (ns slabNodes[T]) Right(i int32) int32 { return ns[i].right }
+func (ns slabNodes[T]) Item(i int32) T      { return ns[i].item }
+func (ns slabNodes[T]) MinUpper(i int32) T  { return ns[ns[i].minUpper].item }
+func (ns slabNodes[T]) MaxUpper(i int32) T  { return ns[ns[i].maxUpper].item }
+
+// Len returns the number of items in the slab.
+func (s *Slab[T]) Len() int {
+	return len(s.nodes)
+}
+
+// Find, see [Tree.Find].
+func (s *Slab[T]) Find(item T) (result T, ok bool) {
+	return s.query.Find(s.root(), item)
+}
+
+// CoverLCP, see [Tree.CoverLCP].
+func (s *Slab[T]) CoverLCP(item T) (result T, ok bool) {
+	return s.query.CoverLCP(s.root(), item)
+}
+
+// CoverSCP, see [Tree.CoverSCP].
+func (s *Slab[T]) CoverSCP(item T) (result T, ok bool) {
+	return s.query.CoverSCP(s.root(), item)
+}
+
+// Covers, see [Tree.Covers].
+func (s *Slab[T]) Covers(item T) []T {
+	return s.query.Covers(s.root(), item, nil)
+}
+
+// CoveredBy, see [Tree.CoveredBy].
+func (s *Slab[T]) CoveredBy(item T) []T {
+	return s.query.CoveredBy(s.root(), item, nil)
+}
+
+// Intersects, see [Tree.Intersects].
+func (s *Slab[T]) Intersects(item T) bool {
+	return s.query.Intersects(s.root(), item)
+}
+
+// Intersections, see [Tree.Intersections].
+func (s *Slab[T]) Intersections(item T) []T {
+	return s.query.Intersections(s.root(), item, nil)
+}
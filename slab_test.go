@@ -0,0 +1,87 @@
+package interval_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestSlabEmpty(t *testing.T) {
+	t.Parallel()
+
+	var zeroItem uintInterval
+	slab := interval.NewTree(cmpUintInterval).Slab()
+
+	if slab.Len() != 0 {
+		t.Errorf("Len(), got: %d, want: 0", slab.Len())
+	}
+
+	if _, ok := slab.Find(zeroItem); ok {
+		t.Errorf("Find(), got: %v, want: false", ok)
+	}
+
+	if _, ok := slab.CoverLCP(zeroItem); ok {
+		t.Errorf("CoverLCP(), got: %v, want: false", ok)
+	}
+
+	if _, ok := slab.CoverSCP(zeroItem); ok {
+		t.Errorf("CoverSCP(), got: %v, want: false", ok)
+	}
+
+	if s := slab.Intersections(zeroItem); s != nil {
+		t.Errorf("Intersections(), got: %v, want: nil", s)
+	}
+}
+
+func TestSlabMatchesTree(t *testing.T) {
+	t.Parallel()
+
+	tree1 := interval.NewTree(cmpUintInterval, ps...)
+	tree1.Insert(gen2UintIvals(1_000)...)
+
+	slab := tree1.Slab()
+
+	if size, _, _, _ := tree1.Statistics(); slab.Len() != size {
+		t.Fatalf("Len(), got: %d, want: %d", slab.Len(), size)
+	}
+
+	probes := append(gen2UintIvals(100), ps...)
+	probes = append(probes, uintInterval{3, 5}, uintInterval{0, 9}, uintInterval{7, 7})
+
+	for _, probe := range probes {
+		if got, want := slab.Intersects(probe), tree1.Intersects(probe); got != want {
+			t.Fatalf("Intersects(%v), got: %v, want: %v", probe, got, want)
+		}
+
+		got, gotOK := slab.Find(probe)
+		want, wantOK := tree1.Find(probe)
+		if got != want || gotOK != wantOK {
+			t.Fatalf("Find(%v), got: (%v, %v), want: (%v, %v)", probe, got, gotOK, want, wantOK)
+		}
+
+		got, gotOK = slab.CoverLCP(probe)
+		want, wantOK = tree1.CoverLCP(probe)
+		if got != want || gotOK != wantOK {
+			t.Fatalf("CoverLCP(%v), got: (%v, %v), want: (%v, %v)", probe, got, gotOK, want, wantOK)
+		}
+
+		got, gotOK = slab.CoverSCP(probe)
+		want, wantOK = tree1.CoverSCP(probe)
+		if got != want || gotOK != wantOK {
+			t.Fatalf("CoverSCP(%v), got: (%v, %v), want: (%v, %v)", probe, got, gotOK, want, wantOK)
+		}
+
+		if got, want := slab.Covers(probe), tree1.Covers(probe); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Covers(%v), got: %v, want: %v", probe, got, want)
+		}
+
+		if got, want := slab.CoveredBy(probe), tree1.CoveredBy(probe); !reflect.DeepEqual(got, want) {
+			t.Fatalf("CoveredBy(%v), got: %v, want: %v", probe, got, want)
+		}
+
+		if got, want := slab.Intersections(probe), tree1.Intersections(probe); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Intersections(%v), got: %v, want: %v", probe, got, want)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package interval
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// snapshot format, all counts and lengths as uvarint:
+//
+//	header:     magic "IVS", version byte, encoding byte, number of items
+//	priorities: priorities of all items, uint32 little endian, in sorted item order
+//	items:      items in sorted order, gob stream or for each item: length, bytes
+//
+// encoding byte, 0: items as gob stream, 1: length prefixed items from the codec
+//
+// Since version 2 each section is followed by its CRC-32 (Castagnoli), uint32 little endian.
+// Version 1 snapshots without checksums are still loaded.
+//
+// The priorities are the heap keys of the treap, together with the sorted items the
+// tree is rebuilt in O(n) with the same shape.
+const (
+	snapshotMagic   = "IVS"
+	snapshotVersion = 2
+)
+
+// ErrChecksum is returned by [Tree.Load] if a section of the snapshot is corrupted,
+// the error wraps [ErrInvalidData] as well.
+var ErrChecksum = errors.New("interval: snapshot checksum mismatch")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// crcWriter, sums up all bytes written.
+type crcWriter struct {
+	w   *bufio.Writer
+	crc uint32
+}
+
+func (cw *crcWriter) Write(p []byte) (int, error) {
+	cw.crc = crc32.Update(cw.crc, crcTable, p)
+	return cw.w.Write(p)
+}
+
+// section, writes the checksum of the section and resets it.
+func (cw *crcWriter) section() {
+	cw.w.Write(binary.LittleEndian.AppendUint32(nil, cw.crc))
+	cw.crc = 0
+}
+
+// crcReader, sums up all bytes read, implements io.ByteReader for the gob decoder,
+// otherwise the decoder reads ahead.
+type crcReader struct {
+	r   *bufio.Reader
+	crc uint32
+}
+
+func (cr *crcReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.crc = crc32.Update(cr.crc, crcTable, p[:n])
+	return n, err
+}
+
+func (cr *crcReader) ReadByte() (byte, error) {
+	b, err := cr.r.ReadByte()
+	if err == nil {
+		cr.crc = crc32.Update(cr.crc, crcTable, []byte{b})
+	}
+	return b, err
+}
+
+// section, verifies the checksum of the section and resets it.
+func (cr *crcReader) section(name string) error {
+	var buf [4]byte
+	if _, err := io.ReadFull(cr.r, buf[:]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidData, err)
+	}
+	if binary.LittleEndian.Uint32(buf[:]) != cr.crc {
+		return fmt.Errorf("%w: %w: %s section", ErrInvalidData, ErrChecksum, name)
+	}
+	cr.crc = 0
+	return nil
+}
+
+// Store writes a versioned snapshot of the tree to w, the items in sorted order
+// with their priorities. The items are encoded with the codec from [WithCodec] if given, with gob otherwise.
+//
+// Restore the tree with [Tree.Load].
+func (t Tree[T]) Store(w io.Writer) error {
+	c, hasCodec, err := t.itemCodec()
+	if err != nil {
+		return err
+	}
+
+	var nodes []*node[T]
+	t.traverse(t.root, inorder, 0, func(n *node[T], _ int) bool {
+		nodes = append(nodes, n)
+		return true
+	})
+
+	bw := bufio.NewWriter(w)
+	cw := &crcWriter{w: bw}
+
+	cw.Write([]byte(snapshotMagic))
+	if hasCodec {
+		cw.Write([]byte{snapshotVersion, encodingCodec})
+	} else {
+		cw.Write([]byte{snapshotVersion, encodingGob})
+	}
+
+	var buf [binary.MaxVarintLen64]byte
+	cw.Write(binary.AppendUvarint(buf[:0], uint64(len(nodes))))
+	cw.section()
+
+	for _, n := range nodes {
+		cw.Write(binary.LittleEndian.AppendUint32(buf[:0], n.prio))
+	}
+	cw.section()
+
+	if hasCodec {
+		for _, n := range nodes {
+			data, err := c.encode(n.item)
+			if err != nil {
+				return fmt.Errorf("interval: encoding item %v: %w", n.item, err)
+			}
+			cw.Write(binary.AppendUvarint(buf[:0], uint64(len(data))))
+			cw.Write(data)
+		}
+	} else {
+		enc := gob.NewEncoder(cw)
+		for _, n := range nodes {
+			if err := enc.Encode(n.item); err != nil {
+				return fmt.Errorf("interval: gob encoding item %v: %w", n.item, err)
+			}
+		}
+	}
+	cw.section()
+
+	return bw.Flush()
+}
+
+// Load replaces all items in t with the snapshot read from r, written by [Tree.Store].
+// The tree is rebuilt in O(n) with the same shape as the stored tree.
+//
+// The checksums of all sections are verified before the tree is rebuilt, a corrupted
+// snapshot returns an error wrapping [ErrChecksum] and t is left unchanged.
+//
+// The tree must be initialized with the compare function and the same codec as the
+// stored tree, e.g. with [New], the zero value can't be used.
+func (t *Tree[T]) Load(r io.Reader) error {
+	if t.cmp == nil {
+		return fmt.Errorf("%w: Load", ErrNoCompareFunc)
+	}
+
+	c, hasCodec, err := t.itemCodec()
+	if err != nil {
+		return err
+	}
+
+	br := &crcReader{r: bufio.NewReader(r)}
+
+	var header [len(snapshotMagic) + 2]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidData, err)
+	}
+
+	if string(header[:len(snapshotMagic)]) != snapshotMagic {
+		return ErrInvalidData
+	}
+
+	version := header[len(snapshotMagic)]
+	if version != 1 && version != snapshotVersion {
+		return fmt.Errorf("%w: unsupported snapshot version %d", ErrInvalidData, version)
+	}
+
+	// verify the checksum of the section, version 1 has no checksums
+	section := func(name string) error {
+		if version == 1 {
+			return nil
+		}
+		return br.section(name)
+	}
+
+	encoding := header[len(snapshotMagic)+1]
+	switch {
+	case encoding == encodingCodec && !hasCodec:
+		return errors.New("interval: snapshot is encoded with a codec, initialize the tree WithCodec")
+	case encoding != encodingCodec && encoding != encodingGob:
+		return fmt.Errorf("%w: unknown encoding %d", ErrInvalidData, encoding)
+	}
+
+	count, err := binary.ReadUvarint(br)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidData, err)
+	}
+
+	if err := section("header"); err != nil {
+		return err
+	}
+
+	// don't trust count for huge allocations, the slices grow on demand
+	capacity := min(count, 1<<16)
+
+	prios := make([]uint32, 0, capacity)
+	var buf [4]byte
+	for i := uint64(0); i < count; i++ {
+		if _, err := io.ReadFull(br, buf[:]); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidData, err)
+		}
+		prios = append(prios, binary.LittleEndian.Uint32(buf[:]))
+	}
+
+	if err := section("priorities"); err != nil {
+		return err
+	}
+
+	items := make([]T, 0, capacity)
+	dec := gob.NewDecoder(br)
+
+	for i := uint64(0); i < count; i++ {
+		var item T
+
+		if encoding == encodingGob {
+			if err := dec.Decode(&item); err != nil {
+				return fmt.Errorf("%w: gob decoding item: %w", ErrInvalidData, err)
+			}
+		} else {
+			size, err := binary.ReadUvarint(br)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidData, err)
+			}
+
+			// don't trust size for huge allocations
+			data, err := io.ReadAll(io.LimitReader(br, int64(min(size, 1<<62))))
+			if err != nil || uint64(len(data)) != size {
+				return fmt.Errorf("%w: truncated item", ErrInvalidData)
+			}
+
+			if item, err = c.decode(data); err != nil {
+				return fmt.Errorf("%w: decoding item: %w", ErrInvalidData, err)
+			}
+		}
+
+		if len(items) > 0 && t.compare(items[len(items)-1], item) >= 0 {
+			return fmt.Errorf("%w: items not in sorted order", ErrInvalidData)
+		}
+		items = append(items, item)
+	}
+
+	// the tree is built only from verified sections
+	if err := section("items"); err != nil {
+		return err
+	}
+
+	t.acquire()
+	t.root = t.buildSorted(items, prios)
+	t.changed()
+
+	return nil
+}
+
+// buildSorted, builds the treap from the sorted items and the priorities in O(n),
+// the right spine of the partial tree is kept on a stack (Cartesian tree).
+func (t *Tree[T]) buildSorted(items []T, prios []uint32) *node[T] {
+	var spine []*node[T]
+
+	for i, item := range items {
+		n := t.makeNodeWithPriority(item, prios[i])
+
+		// pop the lower priorities from the right spine, they become the left subtree of n
+		var last *node[T]
+		for len(spine) > 0 && spine[len(spine)-1].prio < n.prio {
+			last = spine[len(spine)-1]
+			spine = spine[:len(spine)-1]
+
+			// subtree of last is complete
+			t.recalc(last)
+		}
+		n.left = last
+
+		if len(spine) > 0 {
+			spine[len(spine)-1].right = n
+		}
+		spine = append(spine, n)
+	}
+
+	if len(spine) == 0 {
+		return nil
+	}
+
+	// complete the remaining right spine bottom-up
+	for i := len(spine) - 1; i >= 0; i-- {
+		t.recalc(spine[i])
+	}
+
+	return spine[0]
+}
@@ -0,0 +1,126 @@
+package interval_test
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestStoreLoad(t *testing.T) {
+	t.Parallel()
+
+	// FprintBST without the pointers
+	shape := func(tree *interval.Tree[uintInterval]) string {
+		w := new(strings.Builder)
+		_ = tree.FprintBST(w)
+		return regexp.MustCompile(`\[0x.*\]`).ReplaceAllString(w.String(), "")
+	}
+
+	for _, opt := range []interval.Option{
+		interval.WithSeed(42),
+		interval.WithCodec(encodeUintIval, decodeUintIval),
+	} {
+		tree := interval.New(cmpUintInterval, opt)
+		tree.Insert(genUintIvals(1_000)...)
+
+		var buf bytes.Buffer
+		if err := tree.Store(&buf); err != nil {
+			t.Fatal(err)
+		}
+		data := buf.Bytes()
+
+		got := interval.New(cmpUintInterval, opt)
+		got.Insert(ps...)
+
+		if err := got.Load(bytes.NewReader(data)); err != nil {
+			t.Fatal(err)
+		}
+
+		if shape(tree) != shape(got) {
+			t.Fatal("Load(Store()), tree shapes differ")
+		}
+
+		if err := got.CheckInvariants(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := got.Load(bytes.NewReader(data[:len(data)-1])); !errors.Is(err, interval.ErrInvalidData) {
+			t.Fatalf("Load(truncated), got: %v, want: ErrInvalidData", err)
+		}
+	}
+
+	// empty tree
+	var buf bytes.Buffer
+	if err := interval.New(cmpUintInterval).Store(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	got := interval.NewTree(cmpUintInterval, ps...)
+	if err := got.Load(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.String() != "" {
+		t.Fatalf("Load(empty), got: %s, want: empty tree", got)
+	}
+}
+
+func TestLoadChecksum(t *testing.T) {
+	t.Parallel()
+
+	withCodec := interval.WithCodec(encodeUintIval, decodeUintIval)
+
+	tree := interval.New(cmpUintInterval, withCodec)
+	tree.Insert(ps...)
+
+	var buf bytes.Buffer
+	if err := tree.Store(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	// each corrupted byte fails loudly, the tree is unchanged
+	got := interval.New(cmpUintInterval, withCodec)
+	got.Insert(uintInterval{1, 2})
+
+	for i := range data {
+		corrupted := bytes.Clone(data)
+		corrupted[i] ^= 0x10
+
+		if err := got.Load(bytes.NewReader(corrupted)); !errors.Is(err, interval.ErrInvalidData) {
+			t.Fatalf("Load(corrupted at %d), got: %v, want: ErrInvalidData", i, err)
+		}
+
+		if got.String() != "▼\n└─ 1...2\n" {
+			t.Fatalf("Load(corrupted at %d), partially loaded:\n%s", i, got)
+		}
+	}
+
+	// layout: magic, version, encoding, count (1 byte), crc, priorities, crc, items, crc
+	hdr, prios := 6, 4*len(ps)
+
+	// a corrupted priority is only detected by the checksum
+	corrupted := bytes.Clone(data)
+	corrupted[hdr+4+prios/2] ^= 0xff
+
+	if err := got.Load(bytes.NewReader(corrupted)); !errors.Is(err, interval.ErrChecksum) {
+		t.Fatalf("Load(corrupted priority), got: %v, want: ErrChecksum", err)
+	}
+
+	// version 1 without checksums is still loaded
+	v1 := slices.Concat(data[:hdr], data[hdr+4:hdr+4+prios], data[hdr+8+prios:len(data)-4])
+	v1[3] = 1
+
+	if err := got.Load(bytes.NewReader(v1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.String() != tree.String() {
+		t.Fatalf("Load(version 1), got:\n%s\nwant:\n%s", got, tree)
+	}
+}
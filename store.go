@@ -0,0 +1,185 @@
+package interval
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrUnknownVersion is returned by [Store.Rollback] if the version is not in the history.
+var ErrUnknownVersion = errors.New("interval: version not in history")
+
+// Store holds the current version of a tree for lock-free concurrent readers and writers.
+//
+// Readers get the current tree with [Store.Load] and query it without any locking,
+// writers publish new versions with [Store.Swap] or [Store.Update]. A published tree must
+// not be modified in place anymore, readers may use it concurrently.
+//
+// Successive versions share all unchanged nodes, keeping old versions in the history
+// or as checkpoints costs only the changed paths.
+//
+// The zero value is an empty store, Load returns nil until a tree is published.
+type Store[T any] struct {
+	p atomic.Pointer[Version[T]]
+
+	mu      sync.Mutex
+	keep    int          // max number of versions in history, see KeepHistory
+	history []Version[T] // ordered by Seq
+
+	recorded uint64          // Seq of the next version to record, see record
+	early    []transition[T] // published versions waiting for the record of their predecessors
+
+	watchers    map[*watcher[T]]struct{} // see Watch
+	checkpoints map[string]Version[T]    // see Checkpoint
+}
+
+// Version is a published tree with its sequence number, label and publishing time.
+type Version[T any] struct {
+	Seq   uint64
+	Label string
+	Time  time.Time
+	Tree  *Tree[T]
+}
+
+// NewStore returns a store with t as the current version.
+func NewStore[T any](t *Tree[T]) *Store[T] {
+	s := new(Store[T])
+	s.Publish(t, "")
+	return s
+}
+
+// Load returns the current version of the tree, treat it as read-only.
+func (s *Store[T]) Load() *Tree[T] {
+	if v := s.p.Load(); v != nil {
+		return v.Tree
+	}
+	return nil
+}
+
+// Swap publishes t as the new version and returns the previous version.
+func (s *Store[T]) Swap(t *Tree[T]) (old *Tree[T]) {
+	return s.Publish(t, "")
+}
+
+// Publish is [Store.Swap] with a label for the history, e.g. the name of the policy push.
+func (s *Store[T]) Publish(t *Tree[T], label string) (old *Tree[T]) {
+	for {
+		cur := s.p.Load()
+		if s.cas(cur, t, label) {
+			if cur == nil {
+				return nil
+			}
+			return cur.Tree
+		}
+	}
+}
+
+// cas, publishes t as the successor of version cur, if cur is still the current version.
+// The published tree owns no nodes, a Clone by concurrent readers doesn't change it.
+func (s *Store[T]) cas(cur *Version[T], t *Tree[T], label string) bool {
+	if t != nil && t.owner != 0 {
+		t.owner = 0
+	}
+
+	next := &Version[T]{Label: label, Time: time.Now(), Tree: t}
+	if cur != nil {
+		next.Seq = cur.Seq + 1
+	}
+
+	if !s.p.CompareAndSwap(cur, next) {
+		return false
+	}
+
+	s.record(cur, next)
+	return true
+}
+
+// Update publishes the tree returned by fn as the new version.
+//
+// fn is called with a copy of the current version, the copy may be modified in place, the shared
+// nodes are copied before modification (copy-on-write). If another writer published a new version
+// in the meantime, fn is called again with the newer version, fn must be free of side effects.
+// The current version must not be nil, publish an initial tree first.
+func (s *Store[T]) Update(fn func(Tree[T]) Tree[T]) {
+	for {
+		cur := s.p.Load()
+
+		next := fn(*cur.Tree.Clone())
+		if s.cas(cur, &next, "") {
+			return
+		}
+	}
+}
+
+// KeepHistory retains the last n published versions for [Store.History] and [Store.Rollback].
+// n <= 0 disables the history.
+func (s *Store[T]) KeepHistory(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keep = max(n, 0)
+	s.trim()
+}
+
+// record the published version in the history and notify the watchers.
+//
+// Concurrent writers may call record out of order after their CAS, a version waits
+// until all its predecessors are recorded, the versions are recorded in Seq order.
+func (s *Store[T]) record(prev, v *Version[T]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.early = append(s.early, transition[T]{prev, v})
+
+	for {
+		i := slices.IndexFunc(s.early, func(tr transition[T]) bool { return tr.next.Seq == s.recorded })
+		if i < 0 {
+			return
+		}
+		tr := s.early[i]
+		s.early = slices.Delete(s.early, i, i+1)
+		s.recorded++
+
+		s.enqueue(tr.prev, tr.next)
+
+		if s.keep > 0 {
+			s.history = append(s.history, *tr.next)
+			s.trim()
+		}
+	}
+}
+
+// trim the history to the last keep versions.
+func (s *Store[T]) trim() {
+	if drop := len(s.history) - s.keep; drop > 0 {
+		s.history = slices.Delete(s.history, 0, drop)
+	}
+}
+
+// History returns the retained versions, the oldest first.
+func (s *Store[T]) History() []Version[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.history)
+}
+
+// Rollback publishes the tree of version seq from the history again, as a new version.
+func (s *Store[T]) Rollback(seq uint64) error {
+	s.mu.Lock()
+	i := slices.IndexFunc(s.history, func(v Version[T]) bool { return v.Seq == seq })
+	var v Version[T]
+	if i >= 0 {
+		v = s.history[i]
+	}
+	s.mu.Unlock()
+
+	if i < 0 {
+		return ErrUnknownVersion
+	}
+
+	s.Publish(v.Tree, "rollback")
+	return nil
+}
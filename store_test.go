@@ -0,0 +1,109 @@
+package interval_test
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	var zero interval.Store[uintInterval]
+	if zero.Load() != nil {
+		t.Fatal("zero Store, Load(), got: tree, want: nil")
+	}
+
+	tree0 := interval.NewTree(cmpUintInterval, ps...)
+	want := tree0.String()
+
+	s := interval.NewStore(tree0)
+
+	ivals := genUintIvals(1_000)
+
+	var wg sync.WaitGroup
+
+	// concurrent writers
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(part []uintInterval) {
+			defer wg.Done()
+			for _, item := range part {
+				s.Update(func(tree interval.Tree[uintInterval]) interval.Tree[uintInterval] {
+					tree.Insert(item)
+					return tree
+				})
+			}
+		}(ivals[i*250 : (i+1)*250])
+	}
+
+	// concurrent readers
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1_000; j++ {
+				_ = s.Load().Intersections(ps[j%len(ps)])
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	all := interval.NewTree(cmpUintInterval, ps...)
+	all.Insert(ivals...)
+
+	if !equalsSizeAndOrder(all, s.Load()) {
+		t.Fatal("concurrent Update() lost items")
+	}
+
+	// the initial version is unchanged
+	if tree0.String() != want {
+		t.Fatal("Update() changed the published tree in place")
+	}
+
+	if old := s.Swap(tree0); !equalsSizeAndOrder(all, old) {
+		t.Fatal("Swap(), old version differs")
+	}
+}
+
+func TestStoreHistory(t *testing.T) {
+	t.Parallel()
+
+	s := interval.NewStore(interval.NewTree(cmpUintInterval))
+	s.KeepHistory(3)
+
+	for i, item := range ps[:5] {
+		tree := s.Load().InsertImmutable(item)
+		s.Publish(tree, "push-"+strconv.Itoa(i))
+	}
+
+	history := s.History()
+	if len(history) != 3 {
+		t.Fatalf("History(), got: %d versions, want: 3", len(history))
+	}
+
+	for i, v := range history {
+		if want := uint64(i + 3); v.Seq != want {
+			t.Fatalf("History()[%d].Seq, got: %d, want: %d", i, v.Seq, want)
+		}
+		if want := "push-" + strconv.Itoa(i+2); v.Label != want {
+			t.Fatalf("History()[%d].Label, got: %q, want: %q", i, v.Label, want)
+		}
+	}
+
+	if err := s.Rollback(3); err != nil {
+		t.Fatal(err)
+	}
+
+	if !equalsSizeAndOrder(s.Load(), interval.NewTree(cmpUintInterval, ps[:3]...)) {
+		t.Fatal("Rollback(3), wrong tree")
+	}
+
+	if err := s.Rollback(1); !errors.Is(err, interval.ErrUnknownVersion) {
+		t.Fatalf("Rollback(1), got: %v, want: ErrUnknownVersion", err)
+	}
+}
@@ -0,0 +1,62 @@
+package interval
+
+import "context"
+
+// IntersectionsChan streams all intervals that intersect with item in sorted order, see [Tree.Intersections].
+//
+// The channel is closed after the last item or when ctx is done, the traversal is stopped then.
+// The tree must not be changed in place while streaming, the immutable variants are fine.
+func (t Tree[T]) IntersectionsChan(ctx context.Context, item T) <-chan T {
+	ch := make(chan T)
+
+	go func() {
+		defer close(ch)
+
+		t.intersectionsFn(t.root, item, func(hit *T) bool {
+			// select picks randomly if both are ready, check the context first
+			if ctx.Err() != nil {
+				return false
+			}
+
+			select {
+			case ch <- *hit:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	return ch
+}
+
+// intersectionsFn rec-descent, same as intersections but calls fn for each hit in sorted order.
+// Returns false if fn stopped the traversal.
+func (t *Tree[T]) intersectionsFn(n *node[T], item T, fn func(*T) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	// don't traverse this subtree, subtree has too small upper value for intersection
+	if t.cmpLR(item, n.maxUpperItem()) > 0 {
+		return true
+	}
+
+	// in-order traversal, recursive call to left tree
+	if !t.intersectionsFn(n.left, item, fn) {
+		return false
+	}
+
+	// this n.item
+	if t.cmpIntersects(n.item, item) && !fn(&n.item) {
+		return false
+	}
+
+	// don't traverse right subtree, subtree has too small left value for intersection.
+	if t.cmpRL(item, n.item) < 0 {
+		return true
+	}
+
+	// recursive call to right tree
+	return t.intersectionsFn(n.right, item, fn)
+}
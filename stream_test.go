@@ -0,0 +1,49 @@
+package interval_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestIntersectionsChan(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+
+	for _, probe := range genUintIvals(20) {
+		var got []uintInterval
+		for item := range tree.IntersectionsChan(context.Background(), probe) {
+			got = append(got, item)
+		}
+
+		if want := tree.Intersections(probe); !slices.Equal(got, want) {
+			t.Fatalf("IntersectionsChan(%v), got %d items, want %d", probe, len(got), len(want))
+		}
+	}
+}
+
+func TestIntersectionsChanCancel(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+	probe := uintInterval{0, ^uint(0)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := tree.IntersectionsChan(ctx, probe)
+
+	<-ch
+	<-ch
+	cancel()
+
+	// drain, the channel must be closed soon, at most one pending item
+	n := 0
+	for range ch {
+		n++
+	}
+	if n > 1 {
+		t.Fatalf("IntersectionsChan after cancel, got %d more items, want <= 1", n)
+	}
+}
@@ -0,0 +1,141 @@
+package interval
+
+import "sync"
+
+// SyncTree is an interval tree safe for concurrent use, all methods are guarded by an internal RWMutex.
+//
+// It is the simple alternative to [Store] for services that just want a thread-safe interval map
+// and don't care about lock-free reads, versions or immutability. Mutations are done in place.
+type SyncTree[T any] struct {
+	mu   sync.RWMutex
+	tree *Tree[T]
+}
+
+// NewSyncTree initializes an empty concurrency safe interval tree with the compare function
+// and the options, see [New].
+func NewSyncTree[T any](cmp func(a, b T) (ll, rr, lr, rl int), opts ...Option) *SyncTree[T] {
+	return &SyncTree[T]{tree: New(cmp, opts...)}
+}
+
+// Insert inserts items into the tree, see [Tree.Insert].
+func (s *SyncTree[T]) Insert(items ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tree.insertInPlace(items...)
+}
+
+// Delete removes the item from the tree, returns true if it exists, see [Tree.Delete].
+func (s *SyncTree[T]) Delete(item T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.deleteInPlace(item)
+}
+
+// Union combines the other tree into s, see [Tree.Union].
+// The other tree must not be modified concurrently.
+func (s *SyncTree[T]) Union(other *Tree[T], overwrite bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tree.unionInPlace(other, overwrite)
+}
+
+// Snapshot returns an immutable copy of the current tree in O(1).
+// The snapshot can be queried without any locking, later changes of s are not visible in it.
+func (s *SyncTree[T]) Snapshot() *Tree[T] {
+	// Clone changes the owner token of the tree, needs the write lock
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Clone()
+}
+
+// Find, see [Tree.Find].
+func (s *SyncTree[T]) Find(item T) (result T, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Find(item)
+}
+
+// CoverLCP, see [Tree.CoverLCP].
+func (s *SyncTree[T]) CoverLCP(item T) (result T, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.CoverLCP(item)
+}
+
+// CoverSCP, see [Tree.CoverSCP].
+func (s *SyncTree[T]) CoverSCP(item T) (result T, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.CoverSCP(item)
+}
+
+// Intersects, see [Tree.Intersects].
+func (s *SyncTree[T]) Intersects(item T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Intersects(item)
+}
+
+// Covers, see [Tree.Covers].
+func (s *SyncTree[T]) Covers(item T) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Covers(item)
+}
+
+// Precedes, see [Tree.Precedes].
+func (s *SyncTree[T]) Precedes(item T) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Precedes(item)
+}
+
+// CoveredBy, see [Tree.CoveredBy].
+func (s *SyncTree[T]) CoveredBy(item T) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.CoveredBy(item)
+}
+
+// PrecededBy, see [Tree.PrecededBy].
+func (s *SyncTree[T]) PrecededBy(item T) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.PrecededBy(item)
+}
+
+// Intersections, see [Tree.Intersections].
+func (s *SyncTree[T]) Intersections(item T) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Intersections(item)
+}
+
+// Visit, see [Tree.Visit]. The read lock is held during the traversal,
+// visitFn must not call any mutating method of s, this would deadlock.
+func (s *SyncTree[T]) Visit(start, stop T, visitFn func(item T) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.tree.Visit(start, stop, visitFn)
+}
+
+// Min, see [Tree.Min].
+func (s *SyncTree[T]) Min() (min T) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Min()
+}
+
+// Max, see [Tree.Max].
+func (s *SyncTree[T]) Max() (max T) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Max()
+}
+
+// String, see [Tree.String].
+func (s *SyncTree[T]) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.String()
+}
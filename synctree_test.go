@@ -0,0 +1,60 @@
+package interval_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestSyncTree(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(1_000)
+	st := interval.NewSyncTree(cmpUintInterval)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(ivals); i += 4 {
+				st.Insert(ivals[i])
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := range ivals {
+				st.Intersections(ivals[i])
+				st.CoverLCP(ivals[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := interval.NewTree(cmpUintInterval, ivals...)
+	snap := st.Snapshot()
+	if !equalsSizeAndOrder(snap, want) {
+		t.Fatal("SyncTree, after concurrent inserts, snapshot differs from NewTree")
+	}
+
+	var items []uintInterval
+	snap.Visit(snap.Min(), snap.Max(), func(item uintInterval) bool {
+		items = append(items, item)
+		return len(items) < 100
+	})
+
+	for _, item := range items {
+		if !st.Delete(item) {
+			t.Fatalf("Delete(%v), got false, want true", item)
+		}
+	}
+
+	// snapshot is isolated from later changes
+	if !equalsSizeAndOrder(snap, want) {
+		t.Fatal("SyncTree, snapshot changed by Delete")
+	}
+	if _, ok := st.Find(items[0]); ok {
+		t.Fatalf("Find(%v) after Delete, got true, want false", items[0])
+	}
+}
@@ -0,0 +1,115 @@
+package interval
+
+import (
+	"slices"
+	"sync"
+)
+
+// Tables maps names, e.g. VRFs, tenants or zones, to trees. Every table is a [Store],
+// lock-free for readers and atomically swapped by writers, independent of the other tables.
+//
+// Tables is safe for concurrent use. The zero value is not usable, see [NewTables].
+type Tables[T any] struct {
+	cmp  func(a, b T) (ll, rr, lr, rl int)
+	opts []Option
+
+	mu     sync.RWMutex
+	stores map[string]*Store[T]
+}
+
+// NewTables returns an empty set of tables, new tables are created with the compare function
+// and the options, see [New].
+func NewTables[T any](cmp func(a, b T) (ll, rr, lr, rl int), opts ...Option) *Tables[T] {
+	return &Tables[T]{cmp: cmp, opts: opts, stores: make(map[string]*Store[T])}
+}
+
+// Store returns the store of the table name, the table is created empty if it doesn't exist.
+func (ts *Tables[T]) Store(name string) *Store[T] {
+	ts.mu.RLock()
+	s := ts.stores[name]
+	ts.mu.RUnlock()
+
+	if s != nil {
+		return s
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	// double check, another writer may have created it in the meantime
+	if s = ts.stores[name]; s == nil {
+		s = NewStore(New(ts.cmp, ts.opts...))
+		ts.stores[name] = s
+	}
+	return s
+}
+
+// Table returns the current tree of the table name or nil if there is no such table.
+// Treat it as read-only.
+func (ts *Tables[T]) Table(name string) *Tree[T] {
+	ts.mu.RLock()
+	s := ts.stores[name]
+	ts.mu.RUnlock()
+
+	if s == nil {
+		return nil
+	}
+	return s.Load()
+}
+
+// Swap publishes t as the new tree of the table name and returns the previous tree,
+// see [Store.Swap]. The table is created if it doesn't exist.
+func (ts *Tables[T]) Swap(name string, t *Tree[T]) (old *Tree[T]) {
+	return ts.Store(name).Swap(t)
+}
+
+// Update changes the table name, see [Store.Update]. The table is created if it doesn't exist.
+func (ts *Tables[T]) Update(name string, fn func(Tree[T]) Tree[T]) {
+	ts.Store(name).Update(fn)
+}
+
+// Drop removes the table name.
+func (ts *Tables[T]) Drop(name string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	delete(ts.stores, name)
+}
+
+// Names returns the names of all tables in sorted order.
+func (ts *Tables[T]) Names() []string {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	names := make([]string, 0, len(ts.stores))
+	for name := range ts.stores {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Lookup returns the longest-prefix-match for item in the table name, see [Tree.CoverLCP].
+// If there is no such table or no covering item, the zero value and false is returned.
+func (ts *Tables[T]) Lookup(name string, item T) (result T, ok bool) {
+	if t := ts.Table(name); t != nil {
+		return t.CoverLCP(item)
+	}
+	return
+}
+
+// Union returns a new tree with the items of the named tables, all tables if no names are given.
+// For duplicate items the one of the table named last wins. Missing tables are ignored.
+func (ts *Tables[T]) Union(names ...string) *Tree[T] {
+	if len(names) == 0 {
+		names = ts.Names()
+	}
+
+	result := New(ts.cmp, ts.opts...)
+	for _, name := range names {
+		if t := ts.Table(name); t != nil {
+			result = result.UnionImmutable(t, true)
+		}
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package interval_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestTables(t *testing.T) {
+	t.Parallel()
+
+	ts := interval.NewTables(cmpUintInterval)
+
+	if ts.Table("red") != nil {
+		t.Fatal("Table of unknown name, got tree, want nil")
+	}
+
+	ts.Swap("red", interval.NewTree(cmpUintInterval, uintInterval{0, 100}, uintInterval{10, 20}))
+
+	var wg sync.WaitGroup
+	for i := uint(0); i < 10; i++ {
+		wg.Add(1)
+		go func(i uint) {
+			defer wg.Done()
+			ts.Update("blue", func(tree interval.Tree[uintInterval]) interval.Tree[uintInterval] {
+				tree.Insert(uintInterval{i * 10, i*10 + 5})
+				return tree
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	if got := ts.Names(); !slices.Equal(got, []string{"blue", "red"}) {
+		t.Fatalf("Names(), got %v", got)
+	}
+
+	if got, ok := ts.Lookup("red", uintInterval{12, 13}); !ok || got != (uintInterval{10, 20}) {
+		t.Fatalf("Lookup(red), got %v %v, want 10...20 true", got, ok)
+	}
+	if got, ok := ts.Lookup("blue", uintInterval{12, 13}); !ok || got != (uintInterval{10, 15}) {
+		t.Fatalf("Lookup(blue), got %v %v, want 10...15 true", got, ok)
+	}
+	if _, ok := ts.Lookup("green", uintInterval{12, 13}); ok {
+		t.Fatal("Lookup(green), got true, want false")
+	}
+
+	all := ts.Union()
+	if size, _, _, _ := all.Statistics(); size != 12 {
+		t.Fatalf("Union(), got %d items, want 12", size)
+	}
+
+	ts.Drop("blue")
+	if got := ts.Names(); !slices.Equal(got, []string{"red"}) {
+		t.Fatalf("Names() after Drop, got %v", got)
+	}
+}
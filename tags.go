@@ -0,0 +1,53 @@
+package interval
+
+import "slices"
+
+// WithTags, fn returns the tags of an item, e.g. the zone or the owner of a rule,
+// for the tag-scoped queries [Tree.IntersectionsTagged] and [Tree.CoversTagged].
+// The tags are derived from the items on demand, they are not stored in the tree.
+func WithTags[T any](fn func(item T) []string) Option {
+	return func(o *options) {
+		o.tags = fn
+	}
+}
+
+// tagged, returns true if the item has the tag, see WithTags.
+func (t *Tree[T]) tagged(item T, tag string) bool {
+	return t.tags != nil && slices.Contains(t.tags(item), tag)
+}
+
+// IntersectionsTagged returns all intervals with the tag that intersect with item, in sorted order.
+// Items without the tag are skipped during the traversal, no intermediate result is built.
+// Without [WithTags] no item has a tag, the result is empty.
+func (t Tree[T]) IntersectionsTagged(item T, tag string) (result []T) {
+	if t.metrics() != nil {
+		report := t.observe("IntersectionsTagged")
+		defer func() { report(len(result)) }()
+	}
+
+	t.intersectionsFn(t.root, item, func(hit *T) bool {
+		if t.tagged(*hit, tag) {
+			result = append(result, *hit)
+		}
+		return true
+	})
+
+	return result
+}
+
+// CoversTagged returns all intervals with the tag that cover the item, in sorted order.
+// See [Tree.IntersectionsTagged].
+func (t Tree[T]) CoversTagged(item T, tag string) (result []T) {
+	if t.metrics() != nil {
+		report := t.observe("CoversTagged")
+		defer func() { report(len(result)) }()
+	}
+
+	t.covers(t.root, item, func(hit *T) {
+		if t.tagged(*hit, tag) {
+			result = append(result, *hit)
+		}
+	})
+
+	return result
+}
@@ -0,0 +1,65 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+type taggedInterval struct {
+	ival uintInterval
+	tags []string
+}
+
+func cmpTaggedInterval(a, b taggedInterval) (ll, rr, lr, rl int) {
+	return cmpUintInterval(a.ival, b.ival)
+}
+
+func TestTagged(t *testing.T) {
+	t.Parallel()
+
+	zones := []string{"dmz", "lan", "wan"}
+
+	var items []taggedInterval
+	for i, ival := range genUintIvals(2_000) {
+		items = append(items, taggedInterval{ival: ival, tags: []string{zones[i%3], zones[(i+1)%3]}})
+	}
+
+	tree := interval.NewTree(cmpTaggedInterval, items...)
+	tags := func(item taggedInterval) []string { return item.tags }
+	tagged := interval.New(cmpTaggedInterval, interval.WithTags(tags))
+	tagged.Insert(items...)
+
+	// post-filtered results
+	filter := func(hits []taggedInterval, tag string) (result []taggedInterval) {
+		for _, hit := range hits {
+			if slices.Contains(hit.tags, tag) {
+				result = append(result, hit)
+			}
+		}
+		return result
+	}
+
+	equal := func(a, b []taggedInterval) bool {
+		return slices.EqualFunc(a, b, func(x, y taggedInterval) bool { return x.ival == y.ival })
+	}
+
+	for _, probe := range genUintIvals(100) {
+		item := taggedInterval{ival: probe}
+
+		for _, tag := range zones {
+			if got, want := tagged.IntersectionsTagged(item, tag), filter(tree.Intersections(item), tag); !equal(got, want) {
+				t.Fatalf("IntersectionsTagged(%v, %s), got %d items, want %d", probe, tag, len(got), len(want))
+			}
+			if got, want := tagged.CoversTagged(item, tag), filter(tree.Covers(item), tag); !equal(got, want) {
+				t.Fatalf("CoversTagged(%v, %s), got %d items, want %d", probe, tag, len(got), len(want))
+			}
+		}
+
+		// no tag function, no tags
+		if got := tree.IntersectionsTagged(item, "dmz"); got != nil {
+			t.Fatalf("IntersectionsTagged without WithTags, got %v, want nil", got)
+		}
+	}
+}
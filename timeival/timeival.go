@@ -0,0 +1,156 @@
+// Package timeival is an adapter for time intervals with scheduling helpers.
+//
+// The intervals are half-open [Start, End), back-to-back events like 09:00-10:00 and 10:00-11:00
+// don't conflict. A [Schedule] detects the conflicts of an event and finds the free slots in a window.
+//
+//	s := timeival.NewSchedule(events...)
+//	conflicts := s.Conflicts(meeting)
+//	slots := s.FreeSlots(workday, 30*time.Minute)
+package timeival
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gaissmai/interval"
+)
+
+// ErrInvalidInterval is returned by [Parse] and [New] for malformed intervals.
+var ErrInvalidInterval = errors.New("timeival: invalid interval")
+
+// Interval is the half-open time interval [Start, End).
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// New returns the interval [start, end), start must be before end.
+func New(start, end time.Time) (Interval, error) {
+	if !start.Before(end) {
+		return Interval{}, fmt.Errorf("%w: %s/%s", ErrInvalidInterval, start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+	return Interval{start, end}, nil
+}
+
+// Parse parses an RFC3339 range "start/end" like the ISO 8601 time intervals,
+// e.g. "2024-05-02T09:00:00Z/2024-05-02T10:30:00Z".
+func Parse(s string) (Interval, error) {
+	from, to, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Interval{}, fmt.Errorf("%w: missing '/' in %q", ErrInvalidInterval, s)
+	}
+
+	start, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return Interval{}, fmt.Errorf("%w: %w", ErrInvalidInterval, err)
+	}
+	end, err := time.Parse(time.RFC3339, to)
+	if err != nil {
+		return Interval{}, fmt.Errorf("%w: %w", ErrInvalidInterval, err)
+	}
+
+	return New(start, end)
+}
+
+// MustParse is like [Parse] but panics on errors, for tests and static data.
+func MustParse(s string) Interval {
+	i, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// String returns the interval as RFC3339 range "start/end".
+func (i Interval) String() string {
+	return i.Start.Format(time.RFC3339) + "/" + i.End.Format(time.RFC3339)
+}
+
+// Duration returns the length of the interval.
+func (i Interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// Compare is the compare function for time intervals, the trees must be created with
+// [interval.WithHalfOpen], see [NewTree].
+func Compare(a, b Interval) (ll, rr, lr, rl int) {
+	return a.Start.Compare(b.Start), a.End.Compare(b.End), a.Start.Compare(b.End), a.End.Compare(b.Start)
+}
+
+// NewTree returns a half-open interval tree with the intervals.
+func NewTree(intervals ...Interval) *interval.Tree[Interval] {
+	m := interval.NewMutTree(Compare, interval.WithHalfOpen())
+	m.Insert(intervals...)
+	return m.Freeze()
+}
+
+// Schedule is a set of booked time intervals. A Schedule is not safe for concurrent use.
+type Schedule struct {
+	tree *interval.Tree[Interval]
+}
+
+// NewSchedule returns a schedule with the booked events.
+func NewSchedule(events ...Interval) *Schedule {
+	return &Schedule{tree: NewTree(events...)}
+}
+
+// Book adds the events to the schedule, conflicts are not checked, see [Schedule.Conflicts].
+func (s *Schedule) Book(events ...Interval) {
+	m := s.tree.Mutable()
+	m.Insert(events...)
+	s.tree = m.Freeze()
+}
+
+// Cancel removes the event, returns true if it was booked, false otherwise.
+func (s *Schedule) Cancel(event Interval) bool {
+	tree, ok := s.tree.DeleteImmutable(event)
+	s.tree = tree
+	return ok
+}
+
+// Conflicts returns all booked events overlapping the event, in chronological order.
+func (s *Schedule) Conflicts(event Interval) []Interval {
+	return s.tree.Intersections(event)
+}
+
+// FreeSlots returns the gaps between the booked events within the window,
+// in chronological order, with a duration of at least dur.
+func (s *Schedule) FreeSlots(window Interval, dur time.Duration) []Interval {
+	var slots []Interval
+
+	cursor := window.Start
+	add := func(end time.Time) {
+		if end.Sub(cursor) >= dur && cursor.Before(end) {
+			slots = append(slots, Interval{cursor, end})
+		}
+	}
+
+	// sorted by start, overlapping events are merged by the cursor
+	for _, busy := range s.tree.Intersections(window) {
+		if busy.Start.After(cursor) {
+			add(busy.Start)
+		}
+		if busy.End.After(cursor) {
+			cursor = busy.End
+		}
+	}
+
+	if cursor.Before(window.End) {
+		add(window.End)
+	}
+	return slots
+}
+
+// Tree returns the underlying interval tree for all other queries.
+// The returned tree is a snapshot, later changes of s are not visible in it.
+func (s *Schedule) Tree() *interval.Tree[Interval] {
+	return s.tree
+}
+
+// Width measures the duration from the start of left to the end of right in nanoseconds,
+// the width callback for [interval.Uptime] and [interval.CoveredWidth].
+func Width(left, right Interval) float64 {
+	return float64(right.End.Sub(left.Start))
+}
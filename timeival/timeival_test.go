@@ -0,0 +1,99 @@
+package timeival_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gaissmai/interval"
+	"github.com/gaissmai/interval/timeival"
+)
+
+var mp = timeival.MustParse
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	i := mp("2024-05-02T09:00:00Z/2024-05-02T10:30:00Z")
+	if i.Duration() != 90*time.Minute {
+		t.Errorf("Duration(), got %v, want 1h30m", i.Duration())
+	}
+	if got := i.String(); got != "2024-05-02T09:00:00Z/2024-05-02T10:30:00Z" {
+		t.Errorf("String(), got %s", got)
+	}
+
+	for _, in := range []string{
+		"2024-05-02T09:00:00Z",
+		"2024-05-02T10:00:00Z/2024-05-02T09:00:00Z",
+		"2024-05-02T09:00:00Z/2024-05-02T09:00:00Z",
+		"2024-05-02/2024-05-03",
+	} {
+		if _, err := timeival.Parse(in); !errors.Is(err, timeival.ErrInvalidInterval) {
+			t.Errorf("Parse(%q), got err %v, want %v", in, err, timeival.ErrInvalidInterval)
+		}
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	t.Parallel()
+
+	s := timeival.NewSchedule(
+		mp("2024-05-02T09:00:00Z/2024-05-02T10:00:00Z"),
+		mp("2024-05-02T10:00:00Z/2024-05-02T11:00:00Z"),
+		mp("2024-05-02T10:30:00Z/2024-05-02T11:30:00Z"),
+		mp("2024-05-02T13:00:00Z/2024-05-02T13:15:00Z"),
+		mp("2024-05-02T14:00:00Z/2024-05-02T16:00:00Z"),
+	)
+
+	// back-to-back is no conflict
+	if got := s.Conflicts(mp("2024-05-02T11:30:00Z/2024-05-02T12:00:00Z")); len(got) != 0 {
+		t.Errorf("Conflicts, got %v, want none", got)
+	}
+	if got := s.Conflicts(mp("2024-05-02T09:45:00Z/2024-05-02T10:15:00Z")); len(got) != 2 {
+		t.Errorf("Conflicts, got %v, want 2 events", got)
+	}
+
+	workday := mp("2024-05-02T08:00:00Z/2024-05-02T17:00:00Z")
+	want := []string{
+		"2024-05-02T08:00:00Z/2024-05-02T09:00:00Z",
+		"2024-05-02T11:30:00Z/2024-05-02T13:00:00Z",
+		"2024-05-02T16:00:00Z/2024-05-02T17:00:00Z",
+	}
+
+	got := s.FreeSlots(workday, 50*time.Minute)
+	if len(got) != len(want) {
+		t.Fatalf("FreeSlots, got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].String() != want[i] {
+			t.Fatalf("FreeSlots, got %v, want %v", got, want)
+		}
+	}
+
+	// the 45 minutes gap 13:15-14:00 counts for shorter durations
+	if got := s.FreeSlots(workday, 45*time.Minute); len(got) != 4 {
+		t.Errorf("FreeSlots(45m), got %v, want 4 slots", got)
+	}
+
+	// window within a busy event
+	if got := s.FreeSlots(mp("2024-05-02T14:30:00Z/2024-05-02T15:00:00Z"), time.Minute); len(got) != 0 {
+		t.Errorf("FreeSlots in busy window, got %v, want none", got)
+	}
+}
+
+func TestUptime(t *testing.T) {
+	t.Parallel()
+
+	outages := timeival.NewTree(
+		mp("2024-05-01T23:00:00Z/2024-05-02T01:00:00Z"),
+		mp("2024-05-02T12:00:00Z/2024-05-02T12:30:00Z"),
+		mp("2024-05-02T12:15:00Z/2024-05-02T12:45:00Z"),
+	)
+	day := mp("2024-05-02T00:00:00Z/2024-05-03T00:00:00Z")
+
+	// 1h + 45m of 24h
+	want := 100 * (1 - 105.0/1440)
+	if got := interval.Uptime(outages, day, timeival.Width); got < want-1e-9 || got > want+1e-9 {
+		t.Errorf("Uptime, got %v, want %v", got, want)
+	}
+}
@@ -6,31 +6,113 @@
 //
 // However, the interval package is useful for all one-dimensional intervals, e.g. time intervals.
 //
+// Build with the tag 'interval_inline' to store the augmented upper bounds as values in the nodes
+// instead of pointers to other nodes.
+//
+// Build with the tag 'interval_maxonly' to maintain only the max upper bounds, smaller nodes
+// for trees that don't need fast CoveredBy and Precedes queries.
+//
+// Build with the tag 'interval_debug' to cross-check every result of the compare function
+// for consistency, broken compare functions panic with a helpful message.
+//
 // [iprange package]: https://github.com/gaissmai/iprange
 package interval
 
 import (
-	"math/rand"
+	"errors"
+	"fmt"
+	"math/bits"
+	"slices"
 	"sync"
+	"sync/atomic"
 )
 
+// ErrMalformedInterval is returned if the left point of an interval is greater than the right point.
+var ErrMalformedInterval = errors.New("interval: left point is greater than right point")
+
+// ErrNoCompareFunc is returned, or the panic value, if items are added to a zero value [Tree]
+// without compare function.
+var ErrNoCompareFunc = errors.New("interval: tree without compare function, initialize it with New or NewTree")
+
 // node is the basic recursive data structure.
 type node[T any] struct {
-	// augment the treap for interval lookups
-	minUpper *node[T] // pointer to node in subtree with min upper value
-	maxUpper *node[T] // pointer to node in subtree with max upper value
+	// augment the treap for interval lookups, see augment_*.go
+	augment[T]
 	//
 	// base treap fields, in memory efficient order
 	left  *node[T]
 	right *node[T]
 	prio  uint32 // random key for binary heap, balances the tree
-	item  T      // generic key/value
+	owner uint32 // the tree allowed to modify this node in place, see [Tree.own]
+	//
+	// digest of the subtree, zero size without the build tag 'interval_hash', see hash_*.go
+	hashAugment
+	//
+	// number of nodes in the subtree, 8 bytes per node, the price for [Tree.CountBetween]
+	// in O(log n), the O(1) sizes, e.g. [Tree.MemStats], and the preallocated query results
+	size int
+	item T // generic key/value
 }
 
-// Tree is the public handle, using it without initialization will panic.
+// Tree is the public handle, initialize it with [New] or [NewTree].
+//
+// The in place methods, Insert, Delete, Union, change the tree and all copies of the handle.
+// Use the immutable variants, or [MutTree] with only in place methods and O(1) conversions
+// in both directions, to avoid accidental aliasing.
+//
+// The zero value is an empty tree without compare function, e.g. as struct field: all queries
+// are well-defined and return empty results, Delete returns false and Union adopts the
+// compare function and the options of the other tree. Inserting items into it panics
+// with [ErrNoCompareFunc], InsertStrict returns it as error.
 type Tree[T any] struct {
-	root *node[T]
-	cmp  func(T, T) (ll, rr, lr, rl int)
+	root  *node[T]
+	cmp   func(T, T) (ll, rr, lr, rl int)
+	arena *arena[T] // optional node allocator, see [WithArena]
+	opts  *options  // optional configuration, see [New]
+	min   *node[T]  // cached leftmost node, see updateMinMax
+	max   *node[T]  // cached rightmost node, see updateMinMax
+	owner uint32    // copy-on-write token, 0 means: owns no nodes at all
+	gen   uint64    // generation, see [Tree.Generation]
+
+	deferred bool // recalc is deferred to a fixup pass, see insertDeferred
+
+	hashFn func(T) uint64 // optional item hash, see [WithHash]
+
+	onInsert func(T) // optional hook, see [WithOnInsert]
+	onDelete func(T) // optional hook, see [WithOnDelete]
+
+	tiebreak func(a, b T) int // optional order of equal intervals, see [WithTiebreak]
+
+	tags func(T) []string // optional tag extractor, see [WithTags]
+
+	stringer func(T) string // optional item formatter for printing, see [WithStringer]
+}
+
+// ownerSeq, the source for unique owner tokens.
+var ownerSeq atomic.Uint32
+
+// acquire a unique owner token for in place modifications, if the tree owns nothing yet.
+func (t *Tree[T]) acquire() {
+	for t.owner == 0 {
+		// 0 is reserved, skip it on wraparound
+		t.owner = ownerSeq.Add(1)
+	}
+}
+
+// detach, the tree gives up the ownership of its nodes, the next in place modification
+// acquires a new owner token and copies all nodes it changes, nodes shared with other
+// trees are never modified. See the deprecated in place methods of Tree and [MutTree].
+func (t *Tree[T]) detach() {
+	t.owner = 0
+}
+
+// own returns n if the tree owns n, otherwise a copy of n owned by the tree (copy-on-write).
+// Immutable operations clear the owner token before descent, every changed node is copied.
+func (t *Tree[T]) own(n *node[T]) *node[T] {
+	if t.owner != 0 && n.owner == t.owner {
+		return n
+	}
+	return t.copyNode(n)
 }
 
 // NewTree initializes the interval tree with the compare function and items from type T.
@@ -45,29 +127,64 @@ type Tree[T any] struct {
 //	rl: right point interval a compared with left  point interval b (-1, 0, +1)
 func NewTree[T any](cmp func(a, b T) (ll, rr, lr, rl int), items ...T) *Tree[T] {
 	var t Tree[T]
-	t.cmp = cmp
+	t.cmp = checkedCmp(cmp)
 
 	// mutable insert
-	for i := range items {
-		t.root = t.insert(t.root, t.makeNode(items[i]), false)
-	}
+	t.insertInPlace(items...)
 
 	return &t
 }
 
 // NewTreeConcurrent, convenience function for initializing the interval tree for large inputs (> 100_000).
 // A good value reference for jobs is the number of logical CPUs usable by the current process.
+//
+// Deprecated: use [NewMutTree] with the option [WithParallelism] and [MutTree.Insert].
 func NewTreeConcurrent[T any](jobs int, cmp func(a, b T) (ll, rr, lr, rl int), items ...T) *Tree[T] {
-	// define a min chunk size, don't split in too small chunks
-	const minChunkSize = 25_000
-
-	// no fan-out for small input slice or just one job
-	l := len(items)
-	if l <= minChunkSize || jobs <= 1 {
+	// no fan-out for just one job
+	if jobs <= 1 {
 		return NewTree[T](cmp, items...)
 	}
 
-	chunkSize := l/jobs + 1
+	t := New[T](cmp, WithParallelism(jobs))
+	t.insertInPlace(items...)
+
+	return t
+}
+
+// minBuildSize, bulk inserts into an empty tree with at least this number of items
+// are sorted and built bottom-up in O(n) instead of inserted one by one.
+const minBuildSize = 1_000
+
+// buildUnsorted, builds the treap from a copy of the items, sorted, with random priorities.
+// Duplicates are replaced by the last one, like in Insert.
+func (t *Tree[T]) buildUnsorted(items []T) *node[T] {
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, t.compare)
+
+	// keep the last of equal items
+	uniq := sorted[:0]
+	for i := range sorted {
+		if i+1 < len(sorted) && t.compare(sorted[i], sorted[i+1]) == 0 {
+			continue
+		}
+		uniq = append(uniq, sorted[i])
+	}
+
+	prios := make([]uint32, len(uniq))
+	for i := range prios {
+		prios[i] = t.randPrio()
+	}
+
+	return t.buildSorted(uniq, prios)
+}
+
+// minChunkSize, don't split the input for concurrent bulk inserts in too small chunks.
+const minChunkSize = 25_000
+
+// insertConcurrent, the items are partitioned into chunks, the partial trees are
+// created concurrently and combined with the tree.
+func (t *Tree[T]) insertConcurrent(jobs int, items []T) {
+	chunkSize := len(items)/jobs + 1
 	if chunkSize < minChunkSize {
 		chunkSize = minChunkSize
 	}
@@ -77,7 +194,7 @@ func NewTreeConcurrent[T any](jobs int, cmp func(a, b T) (ll, rr, lr, rl int), i
 	partialTrees := make(chan *Tree[T])
 
 	// fan out
-	for ; l > 0; l = len(items) {
+	for l := len(items); l > 0; l = len(items) {
 		// partition input into chunks
 		switch {
 		case l > chunkSize:
@@ -91,7 +208,15 @@ func NewTreeConcurrent[T any](jobs int, cmp func(a, b T) (ll, rr, lr, rl int), i
 		wg.Add(1)
 		go func(chunk ...T) {
 			defer wg.Done()
-			partialTrees <- NewTree[T](cmp, chunk...)
+
+			// the whole configuration of t, e.g. tiebreak and hash function, without the nodes.
+			// All partial trees share the owner token, the fan-in unions are in place.
+			partial := *t
+			partial.root, partial.min, partial.max = nil, nil, nil
+
+			// the items are reported once by the caller, not per chunk
+			partial.insertBulk(chunk)
+			partialTrees <- &partial
 		}(chunk...)
 	}
 
@@ -101,155 +226,487 @@ func NewTreeConcurrent[T any](jobs int, cmp func(a, b T) (ll, rr, lr, rl int), i
 		close(partialTrees)
 	}()
 
-	// fan in
-	t := NewTree[T](cmp)
+	// fan in, fast union, duplicates are replaced like in Insert
 	for other := range partialTrees {
-		// fast union
-		t.Union(other, false)
+		t.root = t.union(t.root, other.root, true, 0)
 	}
-	return t
+}
+
+// newNode, allocate a zero node, from the arena if configured.
+func (t *Tree[T]) newNode() *node[T] {
+	if t.arena != nil {
+		return t.arena.alloc()
+	}
+	return new(node[T])
 }
 
 // makeNode, create new node with item and random priority.
+//
+// The priority is taken from the lock-free, per-thread runtime source of math/rand/v2,
+// concurrent immutable inserts don't contend for a global rand lock, see also [WithSeed].
 func (t *Tree[T]) makeNode(item T) *node[T] {
-	n := new(node[T])
+	return t.makeNodeWithPriority(item, t.randPrio())
+}
+
+// makeNodeWithPriority, create new node with item and the given priority.
+func (t *Tree[T]) makeNodeWithPriority(item T, prio uint32) *node[T] {
+	n := t.newNode()
 	n.item = item
-	n.prio = rand.Uint32()
+	n.prio = prio
+	n.owner = t.owner
 	t.recalc(n) // initial calculation of finger pointers...
 
 	return n
 }
 
 // copyNode, make a shallow copy of the pointers and the item, no recalculation necessary.
-func (n *node[T]) copyNode() *node[T] {
-	c := *n
-	return &c
+// The copy is owned by the tree.
+func (t *Tree[T]) copyNode(n *node[T]) *node[T] {
+	t.restructured("copy")
+
+	c := t.newNode()
+	*c = *n
+	c.owner = t.owner
+	return c
+}
+
+// resize, the number of nodes in the subtree of n, the children must be up to date.
+func (n *node[T]) resize() {
+	n.size = 1
+	if n.left != nil {
+		n.size += n.left.size
+	}
+	if n.right != nil {
+		n.size += n.right.size
+	}
 }
 
 // InsertImmutable elements into the tree, returns the new Tree.
 // If an element is a duplicate, it replaces the previous element.
 func (t Tree[T]) InsertImmutable(items ...T) *Tree[T] {
+	t.mustCmp(len(items))
+
+	// owns no nodes, copy-on-write for all changed nodes
+	t.owner = 0
+
 	for i := range items {
-		t.root = t.insert(t.root, t.makeNode(items[i]), true)
+		t.root = t.insert(t.root, t.makeNode(items[i]))
 	}
+	t.changed()
+	t.inserted(items...)
 
 	return &t
 }
 
+// InsertStrict, same as [Tree.InsertImmutable] but all items are validated first with the compare function.
+// If the left point of an item is greater than its right point, no item is inserted and
+// an error wrapping [ErrMalformedInterval] is returned.
+//
+// Malformed intervals would break the augmentation invariants and the query results silently.
+func (t Tree[T]) InsertStrict(items ...T) (*Tree[T], error) {
+	if t.cmp == nil && len(items) > 0 {
+		return nil, ErrNoCompareFunc
+	}
+
+	for i := range items {
+		if !t.cmpValid(items[i]) {
+			return nil, fmt.Errorf("%w: %v", ErrMalformedInterval, items[i])
+		}
+	}
+
+	return t.InsertImmutable(items...), nil
+}
+
 // Insert inserts items into the tree, changing the original tree.
 // If the original tree does not need to be preserved then this is much faster than the immutable insert.
+//
+// Large bulk inserts into an empty tree are sorted and built bottom-up in O(n), large bulk inserts
+// into a non-empty tree are done concurrently if the tree is configured with [WithParallelism].
+// Otherwise for batches large compared to the tree, the augmented values are recalculated once
+// after the batch and not along each insert path.
+//
+// Deprecated: Tree is persistent, use [Tree.InsertImmutable] or [MutTree.Insert] for repeated in place changes.
+// For compatibility the receiver is still changed, the nodes shared with other trees,
+// e.g. with a [Tree.Mutable] copy, are copied first.
 func (t *Tree[T]) Insert(items ...T) {
+	t.detach()
+	t.insertInPlace(items...)
+}
+
+// insertInPlace, see Insert, the nodes owned by t are changed in place.
+func (t *Tree[T]) insertInPlace(items ...T) {
+	t.mustCmp(len(items))
+	t.acquire()
+
+	t.insertBulk(items)
+	t.changed()
+	t.inserted(items...)
+}
+
+// insertBulk, inserts the items in place with the best strategy for the batch,
+// without reporting to the hooks and metrics, see Insert.
+func (t *Tree[T]) insertBulk(items []T) {
+	// bulk insert into an empty tree, sort and build
+	if t.root == nil && len(items) >= minBuildSize {
+		t.root = t.buildUnsorted(items)
+		return
+	}
+
+	// bulk insert, fan out, see WithParallelism
+	if jobs := t.parallelism(); jobs > 1 && len(items) > minChunkSize {
+		t.insertConcurrent(jobs, items)
+		return
+	}
+
+	// large batch, the augmented values are recalculated once afterwards
+	if t.deferRecalc(len(items)) {
+		t.insertDeferred(items)
+		return
+	}
+
 	for i := range items {
-		t.root = t.insert(t.root, t.makeNode(items[i]), false)
+		t.root = t.insert(t.root, t.makeNode(items[i]))
 	}
 }
 
-// insert into tree, changing nodes are copied, new treap is returned, old treap is modified if immutable is false.
-func (t *Tree[T]) insert(n, m *node[T], immutable bool) *node[T] {
-	if n == nil {
-		return m
+// deferRecalc, returns true if the recalc of the augmented values along each insert path,
+// about k*log(n) recalcs, is more expensive than one fixup pass over up to n owned nodes.
+func (t *Tree[T]) deferRecalc(k int) bool {
+	if t.root == nil {
+		return false
 	}
+	n := t.root.size
+	return k*bits.Len(uint(n)) >= n
+}
 
-	// if m is the new root?
-	if m.prio >= n.prio {
-		//
-		//          m
-		//          | split t in ( <m | dupe? | >m )
-		//          v
-		//       t
-		//      / \
-		//    l     d(upe)
-		//   / \   / \
-		//  l   r l   r
-		//           /
-		//          l
-		//
-		l, dupe, r := t.split(n, m.item, immutable)
-
-		// replace dupe with m. m has same key but different prio than dupe, a join() is required
-		if dupe != nil {
-			return t.join(l, t.join(m, r, immutable), immutable)
-		}
+// insertDeferred, inserts the items without recalc of the augmented values along the insert paths,
+// the insert algorithm doesn't need them. The changed nodes are recalculated in one fixup pass.
+func (t *Tree[T]) insertDeferred(items []T) {
+	t.deferred = true
+	for i := range items {
+		t.root = t.insert(t.root, t.makeNode(items[i]))
+	}
+	t.deferred = false
 
-		// no duplicate, take m as new root
-		//
-		//     m
-		//   /  \
-		//  <m   >m
-		//
-		m.left, m.right = l, r
-		t.recalc(m)
-		return m
+	t.fixup(t.root)
+}
+
+// fixup rec-descent, recalc of all owned nodes bottom-up. Nodes not owned by the tree
+// are shared and unchanged, their subtrees are skipped.
+func (t *Tree[T]) fixup(n *node[T]) {
+	if n == nil || n.owner != t.owner {
+		return
 	}
 
-	cmp := t.compare(m.item, n.item)
-	if cmp == 0 {
-		// replace duplicate item with m, but m has different prio, a join() is required
-		return t.join(n.left, t.join(m, n.right, immutable), immutable)
+	t.fixup(n.left)
+	t.fixup(n.right)
+	t.recalc(n)
+}
+
+// InsertWithPriority inserts the item with the given priority instead of a random one, changing the original tree.
+// If the item is a duplicate, it replaces the previous element.
+//
+// Nodes with higher priorities are nearer to the root, the random priorities are uniformly distributed
+// in the uint32 range. Pin frequently looked-up intervals (e.g. default routes) with high priorities
+// near the root, in skewed lookup distributions this cuts the average descent depth.
+// Use with care, too many high priorities unbalance the tree.
+//
+// Deprecated: Tree is persistent, use [Tree.InsertImmutableWithPriority] or [MutTree.InsertWithPriority] for repeated in place changes.
+// For compatibility the receiver is still changed, the nodes shared with other trees,
+// e.g. with a [Tree.Mutable] copy, are copied first.
+func (t *Tree[T]) InsertWithPriority(item T, prio uint32) {
+	t.detach()
+	t.insertWithPriorityInPlace(item, prio)
+}
+
+// insertWithPriorityInPlace, see InsertWithPriority, the nodes owned by t are changed in place.
+func (t *Tree[T]) insertWithPriorityInPlace(item T, prio uint32) {
+	t.mustCmp(1)
+	t.acquire()
+
+	t.root = t.insert(t.root, t.makeNodeWithPriority(item, prio))
+	t.changed()
+	t.inserted(item)
+}
+
+// InsertImmutableWithPriority, same as [Tree.InsertWithPriority] but returns the new tree,
+// the receiver remains unchanged.
+func (t Tree[T]) InsertImmutableWithPriority(item T, prio uint32) *Tree[T] {
+	t.mustCmp(1)
+
+	// owns no nodes, copy-on-write for all changed nodes
+	t.owner = 0
+
+	t.root = t.insert(t.root, t.makeNodeWithPriority(item, prio))
+	t.changed()
+	t.inserted(item)
+
+	return &t
+}
+
+// maxPathLen, the capacity of the on-stack path buffers, a treap deeper than this is very unlikely.
+// If the path is longer, the buffer just grows on the heap.
+const maxPathLen = 64
+
+// insert into tree, changing nodes are copied if not owned by the tree, new treap is returned.
+//
+// Iterative descent with an explicit path of changed nodes, the augmented values are recalculated bottom-up.
+func (t *Tree[T]) insert(n, m *node[T]) *node[T] {
+	var buf [maxPathLen]*node[T]
+	path := buf[:0]
+
+	// hole is the link to be rewritten with the new subtree
+	root := n
+	hole := &root
+
+	for {
+		if n == nil {
+			// m as new leaf
+			*hole = m
+			break
+		}
+
+		// if m is the new root of this subtree?
+		if m.prio >= n.prio {
+			//
+			//          m
+			//          | split t in ( <m | dupe? | >m )
+			//          v
+			//       t
+			//      / \
+			//    l     d(upe)
+			//   / \   / \
+			//  l   r l   r
+			//           /
+			//          l
+			//
+			l, dupe, r := t.split(n, m.item)
+
+			// replace dupe with m. m has same key but different prio than dupe, a join() is required
+			if dupe != nil {
+				*hole = t.join(l, t.join(m, r))
+				break
+			}
+
+			// no duplicate, take m as new root
+			//
+			//     m
+			//   /  \
+			//  <m   >m
+			//
+			m.left, m.right = l, r
+			t.recalc(m)
+			*hole = m
+			break
+		}
+
+		cmp := t.compare(m.item, n.item)
+		if cmp == 0 {
+			// replace duplicate item with m, but m has different prio, a join() is required
+			*hole = t.join(n.left, t.join(m, n.right))
+			break
+		}
+
+		n = t.own(n)
+		*hole = n
+		path = append(path, n)
+
+		switch {
+		case cmp < 0: // descent
+			hole = &n.left
+			n = n.left
+			//
+			//       R
+			// m    l r
+			//     l   r
+			//
+		case cmp > 0: // descent
+			hole = &n.right
+			n = n.right
+			//
+			//   R
+			//  l r    m
+			// l   r
+			//
+		}
 	}
 
-	if immutable {
-		n = n.copyNode()
+	// nodes on path have changed, recalc bottom-up
+	for i := len(path) - 1; i >= 0; i-- {
+		t.recalc(path[i])
 	}
 
-	switch {
-	case cmp < 0: // rec-descent
-		n.left = t.insert(n.left, m, immutable)
-		//
-		//       R
-		// m    l r
-		//     l   r
-		//
-	case cmp > 0: // rec-descent
-		n.right = t.insert(n.right, m, immutable)
-		//
-		//   R
-		//  l r    m
-		// l   r
-		//
-	}
-
-	t.recalc(n) // node has changed, recalc
-	return n
+	return root
 }
 
 // DeleteImmutable removes an item if it exists, returns the new tree and true, false if not found.
 func (t Tree[T]) DeleteImmutable(item T) (*Tree[T], bool) {
-	// split/join must be immutable
-	l, m, r := t.split(t.root, item, true)
-	t.root = (&t).join(l, r, true)
+	// owns no nodes, split/join must copy all changed nodes
+	t.owner = 0
+
+	l, m, r := t.split(t.root, item)
+	t.root = (&t).join(l, r)
+	t.changed()
 
 	ok := m != nil
+	if ok {
+		t.deleted(m.item)
+	}
 	return &t, ok
 }
 
 // Delete removes an item from tree, returns true if it exists, false otherwise.
 // If the original tree does not need to be preserved then this is much faster than the immutable delete.
+//
+// Deprecated: Tree is persistent, use [Tree.DeleteImmutable] or [MutTree.Delete] for repeated in place changes.
+// For compatibility the receiver is still changed, the nodes shared with other trees,
+// e.g. with a [Tree.Mutable] copy, are copied first.
 func (t *Tree[T]) Delete(item T) bool {
-	l, m, r := t.split(t.root, item, false)
-	t.root = t.join(l, r, false)
+	t.detach()
+	return t.deleteInPlace(item)
+}
 
-	return m != nil
+// deleteInPlace, see Delete, the nodes owned by t are changed in place.
+func (t *Tree[T]) deleteInPlace(item T) bool {
+	t.acquire()
+
+	l, m, r := t.split(t.root, item)
+	t.root = t.join(l, r)
+	t.changed()
+
+	if m == nil {
+		return false
+	}
+	t.deleted(m.item)
+	return true
 }
 
 // Union combines any two trees. In case of duplicate items, the "overwrite" flag
 // controls whether the union keeps the original or whether it is replaced by the item in the other treap.
 //
-// The "immutable" flag controls whether the two trees are allowed to be modified.
+// The receiver is modified in place, the nodes of the other tree are copied as needed,
+// the other tree remains unchanged.
+//
+// If one tree is much smaller than the other, its items are just inserted into the bigger one.
 //
 // To create very large trees, it may be time-saving to slice the input data into chunks,
-// fan out for creation and combine the generated subtrees with non-immutable unions.
+// fan out for creation and combine the generated subtrees with unions, see [NewTreeConcurrent].
+//
+// Deprecated: Tree is persistent, use [Tree.UnionImmutable] or [MutTree.Union] for repeated in place changes.
+// For compatibility the receiver is still changed, the nodes shared with other trees,
+// e.g. with a [Tree.Mutable] copy, are copied first.
 func (t *Tree[T]) Union(other *Tree[T], overwrite bool) {
-	t.root = t.union(t.root, other.root, overwrite, false)
+	t.detach()
+	t.unionInPlace(other, overwrite)
+}
+
+// unionInPlace, see Union, the nodes owned by t are changed in place.
+func (t *Tree[T]) unionInPlace(other *Tree[T], overwrite bool) {
+	t.acquire()
+	t.adopt(other)
+	t.unioned(other, overwrite)
+	t.root = t.unionSized(t.root, other.root, overwrite, 0)
+	t.changed()
+}
+
+// UnionConcurrent, same as [Tree.Union] but the subtrees are combined concurrently with
+// up to jobs goroutines. Useful for the union of large trees (> 100_000).
+// A good value reference for jobs is the number of logical CPUs usable by the current process.
+//
+// Deprecated: Tree is persistent, use [Tree.UnionImmutableConcurrent] or [MutTree.UnionConcurrent] for repeated in place changes.
+// For compatibility the receiver is still changed, the nodes shared with other trees,
+// e.g. with a [Tree.Mutable] copy, are copied first.
+func (t *Tree[T]) UnionConcurrent(jobs int, other *Tree[T], overwrite bool) {
+	t.detach()
+	t.unionConcurrentInPlace(jobs, other, overwrite)
 }
 
+// unionConcurrentInPlace, see UnionConcurrent, the nodes owned by t are changed in place.
+func (t *Tree[T]) unionConcurrentInPlace(jobs int, other *Tree[T], overwrite bool) {
+	t.acquire()
+	t.adopt(other)
+	t.unioned(other, overwrite)
+	t.root = t.unionSized(t.root, other.root, overwrite, fanoutLevels(jobs))
+	t.changed()
+}
+
+// UnionImmutable combines any two trees, see [Tree.Union], returns the new tree.
+// The receiver and the other tree remain unchanged.
 func (t Tree[T]) UnionImmutable(other *Tree[T], overwrite bool) *Tree[T] {
-	t.root = t.union(t.root, other.root, overwrite, true)
+	// owns no nodes, copy-on-write for all changed nodes
+	t.owner = 0
+
+	t.adopt(other)
+	t.unioned(other, overwrite)
+	t.root = t.unionSized(t.root, other.root, overwrite, 0)
+	t.changed()
+
+	return &t
+}
+
+// UnionImmutableConcurrent, same as [Tree.UnionImmutable] but the subtrees are combined concurrently
+// with up to jobs goroutines, see [Tree.UnionConcurrent].
+func (t Tree[T]) UnionImmutableConcurrent(jobs int, other *Tree[T], overwrite bool) *Tree[T] {
+	// owns no nodes, copy-on-write for all changed nodes
+	t.owner = 0
+
+	t.adopt(other)
+	t.unioned(other, overwrite)
+	t.root = t.unionSized(t.root, other.root, overwrite, fanoutLevels(jobs))
+	t.changed()
+
 	return &t
 }
 
+// fanoutLevels, the number of tree levels to fan out for up to jobs goroutines.
+// The subtrees below these levels are too small for the goroutine overhead.
+func fanoutLevels(jobs int) (levels int) {
+	// limit the fan out, the treap is randomly balanced and 2^levels is
+	// the number of goroutines on the last fan out level
+	const maxLevels = 8
+
+	for j := 1; j < jobs && levels < maxLevels; j *= 2 {
+		levels++
+	}
+	return levels
+}
+
+// unionSized, same as union but the items of a much smaller treap are just inserted.
+func (t *Tree[T]) unionSized(n, m *node[T], overwrite bool, levels int) *node[T] {
+	switch {
+	case n == nil || m == nil:
+		return t.union(n, m, overwrite, levels)
+	case m.size*unionRatio < n.size:
+		return t.unionInsert(n, m, overwrite)
+	case n.size*unionRatio < m.size:
+		return t.unionInsert(m, n, !overwrite)
+	default:
+		return t.union(n, m, overwrite, levels)
+	}
+}
+
+// unionRatio, above this size ratio of the treaps the items of the smaller one are inserted,
+// splitting the big treap along the priorities of the tiny one is too expensive.
+const unionRatio = 256
+
+// unionInsert, the union of the treap n with the much smaller treap m, the items of m are inserted
+// into n with their priorities. In case of duplicates overwrite decides, like in union.
+func (t *Tree[T]) unionInsert(n, m *node[T], overwrite bool) *node[T] {
+	t.traverse(m, inorder, 0, func(c *node[T], _ int) bool {
+		if !overwrite && t.findIn(n, c.item) != nil {
+			return true
+		}
+		n = t.insert(n, t.makeNodeWithPriority(c.item, c.prio))
+		return true
+	})
+	return n
+}
+
 // union combines to treaps.
-func (t *Tree[T]) union(n, m *node[T], overwrite bool, immutable bool) *node[T] {
+//
+// For levels > 0 the left and right subtrees are combined concurrently,
+// the number of goroutines doubles with each level.
+func (t *Tree[T]) union(n, m *node[T], overwrite bool, levels int) *node[T] {
 	// recursion stop condition
 	if n == nil {
 		return m
@@ -264,22 +721,31 @@ func (t *Tree[T]) union(n, m *node[T], overwrite bool, immutable bool) *node[T]
 		overwrite = !overwrite
 	}
 
-	// immutable union, copy remaining root
-	if immutable {
-		n = n.copyNode()
-	}
+	// copy remaining root, if not owned
+	n = t.own(n)
 
 	// the treap with the lower priority is split with the root key in the treap with the higher priority
-	l, dupe, r := t.split(m, n.item, immutable)
+	l, dupe, r := t.split(m, n.item)
 
 	// the treaps may have duplicate items
 	if overwrite && dupe != nil {
 		n.item = dupe.item
 	}
 
-	// rec-descent
-	n.left = t.union(n.left, l, overwrite, immutable)
-	n.right = t.union(n.right, r, overwrite, immutable)
+	// rec-descent, the subtrees are disjunct
+	if levels > 0 {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n.left = t.union(n.left, l, overwrite, levels-1)
+		}()
+		n.right = t.union(n.right, r, overwrite, levels-1)
+		wg.Wait()
+	} else {
+		n.left = t.union(n.left, l, overwrite, 0)
+		n.right = t.union(n.right, r, overwrite, 0)
+	}
 	t.recalc(n)
 
 	return n
@@ -288,66 +754,100 @@ func (t *Tree[T]) union(n, m *node[T], overwrite bool, immutable bool) *node[T]
 // split the treap into all nodes that compare less-than, equal
 // and greater-than the provided item (BST key). The resulting nodes are
 // properly formed treaps or nil.
-// If the split must be immutable, first copy concerned nodes.
-func (t *Tree[T]) split(n *node[T], key T, immutable bool) (left, mid, right *node[T]) {
-	// recursion stop condition
-	if n == nil {
-		return nil, nil, nil
-	}
-
-	if immutable {
-		n = n.copyNode()
-	}
-
-	switch cmp := t.compare(n.item, key); {
-	case cmp < 0:
-		l, m, r := t.split(n.right, key, immutable)
-		n.right = l
-		t.recalc(n) // node has changed, recalc
-		return n, m, r
-		//
-		//       (k)
-		//      R
-		//     l r   ==> (R.r, m, r) = split(R.r, k)
-		//    l   r
-		//
-	case cmp > 0:
-		l, m, r := t.split(n.left, key, immutable)
-		n.left = r
-		t.recalc(n) // node has changed, recalc
-		return l, m, n
-		//
-		//   (k)
-		//      R
-		//     l r   ==> (l, m, R.l) = split(R.l, k)
-		//    l   r
-		//
-	default:
-		l, r := n.left, n.right
-		n.left, n.right = nil, nil
-		t.recalc(n) // node has changed, recalc
-		return l, n, r
-		//
-		//     (k)
-		//      R
-		//     l r   ==> (R.l, R, R.r)
-		//    l   r
-		//
+// Concerned nodes not owned by the tree are copied first.
+//
+// Iterative descent with an explicit path of changed nodes, the augmented values are recalculated bottom-up.
+func (t *Tree[T]) split(n *node[T], key T) (left, mid, right *node[T]) {
+	t.restructured("split")
+
+	var buf [maxPathLen]*node[T]
+	path := buf[:0]
+
+	// the links in the left and right treap to be rewritten next
+	lHole, rHole := &left, &right
+
+	for n != nil {
+		n = t.own(n)
+		path = append(path, n)
+
+		switch cmp := t.compare(n.item, key); {
+		case cmp < 0:
+			*lHole = n
+			lHole = &n.right
+			n = n.right
+			//
+			//       (k)
+			//      R
+			//     l r   ==> (R.r, m, r) = split(R.r, k)
+			//    l   r
+			//
+		case cmp > 0:
+			*rHole = n
+			rHole = &n.left
+			n = n.left
+			//
+			//   (k)
+			//      R
+			//     l r   ==> (l, m, R.l) = split(R.l, k)
+			//    l   r
+			//
+		default:
+			*lHole, *rHole = n.left, n.right
+			lHole, rHole = nil, nil
+			n.left, n.right = nil, nil
+			mid = n
+			n = nil
+			//
+			//     (k)
+			//      R
+			//     l r   ==> (R.l, R, R.r)
+			//    l   r
+			//
+		}
+	}
+
+	// key not found, terminate the left and right treap
+	if mid == nil {
+		*lHole, *rHole = nil, nil
+	}
+
+	// nodes on path have changed, recalc bottom-up
+	for i := len(path) - 1; i >= 0; i-- {
+		t.recalc(path[i])
 	}
+
+	return left, mid, right
 }
 
 // Find, searches for the exact interval in the tree and returns it as well as true,
 // otherwise the zero value for item is returned and false.
 func (t Tree[T]) Find(item T) (result T, ok bool) {
-	n := t.root
+	if t.metrics() != nil {
+		report := t.observe("Find")
+		defer func() { report(count(ok)) }()
+	}
+
+	if n := t.find(item); n != nil {
+		return n.item, true
+	}
+	return
+}
+
+// find, the node with the exact item or nil.
+func (t *Tree[T]) find(item T) *node[T] {
+	return t.findIn(t.root, item)
+}
+
+// findIn, same as find but in the treap n.
+func (t *Tree[T]) findIn(n *node[T], item T) *node[T] {
 	for {
 		if n == nil {
-			return
+			return nil
 		}
 
 		switch cmp := t.compare(item, n.item); {
 		case cmp == 0:
-			return n.item, true
+			return n
 		case cmp < 0:
 			n = n.left
 		case cmp > 0:
@@ -414,6 +914,11 @@ func (t Tree[T]) Find(item T) (result T, ok bool) {
 //	    tree.CoverLCP("10.0.1.17/32")       returns "10.0.1.0/24", true
 //	    tree.CoverLCP("2001:7c0:3100::/40") returns "2000::/3",    true
 func (t Tree[T]) CoverLCP(item T) (result T, ok bool) {
+	if t.metrics() != nil {
+		report := t.observe("CoverLCP")
+		defer func() { report(count(ok)) }()
+	}
+
 	return t.lcp(t.root, item)
 }
 
@@ -426,12 +931,12 @@ func (t *Tree[T]) lcp(n *node[T], item T) (result T, ok bool) {
 		}
 
 		// fast exit, node has too small max upper interval value (augmented value)
-		if t.cmpRR(item, n.maxUpper.item) > 0 {
+		if t.cmpRR(item, n.maxUpperItem()) > 0 {
 			// stop condition
 			return
 		}
 
-		cmp := t.compare(n.item, item)
+		cmp := t.compareIval(n.item, item)
 		if cmp == 0 {
 			// equality is always the shortest containing hull
 			return n.item, true
@@ -495,118 +1000,145 @@ func (t *Tree[T]) lcp(n *node[T], item T) (result T, ok bool) {
 //		 tree.CoverSCP(ival{3,7}) returns ival{1,8}, true
 //		 tree.CoverSCP(ival{6,9}) returns ival{},    false
 func (t Tree[T]) CoverSCP(item T) (result T, ok bool) {
-	l, m, _ := t.split(t.root, item, true)
-	result, ok = t.scp(l, item)
-
-	if !ok && m != nil {
-		return m.item, true
+	if t.metrics() != nil {
+		report := t.observe("CoverSCP")
+		defer func() { report(count(ok)) }()
 	}
 
-	return result, ok
+	return t.scp(t.root, item)
 }
 
 // scp rec-descent
 func (t *Tree[T]) scp(n *node[T], item T) (result T, ok bool) {
-	if n == nil {
-		return
-	}
+	for {
+		if n == nil {
+			// stop condition
+			return
+		}
 
-	// fast exit, node has too small max upper interval value (augmented value)
-	if t.cmpRR(item, n.maxUpper.item) > 0 {
-		return
-	}
+		// fast exit, node has too small max upper interval value (augmented value)
+		if t.cmpRR(item, n.maxUpperItem()) > 0 {
+			// stop condition
+			return
+		}
 
-	// SCP => left backtracking
-	if result, ok = t.scp(n.left, item); ok {
-		return result, ok
-	}
+		// n and the right subtree sort behind the item, can't cover it, go left
+		if t.compareIval(n.item, item) > 0 {
+			n = n.left
+			continue
+		}
 
-	// this item
-	if t.cmpCovers(n.item, item) {
-		return n.item, true
-	}
+		// SCP => left backtracking
+		if result, ok = t.scp(n.left, item); ok {
+			return result, ok
+		}
 
-	// right rec-descent
-	return t.scp(n.right, item)
+		// this item
+		if t.cmpCovers(n.item, item) {
+			return n.item, true
+		}
+
+		// right descent
+		n = n.right
+	}
 }
 
 // Covers returns all intervals that cover the item.
 // The returned intervals are in sorted order.
-func (t Tree[T]) Covers(item T) []T {
-	// split, reduce the search space
-	l, m, _ := t.split(t.root, item, true)
-	result := t.covers(l, item)
-
-	if m != nil {
-		return append(result, m.item)
+func (t Tree[T]) Covers(item T) (result []T) {
+	if t.metrics() != nil {
+		report := t.observe("Covers")
+		defer func() { report(len(result)) }()
 	}
 
+	t.covers(t.root, item, func(hit *T) {
+		result = append(result, *hit)
+	})
+
 	return result
 }
 
-// covers rec-descent
-func (t *Tree[T]) covers(n *node[T], item T) (result []T) {
+// covers rec-descent, calls fn for each interval that covers the item, in sorted order.
+// No split is needed, the query doesn't allocate temporary nodes.
+func (t *Tree[T]) covers(n *node[T], item T, fn func(*T)) {
 	if n == nil {
 		return
 	}
 
 	// nope, subtree has too small upper interval value
-	if t.cmpRR(item, n.maxUpper.item) > 0 {
+	if t.cmpRR(item, n.maxUpperItem()) > 0 {
 		return
 	}
 
 	// in-order traversal for supersets, recursive call to left tree
-	result = append(result, t.covers(n.left, item)...)
+	t.covers(n.left, item, fn)
+
+	// n and the right subtree sort behind the item, can't cover it
+	if t.compareIval(n.item, item) > 0 {
+		return
+	}
 
 	// n.item covers item
 	if t.cmpCovers(n.item, item) {
-		result = append(result, n.item)
+		fn(&n.item)
 	}
 
 	// recursive call to right tree
-	return append(result, t.covers(n.right, item)...)
+	t.covers(n.right, item, fn)
 }
 
 // CoveredBy returns all intervals that are covered by item.
 // The returned intervals are in sorted order.
-func (t Tree[T]) CoveredBy(item T) []T {
-	var result []T
-
-	// split, reduce the search space
-	_, m, r := t.split(t.root, item, true)
-
-	if m != nil {
-		result = append(result, m.item)
+func (t Tree[T]) CoveredBy(item T) (result []T) {
+	if t.metrics() != nil {
+		report := t.observe("CoveredBy")
+		defer func() { report(len(result)) }()
 	}
 
-	return append(result, t.coveredBy(r, item)...)
+	t.coveredBy(t.root, item, func(hit *T) {
+		result = append(result, *hit)
+	})
+
+	return result
 }
 
-// coveredBy rec-descent
-func (t *Tree[T]) coveredBy(n *node[T], item T) (result []T) {
+// coveredBy rec-descent, calls fn for each interval that is covered by item, in sorted order.
+// No split is needed, the query doesn't allocate temporary nodes.
+func (t *Tree[T]) coveredBy(n *node[T], item T, fn func(*T)) {
 	if n == nil {
 		return
 	}
 
 	// nope, subtree has too big upper interval value
-	if t.cmpRR(item, n.minUpper.item) < 0 {
+	if augmentMinUpper && t.cmpRR(item, n.minUpperItem()) < 0 {
+		return
+	}
+
+	// n and the left subtree sort before the item, can't be covered by it, go right
+	if t.compareIval(n.item, item) < 0 {
+		t.coveredBy(n.right, item, fn)
 		return
 	}
 
 	// in-order traversal for subsets, recursive call to left tree
-	result = append(result, t.coveredBy(n.left, item)...)
+	t.coveredBy(n.left, item, fn)
 
 	// item covers n.item
 	if t.cmpCovers(item, n.item) {
-		result = append(result, n.item)
+		fn(&n.item)
 	}
 
 	// recursive call to right tree
-	return append(result, t.coveredBy(n.right, item)...)
+	t.coveredBy(n.right, item, fn)
 }
 
 // Intersects returns true if any interval intersects item.
-func (t Tree[T]) Intersects(item T) bool {
+func (t Tree[T]) Intersects(item T) (ok bool) {
+	if t.metrics() != nil {
+		report := t.observe("Intersects")
+		defer func() { report(count(ok)) }()
+	}
+
 	return t.intersects(t.root, item)
 }
 
@@ -624,7 +1156,7 @@ func (t *Tree[T]) intersects(n *node[T], item T) bool {
 	// don't traverse this subtree, subtree has too small upper value for intersection
 	//         item -> |------|
 	// |-------------|  <- maxUpper
-	if t.cmpLR(item, n.maxUpper.item) > 0 {
+	if t.cmpLR(item, n.maxUpperItem()) > 0 {
 		return false
 	}
 
@@ -647,7 +1179,12 @@ func (t *Tree[T]) intersects(n *node[T], item T) bool {
 
 // Intersections returns all intervals that intersect with item.
 // The returned intervals are in sorted order.
-func (t Tree[T]) Intersections(item T) []T {
+func (t Tree[T]) Intersections(item T) (result []T) {
+	if t.metrics() != nil {
+		report := t.observe("Intersections")
+		defer func() { report(len(result)) }()
+	}
+
 	return t.intersections(t.root, item)
 }
 
@@ -660,7 +1197,7 @@ func (t *Tree[T]) intersections(n *node[T], item T) (result []T) {
 	// don't traverse this subtree, subtree has too small upper value for intersection
 	//         item -> |------|
 	// |-------------|  <- maxUpper
-	if t.cmpLR(item, n.maxUpper.item) > 0 {
+	if t.cmpLR(item, n.maxUpperItem()) > 0 {
 		return
 	}
 
@@ -696,10 +1233,13 @@ func (t *Tree[T]) intersections(n *node[T], item T) (result []T) {
 //	 D     |-----------------|
 //
 //	Precedes(item) => [D, B]
-func (t Tree[T]) Precedes(item T) []T {
-	// split, reduce the search space
-	l, _, _ := t.split(t.root, item, true)
-	return t.precedes(l, item)
+func (t Tree[T]) Precedes(item T) (result []T) {
+	if t.metrics() != nil {
+		report := t.observe("Precedes")
+		defer func() { report(len(result)) }()
+	}
+
+	return t.precedes(t.root, item)
 }
 
 // precedes rec-desent
@@ -709,10 +1249,15 @@ func (t *Tree[T]) precedes(n *node[T], item T) (result []T) {
 	}
 
 	// nope, all intervals in this subtree intersects with item
-	if t.cmpLR(item, n.minUpper.item) <= 0 {
+	if augmentMinUpper && t.cmpLR(item, n.minUpperItem()) <= 0 {
 		return
 	}
 
+	// n and the right subtree sort not before item, only the left subtree is left
+	if t.compareIval(n.item, item) >= 0 {
+		return t.precedes(n.left, item)
+	}
+
 	// recursive call to ...
 	result = append(result, t.precedes(n.left, item)...)
 
@@ -738,10 +1283,13 @@ func (t *Tree[T]) precedes(n *node[T], item T) (result []T) {
 //	 D                    |-----------------|
 //
 //	PrecededBy(item) => [B, D]
-func (t Tree[T]) PrecededBy(item T) []T {
-	// split, reduce the search space
-	_, _, r := t.split(t.root, item, true)
-	return t.precededBy(r, item)
+func (t Tree[T]) PrecededBy(item T) (result []T) {
+	if t.metrics() != nil {
+		report := t.observe("PrecededBy")
+		defer func() { report(len(result)) }()
+	}
+
+	return t.precededBy(t.root, item)
 }
 
 // precededBy rec-desent
@@ -750,82 +1298,80 @@ func (t *Tree[T]) precededBy(n *node[T], item T) (result []T) {
 		return
 	}
 
+	// n and the left subtree intersect or precede the item, only the right subtree is left
+	//
+	//   item -> |------------|
+	//     n.item -> |-------------|
+	if t.cmpRL(item, n.item) >= 0 {
+		return t.precededBy(n.right, item)
+	}
+
 	// recursive call to left
 	result = append(result, t.precededBy(n.left, item)...)
 
 	// this n.item
-	if !t.cmpIntersects(n.item, item) {
+	result = append(result, n.item)
+
+	// all items in the right subtree have a greater left point, all are preceded by item
+	t.traverse(n.right, inorder, 0, func(n *node[T], _ int) bool {
 		result = append(result, n.item)
-	}
+		return true
+	})
 
-	// recursive call to right
-	return append(result, t.precededBy(n.right, item)...)
+	return result
 }
 
 // join combines two disjunct treaps. All nodes in treap n have keys <= that of treap m
-// for this algorithm to work correctly. If the join must be immutable, first copy concerned nodes.
-func (t *Tree[T]) join(n, m *node[T], immutable bool) *node[T] {
-	// recursion stop condition
-	if n == nil {
-		return m
-	}
-	if m == nil {
-		return n
-	}
-
-	if n.prio > m.prio {
-		//     n
-		//    l r    m
-		//          l r
-		//
-		if immutable {
-			n = n.copyNode()
-		}
-		n.right = t.join(n.right, m, immutable)
-		t.recalc(n)
-		return n
-	} else {
-		//            m
-		//      n    l r
-		//     l r
-		//
-		if immutable {
-			m = m.copyNode()
+// for this algorithm to work correctly. Concerned nodes not owned by the tree are copied first.
+//
+// Iterative descent along the right spine of n and the left spine of m,
+// the augmented values are recalculated bottom-up.
+func (t *Tree[T]) join(n, m *node[T]) *node[T] {
+	t.restructured("join")
+
+	var buf [maxPathLen]*node[T]
+	path := buf[:0]
+
+	var root *node[T]
+	hole := &root
+
+	for n != nil && m != nil {
+		if n.prio > m.prio {
+			//     n
+			//    l r    m
+			//          l r
+			//
+			n = t.own(n)
+			path = append(path, n)
+
+			*hole = n
+			hole = &n.right
+			n = n.right
+		} else {
+			//            m
+			//      n    l r
+			//     l r
+			//
+			m = t.own(m)
+			path = append(path, m)
+
+			*hole = m
+			hole = &m.left
+			m = m.left
 		}
-		m.left = t.join(n, m.left, immutable)
-		t.recalc(m)
-		return m
 	}
-}
 
-// recalc the augmented fields in treap node after each creation/modification with values in descendants.
-// Only one level deeper must be considered. The treap datastructure is very easy to augment.
-func (t *Tree[T]) recalc(n *node[T]) {
-	if n == nil {
-		return
+	// stop condition, the rest of the other treap
+	if n != nil {
+		*hole = n
+	} else {
+		*hole = m
 	}
 
-	// start with upper min/max pointing to self
-	n.minUpper = n
-	n.maxUpper = n
-
-	if n.right != nil {
-		if t.cmpRR(n.minUpper.item, n.right.minUpper.item) > 0 {
-			n.minUpper = n.right.minUpper
-		}
-
-		if t.cmpRR(n.maxUpper.item, n.right.maxUpper.item) < 0 {
-			n.maxUpper = n.right.maxUpper
-		}
+	// nodes on path have changed, recalc bottom-up
+	for i := len(path) - 1; i >= 0; i-- {
+		t.recalc(path[i])
 	}
 
-	if n.left != nil {
-		if t.cmpRR(n.minUpper.item, n.left.minUpper.item) > 0 {
-			n.minUpper = n.left.minUpper
-		}
-
-		if t.cmpRR(n.maxUpper.item, n.left.maxUpper.item) < 0 {
-			n.maxUpper = n.left.maxUpper
-		}
-	}
+	return root
 }
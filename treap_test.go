@@ -1,12 +1,17 @@
 package interval_test
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"math"
 	"math/rand"
 	"reflect"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/gaissmai/interval"
@@ -258,6 +263,200 @@ func TestTreeWithDups(t *testing.T) {
 	}
 }
 
+func TestInsertBulkBuild(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(5_000)
+
+	// with duplicates, the last one wins
+	var items []taggedInterval
+	for i, ival := range ivals {
+		items = append(items, taggedInterval{ival: ival, tags: []string{"first"}})
+		if i%2 == 0 {
+			items = append(items, taggedInterval{ival: ival, tags: []string{"last"}})
+		}
+	}
+
+	var inserted int
+	bulk := interval.New(cmpTaggedInterval, interval.WithOnInsert(func(taggedInterval) { inserted++ }))
+	bulk.Insert(items...)
+
+	if err := bulk.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	if inserted != len(items) {
+		t.Fatalf("bulk insert, got %d insert hooks, want %d", inserted, len(items))
+	}
+
+	// one by one
+	single := interval.New(cmpTaggedInterval)
+	for _, item := range items {
+		single.Insert(item)
+	}
+
+	if !equalsSizeAndOrder(bulk, single) {
+		t.Fatal("bulk insert differs from single inserts")
+	}
+
+	for i, ival := range ivals {
+		got, _ := bulk.Find(taggedInterval{ival: ival})
+		if want := map[bool]string{true: "last", false: "first"}[i%2 == 0]; got.tags[0] != want {
+			t.Fatalf("bulk insert, duplicate %v, got %v, want %s", ival, got.tags, want)
+		}
+	}
+}
+
+func TestInsertDeferred(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(20_000)
+
+	// big batches into a small tree, with shared nodes
+	base := interval.New(cmpUintInterval)
+	base.Insert(ivals[:1_000]...)
+	clone := base.Clone()
+
+	clone.Insert(ivals[1_000:]...)
+	if err := clone.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := base.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	// single inserts, the same order
+	single := interval.New(cmpUintInterval)
+	for _, ival := range ivals {
+		single.Insert(ival)
+	}
+
+	if !equalsSizeAndOrder(clone, single) {
+		t.Fatal("deferred insert differs from single inserts")
+	}
+
+	// the queries use the augmented values
+	for _, probe := range genUintIvals(100) {
+		if !slices.Equal(clone.Intersections(probe), single.Intersections(probe)) {
+			t.Fatalf("Intersections(%v), deferred insert differs from single inserts", probe)
+		}
+	}
+}
+
+func TestFprintOptions(t *testing.T) {
+	t.Parallel()
+	tree1 := interval.NewTree(cmpUintInterval, ps...)
+
+	w := new(strings.Builder)
+	err := tree1.Fprint(w,
+		interval.WithASCII(),
+		interval.WithMaxDepth(2),
+		interval.WithFormatter(func(p uintInterval) string { return fmt.Sprintf("[%d,%d]", p[0], p[1]) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	asStr := `v
++- [0,6]
+|  ` + "`" + `- [0,5]
++- [1,8]
+|  +- [1,7]
+|  ` + "`" + `- [2,8]
+` + "`" + `- [7,9]
+`
+	if w.String() != asStr {
+		t.Errorf("Fprint(opts...)\nwant:\n%sgot:\n%s", asStr, w.String())
+	}
+
+	// formatter with wrong item type
+	if err := tree1.Fprint(io.Discard, interval.WithFormatter(func(s string) string { return s })); err == nil {
+		t.Error("Fprint(WithFormatter()) with mismatched type, got: nil, want: error")
+	}
+}
+
+func TestFprintMaxChildren(t *testing.T) {
+	t.Parallel()
+	tree := interval.NewTree(cmpUintInterval, ps...)
+
+	w := new(strings.Builder)
+	if err := tree.Fprint(w, interval.WithMaxChildren(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `▼
+├─ 0...6
+│  └─ 0...5
+└─ … (2 more)
+`
+	if w.String() != want {
+		t.Errorf("Fprint(WithMaxChildren(1))\nwant:\n%sgot:\n%s", want, w.String())
+	}
+
+	// combined with the depth limit and ASCII
+	w.Reset()
+	if err := tree.Fprint(w, interval.WithMaxChildren(2), interval.WithMaxDepth(2), interval.WithASCII()); err != nil {
+		t.Fatal(err)
+	}
+
+	want = `v
++- 0...6
+|  ` + "`" + `- 0...5
++- 1...8
+|  +- 1...7
+|  ` + "`" + `- 2...8
+` + "`" + `- ... (1 more)
+`
+	if w.String() != want {
+		t.Errorf("Fprint(WithMaxChildren(2), WithMaxDepth(2))\nwant:\n%sgot:\n%s", want, w.String())
+	}
+
+	// no marker if nothing is elided
+	w.Reset()
+	if err := tree.Fprint(w, interval.WithMaxChildren(3)); err != nil {
+		t.Fatal(err)
+	}
+	if w.String() != tree.String() {
+		t.Errorf("Fprint(WithMaxChildren(3))\nwant:\n%sgot:\n%s", tree.String(), w.String())
+	}
+}
+
+func TestWithStringer(t *testing.T) {
+	t.Parallel()
+
+	secret := func(p uintInterval) string { return fmt.Sprintf("%d-%d", p[0], p[1]) }
+
+	tree := interval.New(cmpUintInterval, interval.WithStringer(secret))
+	tree.Insert(uintInterval{0, 6}, uintInterval{1, 8}, uintInterval{1, 7})
+
+	want := "▼\n├─ 0-6\n└─ 1-8\n   └─ 1-7\n"
+	if got := tree.String(); got != want {
+		t.Errorf("String()\nwant:\n%sgot:\n%s", want, got)
+	}
+
+	// inherited by derived trees
+	if got := tree.InsertImmutable(uintInterval{7, 9}).String(); !strings.Contains(got, "7-9") {
+		t.Errorf("InsertImmutable().String(), stringer not used:\n%s", got)
+	}
+
+	w := new(strings.Builder)
+	if err := tree.FprintBST(w); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(w.String(), "1-8 [prio:") {
+		t.Errorf("FprintBST(), stringer not used:\n%s", w)
+	}
+
+	// the print option takes precedence
+	w.Reset()
+	if err := tree.Fprint(w, interval.WithFormatter(func(uintInterval) string { return "x" })); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(w.String(), "-") {
+		t.Errorf("Fprint(WithFormatter()), stringer used:\n%s", w)
+	}
+}
+
 func TestImmutable(t *testing.T) {
 	t.Parallel()
 	tree1 := interval.NewTree(cmpUintInterval, ps...)
@@ -316,6 +515,135 @@ func TestMutable(t *testing.T) {
 	}
 }
 
+func TestCloneCopyOnWrite(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(1_000)
+	want := interval.NewTree(cmpUintInterval, ivals...)
+
+	tree1 := interval.NewTree(cmpUintInterval, ivals...)
+	clone := tree1.Clone()
+
+	// modify the clone in place, the original must not change
+	clone.Insert(genUintIvals(100)...)
+	for _, item := range ivals[:100] {
+		clone.Delete(item)
+	}
+
+	if !equalsSizeAndOrder(tree1, want) {
+		t.Fatal("modifying the clone changed the original tree")
+	}
+
+	// modify the original in place, the clone must not change
+	wantClone := clone.Clone()
+	tree1.Insert(genUintIvals(100)...)
+	for _, item := range ivals[100:200] {
+		tree1.Delete(item)
+	}
+
+	if !equalsSizeAndOrder(clone, wantClone) {
+		t.Fatal("modifying the original tree changed the clone")
+	}
+
+	// in place modifications of an immutable derived tree must not change the base tree
+	tree2 := want.InsertImmutable(ps...)
+	tree2.Insert(genUintIvals(100)...)
+	tree2.Delete(ivals[0])
+	tree2.Union(clone, true)
+
+	if !equalsSizeAndOrder(want, interval.NewTree(cmpUintInterval, ivals...)) {
+		t.Fatal("modifying the derived tree changed the base tree")
+	}
+
+	if !equalsSizeAndOrder(clone, wantClone) {
+		t.Fatal("Union() changed the other tree")
+	}
+}
+
+// run with -race, clones of a tree that owns no nodes don't write the shared tree
+func TestCloneConcurrent(t *testing.T) {
+	t.Parallel()
+
+	ivals := genUintIvals(1_000)
+	want := interval.NewTree(cmpUintInterval, ivals...).String()
+
+	store := interval.NewStore(interval.NewTree(cmpUintInterval, ivals...))
+	derived := interval.NewTree(cmpUintInterval).InsertImmutable(ivals...)
+
+	for _, shared := range []*interval.Tree[uintInterval]{store.Load(), derived} {
+		var wg sync.WaitGroup
+		for i := range 16 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				// readers and cloners at once
+				_ = shared.Covers(ivals[i])
+
+				c := shared.Clone()
+				c.Insert(genUintIvals(10)...)
+				c.Delete(ivals[i])
+
+				if err := c.CheckInvariants(); err != nil {
+					t.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if got := shared.String(); got != want {
+			t.Fatal("concurrent clones changed the shared tree")
+		}
+	}
+}
+
+func TestInsertWithPriority(t *testing.T) {
+	t.Parallel()
+
+	tree1 := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+	pinned := uintInterval{0, math.MaxUint}
+
+	tree2 := tree1.InsertImmutableWithPriority(pinned, math.MaxUint32)
+	if _, ok := tree1.Find(pinned); ok {
+		t.Fatal("InsertImmutableWithPriority() changed the receiver")
+	}
+
+	// pinned item is root
+	w := new(strings.Builder)
+	_ = tree2.FprintBST(w)
+	if want := "R " + pinned.String() + " "; !strings.HasPrefix(w.String(), want) {
+		t.Fatalf("InsertImmutableWithPriority(), want root: %q, got: %q", want, w.String()[:40])
+	}
+
+	tree1.InsertWithPriority(pinned, math.MaxUint32)
+	if !equalsSizeAndOrder(tree1, tree2) {
+		t.Fatal("InsertWithPriority() differs with InsertImmutableWithPriority()")
+	}
+}
+
+func TestInsertStrict(t *testing.T) {
+	t.Parallel()
+
+	tree1 := interval.NewTree(cmpUintInterval, ps...)
+
+	tree2, err := tree1.InsertStrict(uintInterval{3, 3}, uintInterval{3, 4})
+	if err != nil {
+		t.Fatalf("InsertStrict(), unexpected error: %v", err)
+	}
+
+	if _, ok := tree2.Find(uintInterval{3, 4}); !ok {
+		t.Fatal("InsertStrict(), item not inserted")
+	}
+
+	if _, err := tree1.InsertStrict(uintInterval{5, 6}, uintInterval{4, 3}); !errors.Is(err, interval.ErrMalformedInterval) {
+		t.Fatalf("InsertStrict(), want: %v, got: %v", interval.ErrMalformedInterval, err)
+	}
+
+	if _, ok := tree1.Find(uintInterval{5, 6}); ok {
+		t.Fatal("InsertStrict() changed the receiver")
+	}
+}
+
 func TestFind(t *testing.T) {
 	t.Parallel()
 
@@ -493,6 +821,35 @@ func TestCoverSCP(t *testing.T) {
 	}
 }
 
+func TestCoverSCPAllocs(t *testing.T) {
+	tree1 := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+	probe := genUintIvals(1)[0]
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = tree1.CoverSCP(probe)
+	})
+
+	if allocs != 0 {
+		t.Errorf("CoverSCP(), want 0 allocs, got: %v", allocs)
+	}
+}
+
+func TestCoversAllocs(t *testing.T) {
+	tree1 := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+	probe := genUintIvals(1)[0]
+
+	// no temporary nodes, the results fit into buf
+	buf := make([]uintInterval, 0, 10_000)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = tree1.AppendCovers(buf[:0], probe)
+		buf = tree1.AppendCoveredBy(buf[:0], probe)
+	})
+
+	if allocs != 0 {
+		t.Errorf("Covers(), CoveredBy(), want 0 allocs, got: %v", allocs)
+	}
+}
+
 func TestCoveredBy(t *testing.T) {
 	t.Parallel()
 
@@ -777,6 +1134,22 @@ func TestPrecededBy(t *testing.T) {
 	}
 }
 
+func TestPrecedesAllocs(t *testing.T) {
+	tree1 := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+
+	// probe is preceded by nothing and precedes nothing
+	probe := uintInterval{0, math.MaxUint}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = tree1.Precedes(probe)
+		_ = tree1.PrecededBy(probe)
+	})
+
+	if allocs != 0 {
+		t.Errorf("Precedes() and PrecededBy(), want 0 allocs, got: %v", allocs)
+	}
+}
+
 func TestVisit(t *testing.T) {
 	t.Parallel()
 	tree1 := interval.NewTree(cmpUintInterval, ps...)
@@ -812,6 +1185,20 @@ func TestVisit(t *testing.T) {
 	})
 }
 
+func TestVisitAllocs(t *testing.T) {
+	tree1 := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+	start, stop := tree1.Min(), tree1.Max()
+
+	allocs := testing.AllocsPerRun(10, func() {
+		tree1.Visit(start, stop, func(uintInterval) bool { return true })
+		tree1.Visit(stop, start, func(uintInterval) bool { return true })
+	})
+
+	if allocs != 0 {
+		t.Errorf("Visit(), want 0 allocs, got: %v", allocs)
+	}
+}
+
 func TestMinMax(t *testing.T) {
 	t.Parallel()
 	tree1 := interval.NewTree(cmpUintInterval, ps...)
@@ -826,6 +1213,42 @@ func TestMinMax(t *testing.T) {
 	}
 }
 
+func TestMinMaxUpdate(t *testing.T) {
+	t.Parallel()
+	tree1 := interval.NewTree(cmpUintInterval, ps...)
+
+	// new min and max, immutable
+	tree2 := tree1.InsertImmutable(uintInterval{0, 10}, uintInterval{8, 9})
+	if want := (uintInterval{0, 10}); tree2.Min() != want {
+		t.Fatalf("Min(), want: %v, got: %v", want, tree2.Min())
+	}
+	if want := (uintInterval{8, 9}); tree2.Max() != want {
+		t.Fatalf("Max(), want: %v, got: %v", want, tree2.Max())
+	}
+
+	// receiver unchanged
+	if want := (uintInterval{0, 6}); tree1.Min() != want {
+		t.Fatalf("Min(), want: %v, got: %v", want, tree1.Min())
+	}
+
+	// delete min and max, in place
+	tree2.Delete(uintInterval{0, 10})
+	tree2.Delete(uintInterval{8, 9})
+	tree2.Delete(uintInterval{7, 9})
+	if want := (uintInterval{0, 6}); tree2.Min() != want {
+		t.Fatalf("Min(), want: %v, got: %v", want, tree2.Min())
+	}
+	if want := (uintInterval{6, 7}); tree2.Max() != want {
+		t.Fatalf("Max(), want: %v, got: %v", want, tree2.Max())
+	}
+
+	// union
+	tree2.Union(interval.NewTree(cmpUintInterval, uintInterval{11, 12}), false)
+	if want := (uintInterval{11, 12}); tree2.Max() != want {
+		t.Fatalf("Max(), want: %v, got: %v", want, tree2.Max())
+	}
+}
+
 func TestUnion(t *testing.T) {
 	t.Parallel()
 	tree1 := interval.NewTree(cmpUintInterval)
@@ -905,6 +1328,85 @@ func TestUnion(t *testing.T) {
 	}
 }
 
+func TestUnionConcurrent(t *testing.T) {
+	t.Parallel()
+
+	ivals1 := genUintIvals(100_000)
+	ivals2 := append(genUintIvals(100_000), ivals1[:1_000]...)
+
+	want := interval.NewTree(cmpUintInterval, ivals1...)
+	want.Union(interval.NewTree(cmpUintInterval, ivals2...), false)
+
+	tree1 := interval.NewTree(cmpUintInterval, ivals1...)
+	tree2 := interval.NewTree(cmpUintInterval, ivals2...)
+
+	got := tree1.UnionImmutableConcurrent(runtime.NumCPU(), tree2, false)
+	if !equalsSizeAndOrder(want, got) {
+		t.Fatal("UnionImmutableConcurrent() differs with Union()")
+	}
+
+	if !equalsSizeAndOrder(tree1, interval.NewTree(cmpUintInterval, ivals1...)) {
+		t.Fatal("UnionImmutableConcurrent() changed the receiver")
+	}
+
+	tree1.UnionConcurrent(runtime.NumCPU(), tree2, false)
+	if !equalsSizeAndOrder(want, tree1) {
+		t.Fatal("UnionConcurrent() differs with Union()")
+	}
+}
+
+func TestUnionAsymmetric(t *testing.T) {
+	t.Parallel()
+
+	big := genUintIvals(50_000)
+	small := append(genUintIvals(20), big[:10]...)
+
+	tag := func(ivals []uintInterval, tag string) (items []taggedInterval) {
+		for _, ival := range ivals {
+			items = append(items, taggedInterval{ival: ival, tags: []string{tag}})
+		}
+		return items
+	}
+
+	bigTree := interval.NewTree(cmpTaggedInterval, tag(big, "big")...)
+	smallTree := interval.NewTree(cmpTaggedInterval, tag(small, "small")...)
+
+	for _, overwrite := range []bool{false, true} {
+		for _, tt := range []struct {
+			name       string
+			this, that *interval.Tree[taggedInterval]
+		}{
+			{"big with small", bigTree, smallTree},
+			{"small with big", smallTree, bigTree},
+		} {
+			got := tt.this.UnionImmutable(tt.that, overwrite)
+			if err := got.CheckInvariants(); err != nil {
+				t.Fatalf("%s, overwrite=%v: %v", tt.name, overwrite, err)
+			}
+
+			if size, _, _, _ := got.Statistics(); size != len(big)+len(small)-10 {
+				t.Fatalf("%s, overwrite=%v, got size %d, want %d", tt.name, overwrite, size, len(big)+len(small)-10)
+			}
+
+			// the duplicates
+			want := tt.this.Min().tags[0]
+			if overwrite {
+				want = tt.that.Min().tags[0]
+			}
+			for _, ival := range big[:10] {
+				if item, _ := got.Find(taggedInterval{ival: ival}); item.tags[0] != want {
+					t.Fatalf("%s, overwrite=%v, duplicate %v, got %v, want %s", tt.name, overwrite, ival, item.tags, want)
+				}
+			}
+		}
+	}
+
+	// the receivers are unchanged
+	if size, _, _, _ := smallTree.Statistics(); size != len(small) {
+		t.Fatalf("UnionImmutable changed the receiver")
+	}
+}
+
 func TestStatistics(t *testing.T) {
 	t.Parallel()
 
@@ -935,6 +1437,84 @@ func TestStatistics(t *testing.T) {
 	}
 }
 
+func TestDepthHistogram(t *testing.T) {
+	t.Parallel()
+
+	var zero interval.Tree[uintInterval]
+	if got := zero.DepthHistogram(); len(got) != 0 {
+		t.Fatalf("DepthHistogram of empty tree, got %v", got)
+	}
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+	size, maxDepth, _, _ := tree.Statistics()
+
+	hist := tree.DepthHistogram()
+	if hist[0] != 1 {
+		t.Fatalf("DepthHistogram, got %d nodes with depth 0, want 1", hist[0])
+	}
+
+	sum := 0
+	for depth, n := range hist {
+		if depth > maxDepth || n > 1<<depth {
+			t.Fatalf("DepthHistogram, got %d nodes with depth %d, max depth %d", n, depth, maxDepth)
+		}
+		sum += n
+	}
+
+	if sum != size {
+		t.Fatalf("DepthHistogram, got %d nodes, want %d", sum, size)
+	}
+}
+
+func TestHeight(t *testing.T) {
+	t.Parallel()
+
+	var zero interval.Tree[uintInterval]
+	if got := zero.Height(); got != 0 {
+		t.Fatalf("Height of empty tree, got %d, want 0", got)
+	}
+
+	one := interval.NewTree(cmpUintInterval, uintInterval{1, 2})
+	if got := one.Height(); got != 1 {
+		t.Fatalf("Height of tree with one item, got %d, want 1", got)
+	}
+
+	n := 10_000
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(n)...)
+	_, maxDepth, _, _ := tree.Statistics()
+
+	if got := tree.Height(); got != maxDepth+1 {
+		t.Fatalf("Height, got %d, want maxDepth+1: %d", got, maxDepth+1)
+	}
+
+	if got, limit := tree.Height(), int(4*math.Log2(float64(n))); got > limit {
+		t.Fatalf("Height, got %d, want <= %d", got, limit)
+	}
+}
+
+func TestMemStats(t *testing.T) {
+	t.Parallel()
+
+	var zero interval.Tree[uintInterval]
+	nodes, empty := zero.MemStats()
+	if nodes != 0 || empty == 0 {
+		t.Fatalf("MemStats of empty tree, got %d nodes, %d bytes", nodes, empty)
+	}
+
+	tree := interval.NewTree(cmpUintInterval, genUintIvals(10_000)...)
+	size, _, _, _ := tree.Statistics()
+
+	nodes, bytes := tree.MemStats()
+	if nodes != size {
+		t.Fatalf("MemStats, got %d nodes, want %d", nodes, size)
+	}
+
+	// at least the two uints of the item per node
+	if perNode := (bytes - empty) / uintptr(nodes); perNode < 16 || (bytes-empty)%uintptr(nodes) != 0 {
+		t.Fatalf("MemStats, got %d bytes for %d nodes", bytes-empty, nodes)
+	}
+}
+
 func TestPrintBST(t *testing.T) {
 	t.Parallel()
 	tree1 := interval.NewTree(cmpUintInterval, ps...)
@@ -1061,3 +1641,143 @@ func TestMissing(t *testing.T) {
 		})
 	}
 }
+
+// sortedRef is the reference for the tree, the items sorted like in the tree,
+// left points ascending, supersets first.
+type sortedRef []uintInterval
+
+func (r sortedRef) compare(a, b uintInterval) int {
+	switch {
+	case a[0] != b[0]:
+		return cmpInt(a[0], b[0])
+	default:
+		return cmpInt(b[1], a[1])
+	}
+}
+
+func cmpInt(a, b uint) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func (r *sortedRef) insert(items ...uintInterval) {
+	for _, item := range items {
+		if i, found := slices.BinarySearchFunc(*r, item, r.compare); !found {
+			*r = slices.Insert(*r, i, item)
+		}
+	}
+}
+
+func (r *sortedRef) delete(item uintInterval) bool {
+	i, found := slices.BinarySearchFunc(*r, item, r.compare)
+	if found {
+		*r = slices.Delete(*r, i, i+1)
+	}
+	return found
+}
+
+// degenerate returns a tree with a left or right spine of n nodes, far deeper than
+// the preallocated path stacks. Increasing priorities make each new item the root.
+func degenerate(n int, leftSpine bool) (*interval.Tree[uintInterval], sortedRef) {
+	tree := interval.NewTree[uintInterval](cmpUintInterval)
+	var ref sortedRef
+
+	for i := range n {
+		lo := uint(i) * 10
+		if !leftSpine {
+			lo = uint(n-i) * 10
+		}
+		item := uintInterval{lo, lo + 1_000}
+		tree.InsertWithPriority(item, uint32(i+1))
+		ref.insert(item)
+	}
+	return tree, ref
+}
+
+func checkAgainstRef(t *testing.T, name string, tree *interval.Tree[uintInterval], ref sortedRef) {
+	t.Helper()
+
+	if err := tree.CheckInvariants(); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	if got := sortedItems(tree); !slices.Equal(got, ref) {
+		t.Fatalf("%s: items differ from the reference,\ngot:  %v\nwant: %v", name, got, []uintInterval(ref))
+	}
+}
+
+func TestDeepPaths(t *testing.T) {
+	t.Parallel()
+
+	const n = 500
+
+	for _, leftSpine := range []bool{true, false} {
+		name := fmt.Sprintf("leftSpine=%v", leftSpine)
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tree, ref := degenerate(n, leftSpine)
+			if got := tree.Height(); got != n {
+				t.Fatalf("Height of degenerate tree, got %d, want %d", got, n)
+			}
+			checkAgainstRef(t, "InsertWithPriority", tree, ref)
+
+			// the deepest items are at the end of the spine
+			deep := []uintInterval{{5, 1_000}, {15, 500}, {n*10 - 5, n * 10}, {n * 5, n*5 + 1}}
+
+			// clone and mutate, the original must not change
+			clone := tree.Clone()
+			cloneRef := slices.Clone(ref)
+
+			for _, item := range deep {
+				tree.Insert(item)
+				ref.insert(item)
+			}
+			checkAgainstRef(t, "Insert", tree, ref)
+
+			for _, item := range append(deep, ref[0], ref[len(ref)-1], ref[len(ref)/2]) {
+				if got, want := tree.Delete(item), ref.delete(item); got != want {
+					t.Fatalf("Delete(%v), got %v, want %v", item, got, want)
+				}
+			}
+			checkAgainstRef(t, "Delete", tree, ref)
+
+			checkAgainstRef(t, "Clone before mutation", clone, cloneRef)
+
+			for _, item := range deep[:2] {
+				clone.Insert(item)
+				cloneRef.insert(item)
+			}
+			clone.Delete(cloneRef[0])
+			cloneRef.delete(cloneRef[0])
+			checkAgainstRef(t, "Clone and mutate", clone, cloneRef)
+			checkAgainstRef(t, "original after mutation of the clone", tree, ref)
+
+			// immutable ops, split and join along the spine
+			imm := tree.InsertImmutable(deep...)
+			immRef := slices.Clone(ref)
+			immRef.insert(deep...)
+			checkAgainstRef(t, "InsertImmutable", imm, immRef)
+			checkAgainstRef(t, "original after InsertImmutable", tree, ref)
+
+			other, otherRef := degenerate(n, !leftSpine)
+			union := tree.UnionImmutable(other, false)
+			unionRef := slices.Clone(ref)
+			unionRef.insert(otherRef...)
+			checkAgainstRef(t, "UnionImmutable", union, unionRef)
+
+			for _, item := range otherRef[:n/2] {
+				var ok bool
+				if union, ok = union.DeleteImmutable(item); !ok {
+					t.Fatalf("DeleteImmutable(%v), got false, want true", item)
+				}
+				unionRef.delete(item)
+			}
+			checkAgainstRef(t, "DeleteImmutable", union, unionRef)
+		})
+	}
+}
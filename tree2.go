@@ -0,0 +1,76 @@
+package interval
+
+// Entry is an interval key with a payload, the element type of [Tree2].
+type Entry[K, V any] struct {
+	Key K
+	Val V
+}
+
+// Tree2 is an interval tree with keys of type K and payloads of type V.
+// Only the keys participate in the comparisons, the payload is just carried along.
+//
+// Putting a value under an existing key replaces the payload.
+type Tree2[K, V any] struct {
+	tree *Tree[Entry[K, V]]
+}
+
+// NewTree2 initializes an empty key/value interval tree with the compare function for the keys
+// and the options, see [New].
+func NewTree2[K, V any](cmp func(a, b K) (ll, rr, lr, rl int), opts ...Option) *Tree2[K, V] {
+	cmpEntry := func(a, b Entry[K, V]) (ll, rr, lr, rl int) {
+		return cmp(a.Key, b.Key)
+	}
+	return &Tree2[K, V]{tree: New(cmpEntry, opts...)}
+}
+
+// Put inserts the value under key in place, an existing payload for key is replaced.
+func (t *Tree2[K, V]) Put(key K, val V) {
+	t.tree.Insert(Entry[K, V]{key, val})
+}
+
+// Get returns the payload for key and true, or the zero value and false if key is not in the tree.
+func (t *Tree2[K, V]) Get(key K) (val V, ok bool) {
+	e, ok := t.tree.Find(Entry[K, V]{Key: key})
+	return e.Val, ok
+}
+
+// DeleteKey removes key and its payload in place, returns true if the key exists, false otherwise.
+func (t *Tree2[K, V]) DeleteKey(key K) bool {
+	return t.tree.Delete(Entry[K, V]{Key: key})
+}
+
+// CoverLCP returns the entry with the longest-prefix-match key that covers key.
+func (t *Tree2[K, V]) CoverLCP(key K) (result Entry[K, V], ok bool) {
+	return t.tree.CoverLCP(Entry[K, V]{Key: key})
+}
+
+// CoverSCP returns the entry with the shortest-prefix-match key that covers key.
+func (t *Tree2[K, V]) CoverSCP(key K) (result Entry[K, V], ok bool) {
+	return t.tree.CoverSCP(Entry[K, V]{Key: key})
+}
+
+// Covers returns all entries with keys that cover key, in sorted order.
+func (t *Tree2[K, V]) Covers(key K) []Entry[K, V] {
+	return t.tree.Covers(Entry[K, V]{Key: key})
+}
+
+// CoveredBy returns all entries with keys that are covered by key, in sorted order.
+func (t *Tree2[K, V]) CoveredBy(key K) []Entry[K, V] {
+	return t.tree.CoveredBy(Entry[K, V]{Key: key})
+}
+
+// Intersects returns true if any key intersects key.
+func (t *Tree2[K, V]) Intersects(key K) bool {
+	return t.tree.Intersects(Entry[K, V]{Key: key})
+}
+
+// Intersections returns all entries with keys that intersect with key, in sorted order.
+func (t *Tree2[K, V]) Intersections(key K) []Entry[K, V] {
+	return t.tree.Intersections(Entry[K, V]{Key: key})
+}
+
+// Tree returns the underlying tree of entries for all other methods, e.g. Visit or Fprint.
+// Changes of the returned tree are changes of t.
+func (t *Tree2[K, V]) Tree() *Tree[Entry[K, V]] {
+	return t.tree
+}
@@ -0,0 +1,51 @@
+package interval_test
+
+import (
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestTree2(t *testing.T) {
+	t.Parallel()
+
+	tree := interval.NewTree2[uintInterval, string](cmpUintInterval)
+
+	for _, key := range ps {
+		tree.Put(key, key.String())
+	}
+
+	// replace payload, same key
+	tree.Put(ps[0], "replaced")
+
+	if size, _, _, _ := tree.Tree().Statistics(); size != len(ps) {
+		t.Fatalf("Put() with same key, size got: %d, want: %d", size, len(ps))
+	}
+
+	if val, ok := tree.Get(ps[0]); !ok || val != "replaced" {
+		t.Fatalf("Get(%v), got: (%q, %v), want: (%q, true)", ps[0], val, ok, "replaced")
+	}
+
+	if val, ok := tree.Get(ps[1]); !ok || val != ps[1].String() {
+		t.Fatalf("Get(%v), got: (%q, %v), want: (%q, true)", ps[1], val, ok, ps[1].String())
+	}
+
+	probe := uintInterval{3, 7}
+	lcp, ok := tree.CoverLCP(probe)
+	want, wantOK := interval.NewTree(cmpUintInterval, ps...).CoverLCP(probe)
+	if ok != wantOK || lcp.Key != want || lcp.Val != want.String() {
+		t.Fatalf("CoverLCP(%v), got: (%v, %v), want: (%v, %v)", probe, lcp, ok, want, wantOK)
+	}
+
+	if !tree.DeleteKey(ps[1]) {
+		t.Fatalf("DeleteKey(%v), got: false, want: true", ps[1])
+	}
+
+	if _, ok := tree.Get(ps[1]); ok {
+		t.Fatalf("Get(%v) after DeleteKey, got: true, want: false", ps[1])
+	}
+
+	if tree.DeleteKey(ps[1]) {
+		t.Fatalf("DeleteKey(%v) twice, got: true, want: false", ps[1])
+	}
+}
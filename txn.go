@@ -0,0 +1,92 @@
+package interval
+
+import "errors"
+
+// ErrTxnDone is returned by [Txn.Commit] if the transaction is already committed or aborted,
+// the mutations of a done transaction panic with ErrTxnDone.
+var ErrTxnDone = errors.New("interval: transaction already committed or aborted")
+
+// Txn is a batch of mutations on a [Store], published as a single new version on commit.
+// Concurrent readers of the store never observe half-applied batches.
+//
+// The mutations are applied to a private copy of the version at [Store.Begin], the transaction
+// sees its own writes. On commit the private tree is published if the store is still at the
+// begin version, otherwise the mutations are replayed on the newest version.
+//
+// A Txn must not be used concurrently and not for mutations after [Txn.Commit] or [Txn.Abort].
+type Txn[T any] struct {
+	store *Store[T]
+	base  *Version[T]      // version at begin
+	tree  Tree[T]          // private copy with all mutations applied
+	ops   []func(*Tree[T]) // the mutations, for the replay
+	done  bool
+}
+
+// Begin starts a transaction from the current version, it must not be nil.
+func (s *Store[T]) Begin() *Txn[T] {
+	base := s.p.Load()
+	return &Txn[T]{store: s, base: base, tree: *base.Tree.Clone()}
+}
+
+// apply the mutation to the private tree and queue it for the replay.
+// Panics if the transaction is done, the committed tree is published.
+func (x *Txn[T]) apply(op func(*Tree[T])) {
+	if x.done {
+		panic(ErrTxnDone)
+	}
+	op(&x.tree)
+	x.ops = append(x.ops, op)
+}
+
+// Insert queues the insertion of the items.
+func (x *Txn[T]) Insert(items ...T) {
+	x.apply(func(t *Tree[T]) { t.Insert(items...) })
+}
+
+// Delete queues the deletion of item, returns true if the item exists in the transaction view.
+func (x *Txn[T]) Delete(item T) bool {
+	var ok bool
+	op := func(t *Tree[T]) { ok = t.Delete(item) }
+	x.apply(op)
+	return ok
+}
+
+// Union queues the union with other, see [Tree.Union].
+func (x *Txn[T]) Union(other *Tree[T], overwrite bool) {
+	x.apply(func(t *Tree[T]) { t.Union(other, overwrite) })
+}
+
+// Tree returns the transaction view with all queued mutations applied, treat it as read-only.
+func (x *Txn[T]) Tree() *Tree[T] {
+	return &x.tree
+}
+
+// Commit publishes all mutations as a single new version of the store.
+func (x *Txn[T]) Commit() error {
+	if x.done {
+		return ErrTxnDone
+	}
+	x.done = true
+
+	// fast path, no concurrent writer since begin, the published copy owns no nodes
+	next := x.tree
+	if x.store.cas(x.base, &next, "") {
+		return nil
+	}
+
+	// replay the mutations on the newest version
+	x.store.Update(func(t Tree[T]) Tree[T] {
+		for _, op := range x.ops {
+			op(&t)
+		}
+		return t
+	})
+
+	return nil
+}
+
+// Abort discards all mutations.
+func (x *Txn[T]) Abort() {
+	x.done = true
+	x.ops = nil
+}
@@ -0,0 +1,117 @@
+package interval_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestTxn(t *testing.T) {
+	t.Parallel()
+
+	s := interval.NewStore(interval.NewTree(cmpUintInterval, ps[:5]...))
+	want := s.Load().String()
+
+	// abort
+	txn := s.Begin()
+	txn.Insert(ps[5:]...)
+	txn.Abort()
+
+	if s.Load().String() != want {
+		t.Fatal("Abort() changed the store")
+	}
+
+	if err := txn.Commit(); !errors.Is(err, interval.ErrTxnDone) {
+		t.Fatalf("Commit() after Abort(), got: %v, want: ErrTxnDone", err)
+	}
+
+	// commit
+	txn = s.Begin()
+	txn.Insert(ps[5:]...)
+
+	if !txn.Delete(ps[0]) {
+		t.Fatalf("Delete(%v) in transaction, got: false, want: true", ps[0])
+	}
+
+	if _, ok := txn.Tree().Find(ps[0]); ok {
+		t.Fatal("transaction view must see its own writes")
+	}
+
+	if s.Load().String() != want {
+		t.Fatal("uncommitted transaction is visible")
+	}
+
+	if err := txn.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !equalsSizeAndOrder(s.Load(), interval.NewTree(cmpUintInterval, ps[1:]...)) {
+		t.Fatal("Commit(), wrong version published")
+	}
+
+	// conflict, replay on the newer version
+	txn = s.Begin()
+	txn.Union(interval.NewTree(cmpUintInterval, uintInterval{100, 200}), false)
+
+	s.Update(func(tree interval.Tree[uintInterval]) interval.Tree[uintInterval] {
+		tree.Insert(ps[0])
+		return tree
+	})
+
+	if err := txn.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	all := interval.NewTree(cmpUintInterval, ps...)
+	all.Insert(uintInterval{100, 200})
+
+	if !equalsSizeAndOrder(s.Load(), all) {
+		t.Fatal("Commit() after concurrent Update(), mutations lost")
+	}
+}
+
+func TestTxnDone(t *testing.T) {
+	t.Parallel()
+
+	s := interval.NewStore(interval.NewTree(cmpUintInterval, ps[1:]...))
+
+	txn := s.Begin()
+	txn.Insert(ps[0])
+	if err := txn.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	published := s.Load()
+	want := published.String()
+
+	mustPanic := func(name string, op func()) {
+		t.Helper()
+		defer func() {
+			if r := recover(); r != interval.ErrTxnDone {
+				t.Fatalf("%s after Commit, got panic: %v, want: %v", name, r, interval.ErrTxnDone)
+			}
+		}()
+		op()
+	}
+
+	mustPanic("Insert", func() { txn.Insert(uintInterval{100, 200}) })
+	mustPanic("Delete", func() { txn.Delete(ps[0]) })
+	mustPanic("Union", func() { txn.Union(interval.NewTree(cmpUintInterval, uintInterval{300, 400}), false) })
+
+	if s.Load() != published || published.String() != want {
+		t.Fatal("mutation after Commit changed the published version")
+	}
+
+	if err := published.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := txn.Commit(); !errors.Is(err, interval.ErrTxnDone) {
+		t.Fatalf("second Commit, got: %v, want: %v", err, interval.ErrTxnDone)
+	}
+
+	aborted := s.Begin()
+	aborted.Abort()
+	mustPanic("Insert", func() { aborted.Insert(uintInterval{100, 200}) })
+}
@@ -0,0 +1,94 @@
+package interval
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Bound is an endpoint of an interval that may be unbounded, −∞ or +∞, e.g. "from date X onwards"
+// is the interval [X, +∞). No fake infinity values of the point type are needed.
+//
+// The zero value is the finite bound at the zero value of P.
+type Bound[P any] struct {
+	inf int8 // -1: −∞, +1: +∞, 0: finite
+	p   P
+}
+
+// Finite returns the bound at point p.
+func Finite[P any](p P) Bound[P] {
+	return Bound[P]{p: p}
+}
+
+// NegInf returns the bound −∞, less than all finite bounds.
+func NegInf[P any]() Bound[P] {
+	return Bound[P]{inf: -1}
+}
+
+// PosInf returns the bound +∞, greater than all finite bounds.
+func PosInf[P any]() Bound[P] {
+	return Bound[P]{inf: +1}
+}
+
+// Point returns the point of a finite bound, ok is false for −∞ and +∞.
+func (b Bound[P]) Point() (p P, ok bool) {
+	return b.p, b.inf == 0
+}
+
+// IsNegInf returns true if the bound is −∞.
+func (b Bound[P]) IsNegInf() bool {
+	return b.inf < 0
+}
+
+// IsPosInf returns true if the bound is +∞.
+func (b Bound[P]) IsPosInf() bool {
+	return b.inf > 0
+}
+
+// String implements fmt.Stringer, the infinite bounds are printed as −∞ and +∞.
+func (b Bound[P]) String() string {
+	switch {
+	case b.inf < 0:
+		return "−∞"
+	case b.inf > 0:
+		return "+∞"
+	default:
+		return fmt.Sprint(b.p)
+	}
+}
+
+// CompareBounds returns the compare function for bounds, derived from the compare function
+// for two finite points, returning -1, 0 or +1. Use it with [NewTreeFromLowerUpper]:
+//
+//	type validity struct{ from, until interval.Bound[time.Time] }
+//
+//	tree := interval.NewTreeFromLowerUpper(
+//		func(v validity) interval.Bound[time.Time] { return v.from },
+//		func(v validity) interval.Bound[time.Time] { return v.until },
+//		interval.CompareBounds(time.Time.Compare),
+//		items...)
+func CompareBounds[P any](cmpPoint func(a, b P) int) func(a, b Bound[P]) int {
+	return func(a, b Bound[P]) int {
+		if a.inf != 0 || b.inf != 0 {
+			return cmp.Compare(a.inf, b.inf)
+		}
+		return cmpPoint(a.p, b.p)
+	}
+}
+
+// NewTreeOfBounds initializes the interval tree with simple intervals of type [2]Bound[P],
+// see [NewTreeOf], the intervals may be unbounded on either side:
+//
+//	tree := interval.NewTreeOfBounds(
+//		[2]interval.Bound[int]{interval.NegInf[int](), interval.Finite(0)},
+//		[2]interval.Bound[int]{interval.Finite(42), interval.PosInf[int]()},
+//	)
+func NewTreeOfBounds[P cmp.Ordered](items ...[2]Bound[P]) *Tree[[2]Bound[P]] {
+	cmpBound := CompareBounds(cmp.Compare[P])
+
+	return NewTree(func(a, b [2]Bound[P]) (ll, rr, lr, rl int) {
+		return cmpBound(a[0], b[0]),
+			cmpBound(a[1], b[1]),
+			cmpBound(a[0], b[1]),
+			cmpBound(a[1], b[0])
+	}, items...)
+}
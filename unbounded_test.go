@@ -0,0 +1,89 @@
+package interval_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestNewTreeOfBounds(t *testing.T) {
+	t.Parallel()
+
+	type ival = [2]interval.Bound[int]
+
+	neg, pos, at := interval.NegInf[int](), interval.PosInf[int](), interval.Finite[int]
+
+	all := ival{neg, pos}
+	upTo0 := ival{neg, at(0)}
+	from42 := ival{at(42), pos}
+	mid := ival{at(10), at(20)}
+
+	tree := interval.NewTreeOfBounds(mid, from42, upTo0, all)
+
+	if err := tree.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		probe         ival
+		covers        []ival
+		intersections []ival
+	}{
+		{ival{at(-1_000), at(-1_000)}, []ival{all, upTo0}, []ival{all, upTo0}},
+		{ival{at(15), at(15)}, []ival{all, mid}, []ival{all, mid}},
+		{ival{at(1_000), at(1_000)}, []ival{all, from42}, []ival{all, from42}},
+		{ival{at(0), at(42)}, []ival{all}, []ival{all, upTo0, mid, from42}},
+		{ival{at(50), pos}, []ival{all, from42}, []ival{all, from42}},
+		{ival{neg, pos}, []ival{all}, []ival{all, upTo0, mid, from42}},
+	}
+
+	for _, tt := range tests {
+		if got := tree.Covers(tt.probe); !slices.Equal(got, tt.covers) {
+			t.Errorf("Covers(%v), got %v, want %v", tt.probe, got, tt.covers)
+		}
+		if got := tree.Intersections(tt.probe); !slices.Equal(got, tt.intersections) {
+			t.Errorf("Intersections(%v), got %v, want %v", tt.probe, got, tt.intersections)
+		}
+	}
+
+	want := "▼\n└─ [−∞ +∞]\n   ├─ [−∞ 0]\n   ├─ [10 20]\n   └─ [42 +∞]\n"
+	if got := tree.String(); got != want {
+		t.Errorf("String()\nwant:\n%sgot:\n%s", want, got)
+	}
+
+	if p, ok := from42[0].Point(); !ok || p != 42 {
+		t.Errorf("Point(), got %v, %v, want 42, true", p, ok)
+	}
+	if _, ok := from42[1].Point(); ok || !from42[1].IsPosInf() || !upTo0[0].IsNegInf() {
+		t.Errorf("IsPosInf(), IsNegInf(), unexpected result")
+	}
+}
+
+func TestCompareBounds(t *testing.T) {
+	t.Parallel()
+
+	type validity struct{ from, until interval.Bound[time.Time] }
+
+	day := func(d int) interval.Bound[time.Time] {
+		return interval.Finite(time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC))
+	}
+
+	onwards := validity{day(10), interval.PosInf[time.Time]()}
+	closed := validity{day(1), day(5)}
+
+	tree := interval.NewTreeFromLowerUpper(
+		func(v validity) interval.Bound[time.Time] { return v.from },
+		func(v validity) interval.Bound[time.Time] { return v.until },
+		interval.CompareBounds(time.Time.Compare),
+		onwards, closed)
+
+	if got, ok := tree.CoverLCP(validity{day(20), day(20)}); !ok || got != onwards {
+		t.Errorf("CoverLCP, got %v, %v, want %v", got, ok, onwards)
+	}
+
+	if tree.Intersects(validity{day(6), day(9)}) {
+		t.Errorf("Intersects in the gap, got true")
+	}
+}
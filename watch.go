@@ -0,0 +1,159 @@
+package interval
+
+import (
+	"context"
+	"sync"
+)
+
+// ChangeOp is the kind of change in a [ChangeEvent].
+type ChangeOp uint8
+
+const (
+	ChangeInsert ChangeOp = iota + 1 // item is in the new version, but not in the old one
+	ChangeDelete                     // item is in the old version, but not in the new one
+)
+
+// String implements fmt.Stringer.
+func (op ChangeOp) String() string {
+	switch op {
+	case ChangeInsert:
+		return "insert"
+	case ChangeDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// ChangeEvent is an item inserted or deleted by the publication of version Seq.
+type ChangeEvent[T any] struct {
+	Op   ChangeOp
+	Item T
+	Seq  uint64
+}
+
+// transition, from old to new version.
+type transition[T any] struct {
+	prev, next *Version[T]
+}
+
+// watcher, a subscription with a queue of pending transitions.
+type watcher[T any] struct {
+	filter T
+	ch     chan ChangeEvent[T]
+	notify chan struct{} // cap 1, pending transitions available
+
+	mu      sync.Mutex
+	pending []transition[T]
+}
+
+// Watch returns a channel with the insert and delete events intersecting the filter interval,
+// for every version published after the call. The events of a version are in sorted order,
+// the versions in ascending order. The channel is closed when ctx is done.
+//
+// The publishers are never blocked by slow watchers, the pending versions are queued.
+// Replaced duplicates are no changes, only the keys of the items are compared.
+func (s *Store[T]) Watch(ctx context.Context, filter T) <-chan ChangeEvent[T] {
+	w := &watcher[T]{
+		filter: filter,
+		ch:     make(chan ChangeEvent[T]),
+		notify: make(chan struct{}, 1),
+	}
+
+	s.mu.Lock()
+	if s.watchers == nil {
+		s.watchers = make(map[*watcher[T]]struct{})
+	}
+	s.watchers[w] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		defer close(w.ch)
+		defer func() {
+			s.mu.Lock()
+			delete(s.watchers, w)
+			s.mu.Unlock()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.notify:
+			}
+
+			w.mu.Lock()
+			pending := w.pending
+			w.pending = nil
+			w.mu.Unlock()
+
+			for _, tr := range pending {
+				for _, ev := range diffWithin(tr.prev, tr.next, filter) {
+					select {
+					case w.ch <- ev:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return w.ch
+}
+
+// enqueue the transition for all watchers, s.mu must be held.
+// The transitions are enqueued in Seq order, see record.
+func (s *Store[T]) enqueue(prev, next *Version[T]) {
+	for w := range s.watchers {
+		w.mu.Lock()
+		w.pending = append(w.pending, transition[T]{prev, next})
+		w.mu.Unlock()
+
+		select {
+		case w.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// diffWithin, the changes from prev to next version for the items intersecting the filter.
+func diffWithin[T any](prev, next *Version[T], filter T) (events []ChangeEvent[T]) {
+	var as, bs []T
+	var t *Tree[T] // for the compare function
+
+	if prev != nil && prev.Tree != nil {
+		t = prev.Tree
+		as = t.Intersections(filter)
+	}
+	if next.Tree != nil {
+		t = next.Tree
+		bs = t.Intersections(filter)
+	}
+
+	// merge the sorted items
+	for len(as) > 0 || len(bs) > 0 {
+		var c int
+		switch {
+		case len(as) == 0:
+			c = 1
+		case len(bs) == 0:
+			c = -1
+		default:
+			c = t.compare(as[0], bs[0])
+		}
+
+		switch {
+		case c < 0:
+			events = append(events, ChangeEvent[T]{ChangeDelete, as[0], next.Seq})
+			as = as[1:]
+		case c > 0:
+			events = append(events, ChangeEvent[T]{ChangeInsert, bs[0], next.Seq})
+			bs = bs[1:]
+		default:
+			as, bs = as[1:], bs[1:]
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,85 @@
+package interval_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+
+	s := interval.NewStore(interval.NewTree(cmpUintInterval, ps...))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := s.Watch(ctx, uintInterval{0, 3})
+
+	// outside the filter, no events
+	s.Update(func(tree interval.Tree[uintInterval]) interval.Tree[uintInterval] {
+		tree.Insert(uintInterval{20, 30})
+		return tree
+	})
+
+	s.Update(func(tree interval.Tree[uintInterval]) interval.Tree[uintInterval] {
+		tree.Insert(uintInterval{3, 4})
+		tree.Delete(uintInterval{0, 5})
+		return tree
+	})
+
+	want := []interval.ChangeEvent[uintInterval]{
+		{Op: interval.ChangeDelete, Item: uintInterval{0, 5}, Seq: 2},
+		{Op: interval.ChangeInsert, Item: uintInterval{3, 4}, Seq: 2},
+	}
+
+	var got []interval.ChangeEvent[uintInterval]
+	for len(got) < len(want) {
+		got = append(got, <-events)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Watch(), got: %v, want: %v", got, want)
+	}
+
+	cancel()
+	for range events {
+		// drain until closed
+	}
+}
+
+func TestWatchConcurrentPublishers(t *testing.T) {
+	t.Parallel()
+
+	s := interval.NewStore(interval.NewTree(cmpUintInterval))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := s.Watch(ctx, uintInterval{0, 1_000})
+
+	const publishers, updates = 8, 50
+
+	var wg sync.WaitGroup
+	for p := range publishers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range updates {
+				item := uintInterval{uint(p*updates + i), uint(p*updates + i)}
+				s.Update(func(tree interval.Tree[uintInterval]) interval.Tree[uintInterval] {
+					return *tree.InsertImmutable(item)
+				})
+			}
+		}()
+	}
+
+	// one insert event per version, in Seq order
+	for want := uint64(1); want <= publishers*updates; want++ {
+		ev := <-events
+		if ev.Seq != want || ev.Op != interval.ChangeInsert {
+			t.Fatalf("Watch(), got event %v with Seq %d, want insert with Seq %d", ev.Item, ev.Seq, want)
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,74 @@
+package interval_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/gaissmai/interval"
+)
+
+func TestZeroTree(t *testing.T) {
+	t.Parallel()
+
+	var zero interval.Tree[uintInterval]
+	probe := uintInterval{1, 5}
+
+	if _, ok := zero.Find(probe); ok {
+		t.Error("zero tree, Find, got true, want false")
+	}
+	if _, ok := zero.CoverLCP(probe); ok {
+		t.Error("zero tree, CoverLCP, got true, want false")
+	}
+	if _, ok := zero.CoverSCP(probe); ok {
+		t.Error("zero tree, CoverSCP, got true, want false")
+	}
+	if zero.Intersects(probe) || zero.IsPoint(probe) {
+		t.Error("zero tree, Intersects or IsPoint, got true, want false")
+	}
+	if len(zero.Covers(probe))+len(zero.CoveredBy(probe))+len(zero.Intersections(probe))+
+		len(zero.Precedes(probe))+len(zero.PrecededBy(probe)) != 0 {
+		t.Error("zero tree, list queries, got items, want none")
+	}
+
+	zero.Visit(uintInterval{9, 9}, uintInterval{0, 0}, func(uintInterval) bool {
+		t.Error("zero tree, Visit, visitFn called")
+		return true
+	})
+
+	if err := zero.Fprint(io.Discard); err != nil {
+		t.Errorf("zero tree, Fprint, unexpected error: %v", err)
+	}
+	if err := zero.CheckInvariants(); err != nil {
+		t.Errorf("zero tree, CheckInvariants, unexpected error: %v", err)
+	}
+
+	if zero.Delete(probe) {
+		t.Error("zero tree, Delete, got true, want false")
+	}
+
+	// inserting nothing is fine
+	zero.Insert()
+
+	if _, err := zero.InsertStrict(probe); !errors.Is(err, interval.ErrNoCompareFunc) {
+		t.Errorf("zero tree, InsertStrict, got err %v, want %v", err, interval.ErrNoCompareFunc)
+	}
+
+	func() {
+		defer func() {
+			if err, _ := recover().(error); !errors.Is(err, interval.ErrNoCompareFunc) {
+				t.Errorf("zero tree, Insert, got panic %v, want %v", err, interval.ErrNoCompareFunc)
+			}
+		}()
+		zero.Insert(probe)
+	}()
+
+	// union adopts the compare function
+	zero.Union(interval.NewTree(cmpUintInterval, ps...), false)
+	zero.Insert(probe)
+
+	want := interval.NewTree(cmpUintInterval, append(ps, probe)...)
+	if !equalsSizeAndOrder(&zero, want) {
+		t.Error("zero tree after Union and Insert, differs from NewTree")
+	}
+}